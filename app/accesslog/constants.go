@@ -0,0 +1,37 @@
+package accesslog
+
+const (
+	// LevelKey is the key of the minimum level of the logs
+	LevelKey = "LOG_LEVEL"
+
+	// RequestBodiesKey is the key of the flag that adds the redacted JSON request bodies to the access logs
+	RequestBodiesKey = "LOG_REQUEST_BODIES"
+
+	// DefaultLevel is the default minimum level of the logs
+	DefaultLevel = "info"
+
+	// RedactedValue is the value that replaces the redacted data
+	RedactedValue = "[REDACTED]"
+
+	// UnmatchedRoute is the route logged for the requests that did not match any route
+	UnmatchedRoute = "unmatched"
+
+	// maxRequestBodySize is the maximum size of a request body added to the access logs
+	maxRequestBodySize = 64 << 10
+
+	// recorderKey is the key of the backend call recorder in the Gin context
+	recorderKey = "accesslog.recorder"
+)
+
+// Levels are the accepted minimum levels of the logs
+var Levels = []string{"debug", "info", "warn", "error"}
+
+// sensitiveKeys are the lowercase substrings of the header, metadata, body field, path parameter and log attribute
+// names whose values are redacted
+var sensitiveKeys = []string{
+	"authorization",
+	"password",
+	"token",
+	"secret",
+	"cookie",
+}
@@ -0,0 +1,10 @@
+package accesslog
+
+import (
+	"errors"
+)
+
+var (
+	NilLoggerError     = errors.New("nil logger")
+	NilIdentifierError = errors.New("nil JWT identifier")
+)
@@ -0,0 +1,44 @@
+package accesslog
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryClientInterceptor records the status code of every backend call in the recorder of the request, so it can be
+// added to its access log
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if recorder := recorderFromContext(ctx); recorder != nil {
+			recorder.record(method, status.Code(err))
+		}
+		return err
+	}
+}
+
+// OutgoingMetadataInterceptor logs the outgoing metadata of every backend call at debug level, with the
+// authorization and any other sensitive value redacted. It must be placed after the authentication interceptor
+func (l *Logger) OutgoingMetadataInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		l.OutgoingMetadata(ctx, method, RedactMetadata(md))
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
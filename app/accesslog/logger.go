@@ -0,0 +1,45 @@
+package accesslog
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+)
+
+// Logger is the logger for the access logs
+type Logger struct {
+	logger *slog.Logger
+}
+
+// NewLogger creates a new access logger
+func NewLogger(logger *slog.Logger) (*Logger, error) {
+	// Check if the logger is nil
+	if logger == nil {
+		return nil, NilLoggerError
+	}
+
+	return &Logger{logger: logger}, nil
+}
+
+// Request logs a handled request, at error level for the server errors, at warning level for the client errors and at
+// info level otherwise
+func (l *Logger) Request(ctx context.Context, statusCode int, attributes ...slog.Attr) {
+	level := slog.LevelInfo
+	if statusCode >= http.StatusInternalServerError {
+		level = slog.LevelError
+	} else if statusCode >= http.StatusBadRequest {
+		level = slog.LevelWarn
+	}
+	l.logger.LogAttrs(ctx, level, "request handled", attributes...)
+}
+
+// OutgoingMetadata logs the redacted outgoing metadata of a backend call
+func (l *Logger) OutgoingMetadata(ctx context.Context, method string, metadata map[string][]string) {
+	l.logger.LogAttrs(
+		ctx,
+		slog.LevelDebug,
+		"outgoing backend call",
+		slog.String("method", method),
+		slog.Any("metadata", metadata),
+	)
+}
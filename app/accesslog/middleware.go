@@ -0,0 +1,109 @@
+package accesslog
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	appjwt "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/jwt"
+	apprequestid "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/requestid"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// Middleware writes a structured access log line for every request
+type Middleware struct {
+	logger        *Logger
+	identifier    *appjwt.Identifier
+	requestBodies bool
+}
+
+// NewMiddleware creates a new access log middleware, which adds the redacted JSON request bodies to the logs if
+// requestBodies is set
+func NewMiddleware(logger *Logger, identifier *appjwt.Identifier, requestBodies bool) (*Middleware, error) {
+	// Check if the logger or the identifier are nil
+	if logger == nil {
+		return nil, NilLoggerError
+	}
+	if identifier == nil {
+		return nil, NilIdentifierError
+	}
+
+	return &Middleware{
+		logger:        logger,
+		identifier:    identifier,
+		requestBodies: requestBodies,
+	}, nil
+}
+
+// Log logs every request once it has been handled, with its route template, status, latency, the user ID of its JWT,
+// its request ID and the status code of the backend call, if any
+func (m *Middleware) Log() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+
+		// Record the backend calls made while handling the request
+		recorder := &Recorder{}
+		ctx.Set(recorderKey, recorder)
+
+		// Read the request body before it is consumed by the handlers
+		var body []byte
+		if m.requestBodies {
+			body = m.peekBody(ctx)
+		}
+
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = UnmatchedRoute
+		}
+		statusCode := ctx.Writer.Status()
+		attributes := []slog.Attr{
+			slog.String("method", ctx.Request.Method),
+			slog.String("route", route),
+			slog.String("path", RedactPath(ctx)),
+			slog.Int("status", statusCode),
+			slog.Duration("latency", time.Since(start)),
+			slog.Int("size", ctx.Writer.Size()),
+			slog.String("client_ip", ctx.ClientIP()),
+			slog.String("request_id", apprequestid.FromGinContext(ctx)),
+		}
+		if userID := m.identifier.UserID(ctx); userID != "" {
+			attributes = append(attributes, slog.String("user_id", userID))
+		}
+		if method, code, ok := recorder.Backend(); ok {
+			attributes = append(
+				attributes,
+				slog.String("backend_method", method),
+				slog.String("backend_code", code.String()),
+			)
+		}
+		if len(ctx.Errors) > 0 {
+			attributes = append(attributes, slog.String("errors", RedactEmails(ctx.Errors.String())))
+		}
+		if body != nil {
+			if document, ok := RedactJSON(body); ok {
+				attributes = append(attributes, slog.Any("body", document))
+			}
+		}
+		m.logger.Request(ctx.Request.Context(), statusCode, attributes...)
+	}
+}
+
+// peekBody reads the JSON request body, up to the maximum logged size, and restores it for the handlers
+func (m *Middleware) peekBody(ctx *gin.Context) []byte {
+	if ctx.Request.Body == nil || !strings.HasPrefix(ctx.ContentType(), "application/json") {
+		return nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(ctx.Request.Body, maxRequestBodySize+1))
+	ctx.Request.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), ctx.Request.Body), ctx.Request.Body}
+	if err != nil || len(body) > maxRequestBodySize {
+		return nil
+	}
+	return body
+}
@@ -0,0 +1,62 @@
+package accesslog
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"sync"
+)
+
+type (
+	// Recorder records the outcome of the backend gRPC calls made while handling a request
+	Recorder struct {
+		mutex  sync.Mutex
+		method string
+		code   codes.Code
+		called bool
+	}
+
+	// recorderCtxKey is the type of the key of the recorder in a context
+	recorderCtxKey struct{}
+)
+
+// record records the status code of a backend call, keeping the first failed one over the successful ones
+func (r *Recorder) record(method string, code codes.Code) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.called && r.code != codes.OK {
+		return
+	}
+	r.method = method
+	r.code = code
+	r.called = true
+}
+
+// Backend returns the method and the status code of the recorded backend call, if any
+func (r *Recorder) Backend() (method string, code codes.Code, ok bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.method, r.code, r.called
+}
+
+// RecorderFromGinContext returns the recorder of the request stored in the Gin context, or nil if there is none
+func RecorderFromGinContext(ctx *gin.Context) *Recorder {
+	recorder, ok := ctx.Get(recorderKey)
+	if !ok {
+		return nil
+	}
+	return recorder.(*Recorder)
+}
+
+// ContextWithRecorder returns a copy of the context that carries the recorder
+func ContextWithRecorder(ctx context.Context, recorder *Recorder) context.Context {
+	return context.WithValue(ctx, recorderCtxKey{}, recorder)
+}
+
+// recorderFromContext returns the recorder carried by the context, or nil if there is none
+func recorderFromContext(ctx context.Context) *Recorder {
+	recorder, _ := ctx.Value(recorderCtxKey{}).(*Recorder)
+	return recorder
+}
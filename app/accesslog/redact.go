@@ -0,0 +1,122 @@
+package accesslog
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// emailRegex matches the email addresses, capturing their domain
+var emailRegex = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@([A-Za-z0-9\-]+(?:\.[A-Za-z0-9\-]+)+)`)
+
+// IsSensitive checks if the value of the header, metadata, body field, path parameter or log attribute with the given
+// name must be redacted
+func IsSensitive(name string) bool {
+	name = strings.ToLower(name)
+	for _, key := range sensitiveKeys {
+		if strings.Contains(name, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactEmails replaces the local part of every email address in the given text, keeping the domain
+func RedactEmails(text string) string {
+	if !strings.Contains(text, "@") {
+		return text
+	}
+	return emailRegex.ReplaceAllString(text, RedactedValue+"@$1")
+}
+
+// RedactPath rebuilds the path of a request from its route template, redacting the values of the sensitive path
+// parameters, like the reset password tokens, and the email addresses
+func RedactPath(ctx *gin.Context) string {
+	route := ctx.FullPath()
+	if route == "" {
+		return RedactEmails(ctx.Request.URL.Path)
+	}
+
+	segments := strings.Split(route, "/")
+	for index, segment := range segments {
+		if segment == "" || (segment[0] != ':' && segment[0] != '*') {
+			continue
+		}
+
+		name := segment[1:]
+		if IsSensitive(name) {
+			segments[index] = RedactedValue
+		} else {
+			segments[index] = RedactEmails(strings.TrimPrefix(ctx.Param(name), "/"))
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// RedactMetadata returns a copy of the given headers or gRPC metadata with the sensitive values redacted and the
+// email addresses masked
+func RedactMetadata(metadata map[string][]string) map[string][]string {
+	redacted := make(map[string][]string, len(metadata))
+	for name, values := range metadata {
+		redactedValues := make([]string, len(values))
+		for index, value := range values {
+			if IsSensitive(name) {
+				redactedValues[index] = RedactedValue
+			} else {
+				redactedValues[index] = RedactEmails(value)
+			}
+		}
+		redacted[name] = redactedValues
+	}
+	return redacted
+}
+
+// RedactJSON decodes the given JSON document redacting the sensitive fields, like the passwords of the sign-up and
+// change password bodies, and masking the email addresses. It returns false if the document is not valid JSON
+func RedactJSON(data []byte) (interface{}, bool) {
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, false
+	}
+	return redactValue(document), true
+}
+
+// redactValue redacts a decoded JSON value
+func redactValue(value interface{}) interface{} {
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		for key, fieldValue := range typedValue {
+			if IsSensitive(key) {
+				typedValue[key] = RedactedValue
+			} else {
+				typedValue[key] = redactValue(fieldValue)
+			}
+		}
+		return typedValue
+	case []interface{}:
+		for index, item := range typedValue {
+			typedValue[index] = redactValue(item)
+		}
+		return typedValue
+	case string:
+		return RedactEmails(typedValue)
+	default:
+		return value
+	}
+}
+
+// RedactAttr redacts the sensitive attributes and masks the email addresses of every log record, so no logger leaks
+// them by mistake. It is meant to be used as the ReplaceAttr function of the slog handlers
+func RedactAttr(_ []string, attr slog.Attr) slog.Attr {
+	if IsSensitive(attr.Key) {
+		return slog.String(attr.Key, RedactedValue)
+	}
+	if attr.Value.Kind() == slog.KindString {
+		if value := attr.Value.String(); strings.Contains(value, "@") {
+			return slog.String(attr.Key, RedactEmails(value))
+		}
+	}
+	return attr
+}
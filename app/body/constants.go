@@ -0,0 +1,60 @@
+package body
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	// MaxBytesKey is the key of the default maximum size in bytes of the request bodies
+	MaxBytesKey = "BODY_MAX_BYTES"
+
+	// StrictKey is the key of the flag that rejects the request bodies with unknown fields
+	StrictKey = "BODY_STRICT"
+
+	// DefaultMaxBytes is the default maximum size in bytes of the request bodies of the routes without their own one
+	DefaultMaxBytes = 64 << 10
+
+	// JSONContentType is the content type of the JSON request bodies
+	JSONContentType = "application/json"
+
+	// TooLargeErrorCode is the error code of the request bodies that exceed the maximum size of their route
+	TooLargeErrorCode = "PAYLOAD_TOO_LARGE"
+
+	// UnsupportedMediaTypeErrorCode is the error code of the request bodies with an unsupported content type
+	UnsupportedMediaTypeErrorCode = "UNSUPPORTED_MEDIA_TYPE"
+
+	// ginCtxKey is the key of the request body in the Gin context, which is only stored in strict mode
+	ginCtxKey = "body"
+)
+
+var (
+	// specialJSONTypes are the well-known types whose JSON mapping is not an object with their fields
+	specialJSONTypes = map[protoreflect.FullName]bool{
+		"google.protobuf.Any":         true,
+		"google.protobuf.Timestamp":   true,
+		"google.protobuf.Duration":    true,
+		"google.protobuf.FieldMask":   true,
+		"google.protobuf.Struct":      true,
+		"google.protobuf.Value":       true,
+		"google.protobuf.ListValue":   true,
+		"google.protobuf.DoubleValue": true,
+		"google.protobuf.FloatValue":  true,
+		"google.protobuf.Int64Value":  true,
+		"google.protobuf.UInt64Value": true,
+		"google.protobuf.Int32Value":  true,
+		"google.protobuf.UInt32Value": true,
+		"google.protobuf.BoolValue":   true,
+		"google.protobuf.StringValue": true,
+		"google.protobuf.BytesValue":  true,
+	}
+
+	// DefaultRoutes are the maximum sizes in bytes of the request bodies of the routes that differ from the default
+	// one by default, indexed by their route key
+	DefaultRoutes = map[string]int{
+		"POST /api/v1/auth/log-in":                      4 << 10,
+		"POST /api/v1/shops/products/":                  256 << 10,
+		"PUT /api/v1/shops/products/{product-id}":       256 << 10,
+		"POST /api/v1/shops/shops/products/":            256 << 10,
+		"PUT /api/v1/shops/shops/products/{product-id}": 256 << 10,
+	}
+)
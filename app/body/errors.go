@@ -0,0 +1,12 @@
+package body
+
+import (
+	"errors"
+)
+
+var (
+	NonPositiveLimitError     = errors.New("non-positive maximum size of the request bodies")
+	TooLargeError             = errors.New("the request body exceeds the maximum size")
+	UnsupportedMediaTypeError = errors.New("unsupported content type of the request body, expected application/json or application/x-protobuf")
+	FailedToReadBodyError     = errors.New("failed to read the request body")
+)
@@ -0,0 +1,110 @@
+package body
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	appcodec "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/codec"
+	appproblem "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	approute "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/route"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// Middleware bounds the size of the request bodies by the maximum of their route and rejects the ones that are neither
+// JSON nor binary protobuf, before they are read by the rest of the middlewares and decoded by the handlers
+type Middleware struct {
+	defaultMaxBytes int64
+	routeMaxBytes   map[string]int64
+	strict          bool
+}
+
+// NewMiddleware creates a new body middleware with the maximum sizes in bytes of the routes indexed by their route
+// key, like "POST /api/v1/shops/products/", and the default maximum size of the rest. In strict mode, the request
+// bodies with unknown fields are rejected too
+func NewMiddleware(defaultMaxBytes int, routeMaxBytes map[string]int, strict bool) (*Middleware, error) {
+	if defaultMaxBytes <= 0 {
+		return nil, NonPositiveLimitError
+	}
+
+	// Check the maximum sizes and normalize the route keys
+	normalizedMaxBytes := make(map[string]int64, len(routeMaxBytes))
+	for key, maxBytes := range routeMaxBytes {
+		if maxBytes <= 0 {
+			return nil, NonPositiveLimitError
+		}
+		normalizedKey, err := approute.ParseKey(key)
+		if err != nil {
+			return nil, err
+		}
+		normalizedMaxBytes[normalizedKey] = int64(maxBytes)
+	}
+
+	return &Middleware{
+		defaultMaxBytes: int64(defaultMaxBytes),
+		routeMaxBytes:   normalizedMaxBytes,
+		strict:          strict,
+	}, nil
+}
+
+// Handler reads the request body up to the maximum size of its route, answering 413 if it is larger and 415 if it is
+// neither JSON nor binary protobuf, and restores it for the rest of the handlers
+func (m *Middleware) Handler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		// The requests without a body are not checked
+		if ctx.Request.Body == nil || ctx.Request.Body == http.NoBody || ctx.Request.ContentLength == 0 {
+			ctx.Next()
+			return
+		}
+
+		contentType := ctx.GetHeader("Content-Type")
+		if !isJSON(contentType) && !appcodec.IsProtobuf(contentType) {
+			appproblem.New(
+				http.StatusUnsupportedMediaType,
+				UnsupportedMediaTypeErrorCode,
+				UnsupportedMediaTypeError,
+			).Abort(ctx)
+			return
+		}
+
+		maxBytes, ok := m.routeMaxBytes[approute.KeyFromGinContext(ctx)]
+		if !ok {
+			maxBytes = m.defaultMaxBytes
+		}
+		tooLargeErr := fmt.Errorf("%w of %d bytes", TooLargeError, maxBytes)
+
+		// Reject the declared sizes right away, and read one more byte than the maximum to detect the rest
+		if ctx.Request.ContentLength > maxBytes {
+			appproblem.New(http.StatusRequestEntityTooLarge, TooLargeErrorCode, tooLargeErr).Abort(ctx)
+			return
+		}
+		body, err := io.ReadAll(io.LimitReader(ctx.Request.Body, maxBytes+1))
+		if err != nil {
+			appproblem.New(http.StatusBadRequest, appproblem.InvalidArgumentCode, FailedToReadBodyError).Abort(ctx)
+			return
+		}
+		if int64(len(body)) > maxBytes {
+			appproblem.New(http.StatusRequestEntityTooLarge, TooLargeErrorCode, tooLargeErr).Abort(ctx)
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		// Keep the body to look for its unknown fields once its request message is known
+		if m.strict {
+			ctx.Set(ginCtxKey, body)
+		}
+		ctx.Next()
+	}
+}
+
+// isJSON checks if the content type is the one of the JSON documents, which are encoded in UTF-8
+func isJSON(contentType string) bool {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != JSONContentType {
+		return false
+	}
+	charset, ok := params["charset"]
+	return !ok || strings.EqualFold(charset, "utf-8")
+}
@@ -0,0 +1,204 @@
+package body
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	appcodec "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/codec"
+	appvalidation "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/validation"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CheckUnknownFields checks the request body has no fields unknown to its request message, returning an
+// *appvalidation.Error naming each of them if any. The body is only checked in strict mode, and the bodies that are
+// not JSON objects are left to the decoding of the request message. The JSON fields are matched like the codec of the
+// request decodes them, either with encoding/json or protojson. The unknown fields of the binary protobuf bodies, which
+// are kept by the decoded request message, are named by their field numbers
+func CheckUnknownFields(ctx *gin.Context, request proto.Message) error {
+	value, ok := ctx.Get(ginCtxKey)
+	if !ok || request == nil {
+		return nil
+	}
+	body, ok := value.([]byte)
+	if !ok {
+		return nil
+	}
+
+	var violations []appvalidation.Violation
+	if appcodec.IsProtobuf(ctx.GetHeader("Content-Type")) {
+		violations = unknownProtobufFields(request.ProtoReflect(), "")
+	} else {
+		var document any
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		if err := decoder.Decode(&document); err != nil {
+			return nil
+		}
+		boundField := encodingJSONField
+		if appcodec.FromGinContext(ctx).IsProtoJSON() {
+			boundField = protoJSONField
+		}
+		violations = unknownFields(document, request.ProtoReflect().Descriptor(), "", boundField)
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	sort.Slice(
+		violations, func(i, j int) bool {
+			return violations[i].Field < violations[j].Field
+		},
+	)
+	return &appvalidation.Error{Violations: violations}
+}
+
+// unknownFields returns the violations of the fields of the JSON object that are unknown to the given message, as
+// bound by the given function, looking into its nested messages. The fields are named by their proto names separated
+// by dots, prefixed by the given path
+func unknownFields(
+	value any,
+	descriptor protoreflect.MessageDescriptor,
+	path string,
+	boundField func(fields protoreflect.FieldDescriptors, key string) protoreflect.FieldDescriptor,
+) []appvalidation.Violation {
+	object, ok := value.(map[string]any)
+	if !ok || specialJSONTypes[descriptor.FullName()] {
+		return nil
+	}
+
+	var violations []appvalidation.Violation
+	fields := descriptor.Fields()
+	for key, fieldValue := range object {
+		field := boundField(fields, key)
+		if field == nil {
+			violations = append(
+				violations,
+				appvalidation.Violation{Field: path + key, Description: "is not a field of the request"},
+			)
+			continue
+		}
+
+		fieldPath := path + field.TextName()
+		switch {
+		case field.IsMap():
+			if field.MapValue().Kind() != protoreflect.MessageKind {
+				continue
+			}
+			entries, _ := fieldValue.(map[string]any)
+			for entryKey, entry := range entries {
+				violations = append(
+					violations,
+					unknownFields(entry, field.MapValue().Message(), fieldPath+"["+entryKey+"].", boundField)...,
+				)
+			}
+		case field.Kind() != protoreflect.MessageKind && field.Kind() != protoreflect.GroupKind:
+			continue
+		case field.IsList():
+			items, _ := fieldValue.([]any)
+			for index, item := range items {
+				violations = append(
+					violations,
+					unknownFields(item, field.Message(), fieldPath+"["+strconv.Itoa(index)+"].", boundField)...,
+				)
+			}
+		default:
+			violations = append(violations, unknownFields(fieldValue, field.Message(), fieldPath+".", boundField)...)
+		}
+	}
+	return violations
+}
+
+// encodingJSONField returns the field the JSON binding of the handlers decodes the given key into, or nil if the key is
+// ignored by it. The binding decodes the generated structs with encoding/json, which names the fields by their proto
+// names, matching them case-insensitively, and can not decode the members of the oneofs, whose struct fields are
+// interfaces
+func encodingJSONField(fields protoreflect.FieldDescriptors, key string) protoreflect.FieldDescriptor {
+	if field := fields.ByTextName(key); field != nil && !inOneof(field) {
+		return field
+	}
+	for i := 0; i < fields.Len(); i++ {
+		if field := fields.Get(i); !inOneof(field) && strings.EqualFold(field.TextName(), key) {
+			return field
+		}
+	}
+	return nil
+}
+
+// protoJSONField returns the field the protojson decoding of the codec decodes the given key into, or nil if the key
+// is discarded by it. The protojson decoding matches the fields by their JSON names, which are camelCase, or by their
+// proto names, and decodes the members of the oneofs too
+func protoJSONField(fields protoreflect.FieldDescriptors, key string) protoreflect.FieldDescriptor {
+	if field := fields.ByJSONName(key); field != nil {
+		return field
+	}
+	return fields.ByTextName(key)
+}
+
+// inOneof checks if the field is a member of a oneof, other than the synthetic ones of the proto3 optional fields
+func inOneof(field protoreflect.FieldDescriptor) bool {
+	oneof := field.ContainingOneof()
+	return oneof != nil && !oneof.IsSynthetic()
+}
+
+// unknownProtobufFields returns the violations of the unknown fields kept by the given decoded message, looking into
+// its nested messages. The unknown fields are named by their field numbers, prefixed by the given path
+func unknownProtobufFields(message protoreflect.Message, path string) []appvalidation.Violation {
+	var violations []appvalidation.Violation
+	for unknown := message.GetUnknown(); len(unknown) > 0; {
+		number, wireType, length := protowire.ConsumeTag(unknown)
+		if length < 0 {
+			break
+		}
+		fieldLength := protowire.ConsumeFieldValue(number, wireType, unknown[length:])
+		if fieldLength < 0 {
+			break
+		}
+		unknown = unknown[length+fieldLength:]
+
+		violations = append(
+			violations,
+			appvalidation.Violation{
+				Field:       path + "#" + strconv.Itoa(int(number)),
+				Description: "is not a field of the request",
+			},
+		)
+	}
+
+	message.Range(
+		func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+			fieldPath := path + field.TextName()
+			switch {
+			case field.IsMap():
+				if field.MapValue().Kind() != protoreflect.MessageKind {
+					return true
+				}
+				value.Map().Range(
+					func(key protoreflect.MapKey, entry protoreflect.Value) bool {
+						violations = append(
+							violations,
+							unknownProtobufFields(entry.Message(), fieldPath+"["+key.String()+"].")...,
+						)
+						return true
+					},
+				)
+			case field.Kind() != protoreflect.MessageKind && field.Kind() != protoreflect.GroupKind:
+			case field.IsList():
+				list := value.List()
+				for index := 0; index < list.Len(); index++ {
+					violations = append(
+						violations,
+						unknownProtobufFields(list.Get(index).Message(), fieldPath+"["+strconv.Itoa(index)+"].")...,
+					)
+				}
+			default:
+				violations = append(violations, unknownProtobufFields(value.Message(), fieldPath+".")...)
+			}
+			return true
+		},
+	)
+	return violations
+}
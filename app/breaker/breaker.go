@@ -0,0 +1,152 @@
+package breaker
+
+import (
+	"google.golang.org/grpc/status"
+	"slices"
+	"sync"
+	"time"
+)
+
+type (
+	// breaker is the circuit breaker of a backend service
+	breaker struct {
+		service    string
+		policy     Policy
+		logger     *Logger
+		mutex      sync.Mutex
+		state      State
+		generation uint64
+		failures   int
+		openedAt   time.Time
+		probes     int
+		successes  int
+	}
+
+	// Status is the exposed state of the circuit breaker of a backend service
+	Status struct {
+		Service             string     `json:"service"`
+		State               State      `json:"state"`
+		ConsecutiveFailures int        `json:"consecutive_failures"`
+		OpenedAt            *time.Time `json:"opened_at,omitempty"`
+		RetryAt             *time.Time `json:"retry_at,omitempty"`
+	}
+)
+
+// newBreaker creates a new closed circuit breaker for the given backend service
+func newBreaker(service string, policy Policy, logger *Logger) *breaker {
+	return &breaker{
+		service: service,
+		policy:  policy,
+		logger:  logger,
+	}
+}
+
+// allow checks if a call can go through. It returns the generation of the state the call was allowed in, which must
+// be given back when recording its outcome, or the time left until the circuit is probed again if it was rejected
+func (b *breaker) allow(now time.Time) (generation uint64, retryAfter time.Duration, allowed bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	// Probe the backend service once the circuit has been open long enough
+	if b.state == OpenState {
+		retryAt := b.openedAt.Add(b.policy.OpenDuration)
+		if now.Before(retryAt) {
+			return 0, retryAt.Sub(now), false
+		}
+		b.setState(HalfOpenState)
+	}
+
+	if b.state == HalfOpenState {
+		if b.probes >= b.policy.HalfOpenProbes {
+			return 0, time.Second, false
+		}
+		b.probes++
+	}
+	return b.generation, 0, true
+}
+
+// record records the outcome of a call allowed in the given generation
+func (b *breaker) record(generation uint64, err error, now time.Time) {
+	failed := IsFailure(err)
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	// Ignore the calls allowed before the last change of state
+	if generation != b.generation {
+		return
+	}
+
+	switch b.state {
+	case ClosedState:
+		if !failed {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failures >= b.policy.FailureThreshold {
+			b.open(now)
+		}
+	case HalfOpenState:
+		b.probes--
+		if failed {
+			b.failures++
+			b.open(now)
+			return
+		}
+		b.successes++
+		if b.successes >= b.policy.HalfOpenProbes {
+			b.failures = 0
+			b.setState(ClosedState)
+		}
+	}
+}
+
+// release releases a call allowed in the given generation without recording its outcome
+func (b *breaker) release(generation uint64) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if generation == b.generation && b.state == HalfOpenState {
+		b.probes--
+	}
+}
+
+// open opens the circuit
+func (b *breaker) open(now time.Time) {
+	b.openedAt = now
+	b.setState(OpenState)
+}
+
+// setState changes the state of the circuit, resetting the probes and starting a new generation
+func (b *breaker) setState(state State) {
+	b.logger.StateChanged(b.service, b.state, state, b.failures)
+	b.state = state
+	b.generation++
+	b.probes = 0
+	b.successes = 0
+}
+
+// status returns the exposed state of the circuit breaker
+func (b *breaker) status() Status {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	status := Status{
+		Service:             b.service,
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+	}
+	if b.state != ClosedState {
+		openedAt := b.openedAt
+		retryAt := openedAt.Add(b.policy.OpenDuration)
+		status.OpenedAt = &openedAt
+		status.RetryAt = &retryAt
+	}
+	return status
+}
+
+// IsFailure checks if the error is a failure of the backend service itself
+func IsFailure(err error) bool {
+	return err != nil && slices.Contains(FailureCodes, status.Code(err))
+}
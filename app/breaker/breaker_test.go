@@ -0,0 +1,230 @@
+package breaker
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+type (
+	// breakerAction is what a step does with the circuit breaker
+	breakerAction int
+
+	// breakerStep is a call allowed through the circuit breaker, the outcome of an allowed call, or the time passing
+	breakerStep struct {
+		action breakerAction
+
+		// call is the index of the allowed call whose outcome is recorded or that is released
+		call int
+		err  error
+
+		wantAllowed bool
+		wantState   State
+	}
+
+	// breakerCase is a sequence of steps and the state of the circuit after each of them
+	breakerCase struct {
+		name  string
+		steps []breakerStep
+	}
+)
+
+const (
+	// allowAction allows a call through the circuit breaker
+	allowAction breakerAction = iota
+
+	// recordAction records the outcome of an allowed call
+	recordAction
+
+	// releaseAction releases an allowed call without recording its outcome
+	releaseAction
+
+	// waitAction waits until the open circuit is probed again
+	waitAction
+)
+
+var (
+	// testPolicy opens the circuit after two consecutive failures, and closes it after two successful probes
+	testPolicy = Policy{FailureThreshold: 2, OpenDuration: 30 * time.Second, HalfOpenProbes: 2}
+
+	// unavailableError is an error counted as a failure of the backend service
+	unavailableError = status.Error(codes.Unavailable, "unavailable")
+
+	// invalidArgumentError is an error caused by the request itself
+	invalidArgumentError = status.Error(codes.InvalidArgument, "invalid argument")
+)
+
+// openSteps are the steps that allow three calls and open the circuit with the failures of the first two
+var openSteps = []breakerStep{
+	{action: allowAction, wantAllowed: true, wantState: ClosedState},
+	{action: allowAction, wantAllowed: true, wantState: ClosedState},
+	{action: allowAction, wantAllowed: true, wantState: ClosedState},
+	{action: recordAction, call: 0, err: unavailableError, wantState: ClosedState},
+	{action: recordAction, call: 1, err: unavailableError, wantState: OpenState},
+}
+
+// halfOpenSteps are the steps that open the circuit and allow its two probes, the calls 3 and 4
+var halfOpenSteps = withSteps(
+	openSteps,
+	breakerStep{action: waitAction, wantState: OpenState},
+	breakerStep{action: allowAction, wantAllowed: true, wantState: HalfOpenState},
+	breakerStep{action: allowAction, wantAllowed: true, wantState: HalfOpenState},
+)
+
+// withSteps returns the given steps followed by the other ones
+func withSteps(steps []breakerStep, other ...breakerStep) []breakerStep {
+	return append(steps[:len(steps):len(steps)], other...)
+}
+
+// TestBreaker checks the circuit opens after consecutive failures, is probed once it has been open long enough, and
+// ignores the outcome of the calls allowed before its last change of state
+func TestBreaker(t *testing.T) {
+	logger, err := NewLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("NewLogger() error = %v", err)
+	}
+
+	cases := []breakerCase{
+		{
+			name: "successful call",
+			steps: []breakerStep{
+				{action: allowAction, wantAllowed: true, wantState: ClosedState},
+				{action: recordAction, call: 0, wantState: ClosedState},
+			},
+		},
+		{
+			name: "failures of the request",
+			steps: []breakerStep{
+				{action: allowAction, wantAllowed: true, wantState: ClosedState},
+				{action: allowAction, wantAllowed: true, wantState: ClosedState},
+				{action: recordAction, call: 0, err: invalidArgumentError, wantState: ClosedState},
+				{action: recordAction, call: 1, err: invalidArgumentError, wantState: ClosedState},
+			},
+		},
+		{
+			name: "failures not consecutive",
+			steps: []breakerStep{
+				{action: allowAction, wantAllowed: true, wantState: ClosedState},
+				{action: allowAction, wantAllowed: true, wantState: ClosedState},
+				{action: allowAction, wantAllowed: true, wantState: ClosedState},
+				{action: recordAction, call: 0, err: unavailableError, wantState: ClosedState},
+				{action: recordAction, call: 1, wantState: ClosedState},
+				{action: recordAction, call: 2, err: unavailableError, wantState: ClosedState},
+			},
+		},
+		{
+			name: "open circuit",
+			steps: withSteps(
+				openSteps,
+				breakerStep{action: allowAction, wantAllowed: false, wantState: OpenState},
+			),
+		},
+		{
+			name:  "half-open circuit",
+			steps: halfOpenSteps,
+		},
+		{
+			name: "half-open probes taken",
+			steps: withSteps(
+				halfOpenSteps,
+				breakerStep{action: allowAction, wantAllowed: false, wantState: HalfOpenState},
+			),
+		},
+		{
+			name: "half-open probe released",
+			steps: withSteps(
+				halfOpenSteps,
+				breakerStep{action: releaseAction, call: 3, wantState: HalfOpenState},
+				breakerStep{action: allowAction, wantAllowed: true, wantState: HalfOpenState},
+			),
+		},
+		{
+			name: "half-open probes succeeded",
+			steps: withSteps(
+				halfOpenSteps,
+				breakerStep{action: recordAction, call: 3, wantState: HalfOpenState},
+				breakerStep{action: recordAction, call: 4, wantState: ClosedState},
+				breakerStep{action: allowAction, wantAllowed: true, wantState: ClosedState},
+			),
+		},
+		{
+			name: "half-open probe failed",
+			steps: withSteps(
+				halfOpenSteps,
+				breakerStep{action: recordAction, call: 3, err: unavailableError, wantState: OpenState},
+				breakerStep{action: allowAction, wantAllowed: false, wantState: OpenState},
+			),
+		},
+		{
+			name: "failure of previous generation",
+			steps: withSteps(
+				halfOpenSteps,
+				breakerStep{action: recordAction, call: 2, err: unavailableError, wantState: HalfOpenState},
+			),
+		},
+		{
+			name: "success of previous generation",
+			steps: withSteps(
+				halfOpenSteps,
+				breakerStep{action: recordAction, call: 2, wantState: HalfOpenState},
+				breakerStep{action: allowAction, wantAllowed: false, wantState: HalfOpenState},
+				breakerStep{action: recordAction, call: 3, wantState: HalfOpenState},
+			),
+		},
+		{
+			name: "release of previous generation",
+			steps: withSteps(
+				halfOpenSteps,
+				breakerStep{action: releaseAction, call: 2, wantState: HalfOpenState},
+				breakerStep{action: allowAction, wantAllowed: false, wantState: HalfOpenState},
+			),
+		},
+		{
+			name: "probe of previous half-open circuit",
+			steps: withSteps(
+				halfOpenSteps,
+				breakerStep{action: recordAction, call: 3, err: unavailableError, wantState: OpenState},
+				breakerStep{action: waitAction, wantState: OpenState},
+				breakerStep{action: allowAction, wantAllowed: true, wantState: HalfOpenState},
+				breakerStep{action: recordAction, call: 4, err: unavailableError, wantState: HalfOpenState},
+				breakerStep{action: recordAction, call: 5, wantState: HalfOpenState},
+			),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(
+			c.name, func(t *testing.T) {
+				b := newBreaker("service", testPolicy, logger)
+				now := time.Now()
+
+				var generations []uint64
+				for index, step := range c.steps {
+					switch step.action {
+					case allowAction:
+						generation, _, allowed := b.allow(now)
+						if allowed != step.wantAllowed {
+							t.Fatalf("step %d: allow() allowed = %v, want %v", index, allowed, step.wantAllowed)
+						}
+						if allowed {
+							generations = append(generations, generation)
+						}
+					case recordAction:
+						b.record(generations[step.call], step.err, now)
+					case releaseAction:
+						b.release(generations[step.call])
+					case waitAction:
+						now = now.Add(testPolicy.OpenDuration)
+					}
+
+					if state := b.status().State; state != step.wantState {
+						t.Fatalf("step %d: state = %v, want %v", index, state, step.wantState)
+					}
+				}
+			},
+		)
+	}
+}
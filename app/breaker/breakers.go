@@ -0,0 +1,135 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"sort"
+	"time"
+)
+
+type (
+	// Breakers are the circuit breakers of the backend services, which reject the calls to a failing service right
+	// away instead of waiting for every one of them to fail
+	Breakers struct {
+		breakers map[string]*breaker
+	}
+
+	// openError is the error of a call rejected because the circuit of its backend service is open
+	openError struct {
+		service    string
+		retryAfter time.Duration
+	}
+)
+
+// NewBreakers creates the circuit breakers of the given backend services, with their policies indexed by the service
+// name and the default policy of the rest
+func NewBreakers(
+	services []string,
+	defaultPolicy Policy,
+	servicePolicies map[string]Policy,
+	logger *Logger,
+) (*Breakers, error) {
+	// Check if the logger is nil
+	if logger == nil {
+		return nil, NilLoggerError
+	}
+
+	// Check the policies
+	if err := defaultPolicy.Validate(); err != nil {
+		return nil, err
+	}
+	breakers := make(map[string]*breaker, len(services))
+	for _, service := range services {
+		policy, ok := servicePolicies[service]
+		if !ok {
+			policy = defaultPolicy
+		} else if err := policy.Validate(); err != nil {
+			return nil, err
+		}
+		breakers[service] = newBreaker(service, policy, logger)
+	}
+	for service := range servicePolicies {
+		if _, ok := breakers[service]; !ok {
+			return nil, UnknownServiceError
+		}
+	}
+
+	return &Breakers{breakers: breakers}, nil
+}
+
+// UnaryClientInterceptor rejects the calls to the given backend service while its circuit is open, and records the
+// outcome of the rest. The health checks of the readiness probe are neither rejected nor recorded, so they report the
+// health of the backend service without tripping its circuit or taking its half-open probe
+func (b *Breakers) UnaryClientInterceptor(service string) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		breaker, ok := b.breakers[service]
+		if !ok || method == healthpb.Health_Check_FullMethodName {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		generation, retryAfter, allowed := breaker.allow(time.Now())
+		if !allowed {
+			return &openError{service: service, retryAfter: retryAfter}
+		}
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		// The calls cancelled by the client say nothing about the backend service
+		if ctx.Err() == context.Canceled {
+			breaker.release(generation)
+		} else {
+			breaker.record(generation, err, time.Now())
+		}
+		return err
+	}
+}
+
+// Statuses returns the state of the circuit breakers, sorted by service
+func (b *Breakers) Statuses() []Status {
+	statuses := make([]Status, 0, len(b.breakers))
+	for _, breaker := range b.breakers {
+		statuses = append(statuses, breaker.status())
+	}
+	sort.Slice(
+		statuses, func(i, j int) bool {
+			return statuses[i].Service < statuses[j].Service
+		},
+	)
+	return statuses
+}
+
+// RetryAfter returns the time left until the circuit that rejected the call is probed again, if the error is the one
+// of a rejected call
+func RetryAfter(err error) (time.Duration, bool) {
+	var openErr *openError
+	if !errors.As(err, &openErr) {
+		return 0, false
+	}
+	return openErr.retryAfter, true
+}
+
+// Error returns the message of the error
+func (e *openError) Error() string {
+	return OpenCircuitError.Error() + ": " + e.service
+}
+
+// Unwrap returns the open circuit error, so the error can be checked with errors.Is
+func (e *openError) Unwrap() error {
+	return OpenCircuitError
+}
+
+// GRPCStatus returns the status of the rejected call, so it is reported like any other unavailable service
+func (e *openError) GRPCStatus() *status.Status {
+	return status.New(codes.Unavailable, e.Error())
+}
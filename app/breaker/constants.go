@@ -0,0 +1,34 @@
+package breaker
+
+import (
+	"google.golang.org/grpc/codes"
+	"time"
+)
+
+const (
+	// EnabledKey is the key of the flag that enables the circuit breakers of the backend services
+	EnabledKey = "CIRCUIT_BREAKER_ENABLED"
+
+	// DebugPath is the path of the endpoint that exposes the state of the circuit breakers
+	DebugPath = "/debug/circuit-breakers"
+
+	// OpenErrorCode is the error code of the responses rejected while the circuit of a backend service is open
+	OpenErrorCode = "BACKEND_CIRCUIT_OPEN"
+)
+
+const (
+	// DefaultFailureThreshold is the default number of consecutive failures that opens a circuit
+	DefaultFailureThreshold = 5
+
+	// DefaultOpenDuration is the default time a circuit stays open before probing the backend service again
+	DefaultOpenDuration = 30 * time.Second
+
+	// DefaultHalfOpenProbes is the default number of successful probes that closes a half-open circuit
+	DefaultHalfOpenProbes = 2
+)
+
+var (
+	// FailureCodes are the status codes counted as failures of a backend service, which are the ones of an
+	// unreachable or overloaded service and not the ones caused by the request itself
+	FailureCodes = []codes.Code{codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted}
+)
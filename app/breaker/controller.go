@@ -0,0 +1,51 @@
+package breaker
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// Controller struct for the debug circuit breakers module
+// @Summary Debug Circuit Breakers Router Group
+// @Description Router group for the state of the circuit breakers, only available in development mode
+// @Tags debug
+// @Produce json
+// @Router /debug [group]
+type Controller struct {
+	engine   *gin.Engine
+	breakers *Breakers
+}
+
+// StatusesResponse is the response of the circuit breakers endpoint
+type StatusesResponse struct {
+	Breakers []Status `json:"breakers"`
+}
+
+// NewController creates a new debug circuit breakers controller
+func NewController(engine *gin.Engine, breakers *Breakers) (*Controller, error) {
+	// Check if the circuit breakers are nil
+	if breakers == nil {
+		return nil, NilBreakersError
+	}
+
+	return &Controller{
+		engine:   engine,
+		breakers: breakers,
+	}, nil
+}
+
+// Initialize initializes the routes for the controller
+func (c *Controller) Initialize() {
+	c.engine.GET(DebugPath, c.getStatuses)
+}
+
+// getStatuses gets the state of the circuit breakers
+// @Summary Get the circuit breakers
+// @Description Get the state of the circuit breaker of every backend service
+// @Tags debug
+// @Produce json
+// @Success 200 {object} StatusesResponse
+// @Router /debug/circuit-breakers [get]
+func (c *Controller) getStatuses(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, StatusesResponse{Breakers: c.breakers.Statuses()})
+}
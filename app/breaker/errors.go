@@ -0,0 +1,13 @@
+package breaker
+
+import (
+	"errors"
+)
+
+var (
+	NilLoggerError      = errors.New("nil logger")
+	NilBreakersError    = errors.New("nil circuit breakers")
+	InvalidPolicyError  = errors.New("invalid circuit breaker policy")
+	UnknownServiceError = errors.New("unknown backend service")
+	OpenCircuitError    = errors.New("the backend service is temporarily unavailable")
+)
@@ -0,0 +1,38 @@
+package breaker
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Logger is the logger for the circuit breakers
+type Logger struct {
+	logger *slog.Logger
+}
+
+// NewLogger creates a new circuit breaker logger
+func NewLogger(logger *slog.Logger) (*Logger, error) {
+	// Check if the logger is nil
+	if logger == nil {
+		return nil, NilLoggerError
+	}
+
+	return &Logger{logger: logger}, nil
+}
+
+// StateChanged logs that the circuit of a backend service changed its state
+func (l *Logger) StateChanged(service string, from, to State, failures int) {
+	level := slog.LevelInfo
+	if to == OpenState {
+		level = slog.LevelWarn
+	}
+	l.logger.Log(
+		context.Background(),
+		level,
+		"circuit breaker state changed",
+		slog.String("service", service),
+		slog.String("from", from.String()),
+		slog.String("to", to.String()),
+		slog.Int("consecutive_failures", failures),
+	)
+}
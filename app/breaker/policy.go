@@ -0,0 +1,22 @@
+package breaker
+
+import (
+	"time"
+)
+
+// Policy is the circuit breaker policy of a backend service. The circuit opens after FailureThreshold consecutive
+// failures, rejecting every call for OpenDuration. Then it becomes half-open, letting up to HalfOpenProbes calls
+// through: it closes once all of them succeed, and opens again as soon as any of them fails
+type Policy struct {
+	FailureThreshold int
+	OpenDuration     time.Duration
+	HalfOpenProbes   int
+}
+
+// Validate checks that the policy can be used
+func (p Policy) Validate() error {
+	if p.FailureThreshold <= 0 || p.OpenDuration <= 0 || p.HalfOpenProbes <= 0 {
+		return InvalidPolicyError
+	}
+	return nil
+}
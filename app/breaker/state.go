@@ -0,0 +1,32 @@
+package breaker
+
+// State is the state of a circuit
+type State int
+
+const (
+	// ClosedState lets every call through
+	ClosedState State = iota
+
+	// OpenState rejects every call
+	OpenState
+
+	// HalfOpenState lets a few probe calls through
+	HalfOpenState
+)
+
+// String returns the name of the state
+func (s State) String() string {
+	switch s {
+	case OpenState:
+		return "open"
+	case HalfOpenState:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// MarshalText marshals the state as its name
+func (s State) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
@@ -0,0 +1,22 @@
+package bruteforce
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// failedAttemptCodes are the status codes of the auth service that mean the credentials of a log-in attempt were
+// wrong, whether the user does not exist or the password does not match
+var failedAttemptCodes = map[codes.Code]bool{
+	codes.Unauthenticated:  true,
+	codes.PermissionDenied: true,
+	codes.NotFound:         true,
+	codes.InvalidArgument:  true,
+}
+
+// IsFailedAttempt checks if the error returned by the auth service to a log-in attempt means its credentials were
+// wrong, which must be answered with InvalidCredentialsError so an unknown username, a wrong password and a locked
+// out account cannot be told apart
+func IsFailedAttempt(err error) bool {
+	return err != nil && failedAttemptCodes[status.Code(err)]
+}
@@ -0,0 +1,37 @@
+package bruteforce
+
+import (
+	"time"
+)
+
+const (
+	// EnabledKey is the key of the flag that enables the log-in brute-force protection
+	EnabledKey = "LOGIN_PROTECTION_ENABLED"
+
+	// AdminRoleKey is the key of the name of the role whose users can get the lockout state
+	AdminRoleKey = "LOGIN_ADMIN_ROLE"
+
+	// DefaultAdminRole is the default name of the role whose users can get the lockout state
+	DefaultAdminRole = "admin"
+
+	// UsernameKind is the kind of the attempts tracked per username
+	UsernameKind = "username"
+
+	// IPKind is the kind of the attempts tracked per client IP
+	IPKind = "ip"
+)
+
+const (
+	// cleanupInterval is the interval the expired entries are removed at
+	cleanupInterval = time.Minute
+
+	// maxEntries is the maximum number of tracked usernames and IPs, the oldest unlocked ones are evicted beyond it,
+	// and of the IPs known to the usernames, which are no longer added beyond it
+	maxEntries = 100000
+
+	// knownIPTTL is the time an IP stays known to a username after its last successful log-in from it
+	knownIPTTL = 30 * 24 * time.Hour
+
+	// latencySmoothing is the number of failed attempts the moving average of their latency is smoothed over
+	latencySmoothing = 16
+)
@@ -0,0 +1,156 @@
+package bruteforce
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
+	appmapper "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/mapper"
+	appproblem "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
+	commonginctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/context"
+	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
+	commonjwt "github.com/pixel-plaza-dev/uru-databases-2-go-service-common/crypto/jwt"
+	pbauth "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/auth"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"net/http"
+	"strings"
+)
+
+// Controller struct for the log-in lockouts module
+// @Summary Log-in Lockouts Router Group
+// @Description Router group for the lockout state of the log-in brute-force protection, only available to the users with the admin role
+// @Tags v1 auth
+// @Produce json
+// @Router /api/v1/auth [group]
+type Controller struct {
+	route           *gin.RouterGroup
+	guard           *Guard
+	client          pbauth.AuthClient
+	routeHandler    commonhandler.Handler
+	responseHandler *appresponse.Handler
+	adminRole       string
+}
+
+// LockoutsResponse is the response of the lockouts endpoint
+type LockoutsResponse struct {
+	Lockouts []State `json:"lockouts"`
+}
+
+// NewController creates a new log-in lockouts controller, whose callers must have the given admin role, as assigned by
+// the auth service
+func NewController(
+	authRoute *gin.RouterGroup,
+	guard *Guard,
+	client pbauth.AuthClient,
+	routeHandler commonhandler.Handler,
+	responseHandler *appresponse.Handler,
+	adminRole string,
+) *Controller {
+	return &Controller{
+		route:           authRoute,
+		guard:           guard,
+		client:          client,
+		routeHandler:    routeHandler,
+		responseHandler: responseHandler,
+		adminRole:       adminRole,
+	}
+}
+
+// Initialize initializes the routes for the controller
+func (c *Controller) Initialize() {
+	c.route.GET(c.routeHandler.CreateAuthenticatedEndpoint(appmapper.GetLockoutsMapper, c.getLockouts))
+}
+
+// getLockouts gets the state of the usernames and IPs with failed log-in attempts
+// @Summary Get the log-in lockouts
+// @Description Get the failed log-in attempts, backoffs and lockouts of the tracked usernames and IPs
+// @Tags v1 auth
+// @Produce json
+// @Param kind query string false "Filter by kind" Enums(username, ip)
+// @Param value query string false "Filter by username or IP"
+// @Param locked query bool false "Only return the locked out usernames and IPs"
+// @Success 200 {object} LockoutsResponse
+// @Failure 401 {object} _.Problem
+// @Failure 403 {object} _.Problem
+// @Security BearerAuth
+// @Router /api/v1/auth/lockouts [get]
+func (c *Controller) getLockouts(ctx *gin.Context) {
+	// Prepare the gRPC context
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, nil)
+	if err != nil {
+		c.responseHandler.HandlePrepareCtxError(ctx, err)
+		return
+	}
+
+	// Get the user ID of the caller from the claims of its verified token
+	claims, err := commonginctx.GetCtxTokenClaims(ctx)
+	if err != nil {
+		appproblem.New(http.StatusUnauthorized, appproblem.UnauthenticatedCode, err).Write(ctx)
+		return
+	}
+	userId, ok := (*claims)[commonjwt.UserIdClaim].(string)
+	if !ok || userId == "" {
+		appproblem.New(http.StatusUnauthorized, appproblem.UnauthenticatedCode, MissingUserIdClaimError).Write(ctx)
+		return
+	}
+
+	// Check if the caller has the admin role
+	isAdmin, err := c.isAdmin(grpcCtx, userId)
+	if err != nil {
+		c.responseHandler.HandleResponse(ctx, http.StatusOK, nil, err)
+		return
+	}
+	if !isAdmin {
+		appproblem.New(http.StatusForbidden, appproblem.PermissionDeniedCode, NotAdminError).Write(ctx)
+		return
+	}
+
+	// Filter the states
+	kind := ctx.Query("kind")
+	value := ctx.Query("value")
+	onlyLocked := ctx.Query("locked") == "true"
+
+	lockouts := make([]State, 0)
+	for _, state := range c.guard.States() {
+		if kind != "" && state.Kind != kind {
+			continue
+		}
+		if value != "" && !strings.EqualFold(state.Value, value) {
+			continue
+		}
+		if onlyLocked && !state.Locked {
+			continue
+		}
+		lockouts = append(lockouts, state)
+	}
+	ctx.JSON(http.StatusOK, LockoutsResponse{Lockouts: lockouts})
+}
+
+// isAdmin checks if any of the roles of the user, as assigned by the auth service, is the admin role
+func (c *Controller) isAdmin(grpcCtx context.Context, userId string) (bool, error) {
+	// Get the roles of the user
+	userRoles, err := c.client.GetUserRoles(grpcCtx, &pbauth.GetUserRolesRequest{UserId: userId})
+	if err != nil {
+		return false, err
+	}
+	if len(userRoles.GetRolesId()) == 0 {
+		return false, nil
+	}
+
+	// Get the names of the roles, since the user roles are only referenced by their IDs
+	roles, err := c.client.GetRoles(grpcCtx, &emptypb.Empty{})
+	if err != nil {
+		return false, err
+	}
+	for _, role := range roles.GetRoles() {
+		if role.GetName() != c.adminRole {
+			continue
+		}
+		for _, roleId := range userRoles.GetRolesId() {
+			if roleId == role.GetRoleId() {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,13 @@
+package bruteforce
+
+import (
+	"errors"
+)
+
+var (
+	NilLoggerError          = errors.New("nil logger")
+	InvalidPolicyError      = errors.New("invalid log-in protection policy")
+	InvalidCredentialsError = errors.New("invalid username or password")
+	MissingUserIdClaimError = errors.New("missing user id claim in the token")
+	NotAdminError           = errors.New("only the admins can get the log-in lockouts")
+)
@@ -0,0 +1,294 @@
+package bruteforce
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// Guard tracks the failed log-in attempts per username and per client IP in memory, rejecting the attempts of
+	// the usernames and IPs that are backing off or locked out. The IPs are only tracked if the IP of the clients is
+	// trusted, since it is the one of the proxy in front of the gateway otherwise. The IPs a username successfully
+	// logged in from are known to it, and are not blocked by its lockout, so anyone can not lock a user out of their
+	// account by failing its log-in on purpose
+	Guard struct {
+		mutex          sync.Mutex
+		entries        map[string]*entry
+		knownIPs       map[string]time.Time
+		usernamePolicy Policy
+		ipPolicy       Policy
+		trackIPs       bool
+		failureLatency time.Duration
+		logger         *Logger
+		stop           chan struct{}
+		done           chan struct{}
+	}
+
+	// entry is the state of the failed attempts of a username or an IP
+	entry struct {
+		kind          string
+		value         string
+		failures      int
+		lastFailureAt time.Time
+		blockedUntil  time.Time
+		lockedUntil   time.Time
+	}
+
+	// State is the exposed state of the failed attempts of a username or an IP
+	State struct {
+		Kind          string     `json:"kind"`
+		Value         string     `json:"value"`
+		Failures      int        `json:"failures"`
+		LastFailureAt time.Time  `json:"last_failure_at"`
+		BlockedUntil  *time.Time `json:"blocked_until,omitempty"`
+		Locked        bool       `json:"locked"`
+	}
+)
+
+// NewGuard creates a new log-in guard with the given policies for the usernames and the IPs, which removes the
+// expired entries in the background until it is closed. The IPs are only tracked if they are trusted
+func NewGuard(usernamePolicy, ipPolicy Policy, trackIPs bool, logger *Logger) (*Guard, error) {
+	// Check if the logger is nil
+	if logger == nil {
+		return nil, NilLoggerError
+	}
+
+	// Check the policies
+	if err := usernamePolicy.Validate(); err != nil {
+		return nil, err
+	}
+	if err := ipPolicy.Validate(); err != nil {
+		return nil, err
+	}
+
+	guard := &Guard{
+		entries:        make(map[string]*entry),
+		knownIPs:       make(map[string]time.Time),
+		usernamePolicy: usernamePolicy,
+		ipPolicy:       ipPolicy,
+		trackIPs:       trackIPs,
+		logger:         logger,
+		stop:           make(chan struct{}),
+		done:           make(chan struct{}),
+	}
+	go guard.cleanup()
+	return guard, nil
+}
+
+// Allow checks if a log-in attempt of the username from the IP is allowed, which is not the case if any of them is
+// backing off or locked out. The username does not block the attempts from the IPs known to it
+func (g *Guard) Allow(username, ip string) bool {
+	now := time.Now()
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	var keys []string
+	if !g.isKnownIP(username, ip, now) {
+		keys = append(keys, usernameKey(username))
+	}
+	if g.trackIPs {
+		keys = append(keys, ipKey(ip))
+	}
+	for _, key := range keys {
+		if e, ok := g.entries[key]; ok && now.Before(e.blockedUntil) {
+			g.logger.AttemptRejected(e.kind, e.value, e.blockedUntil)
+			return false
+		}
+	}
+	return true
+}
+
+// Failure records a failed log-in attempt of the username from the IP, and the time the auth service took to answer
+// it
+func (g *Guard) Failure(username, ip string, latency time.Duration) {
+	now := time.Now()
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.fail(UsernameKind, normalizeUsername(username), g.usernamePolicy, now)
+	if g.trackIPs {
+		g.fail(IPKind, ip, g.ipPolicy, now)
+	}
+
+	// Keep the moving average of the latency of the failed attempts
+	if g.failureLatency == 0 {
+		g.failureLatency = latency
+	} else {
+		g.failureLatency += (latency - g.failureLatency) / latencySmoothing
+	}
+}
+
+// Success records a successful log-in of the username from the IP, forgetting its failures and knowing the IP to it.
+// The failures of the IP are kept, so a valid account does not reset the IPs used for credential stuffing
+func (g *Guard) Success(username, ip string) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	delete(g.entries, usernameKey(username))
+	if g.trackIPs && (len(g.knownIPs) < maxEntries || g.isKnownIP(username, ip, time.Now())) {
+		g.knownIPs[knownIPKey(username, ip)] = time.Now().Add(knownIPTTL)
+	}
+}
+
+// RejectionDelay returns the time a rejected attempt must wait before being answered, which is the average time the
+// auth service takes to answer the failed attempts, so a locked out account can not be told apart from wrong
+// credentials by the latency of its answer
+func (g *Guard) RejectionDelay() time.Duration {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return g.failureLatency
+}
+
+// States returns the state of the tracked usernames and IPs, the locked ones first
+func (g *Guard) States() []State {
+	now := time.Now()
+
+	g.mutex.Lock()
+	states := make([]State, 0, len(g.entries))
+	for _, e := range g.entries {
+		state := State{
+			Kind:          e.kind,
+			Value:         e.value,
+			Failures:      e.failures,
+			LastFailureAt: e.lastFailureAt,
+			Locked:        now.Before(e.lockedUntil),
+		}
+		if now.Before(e.blockedUntil) {
+			blockedUntil := e.blockedUntil
+			state.BlockedUntil = &blockedUntil
+		}
+		states = append(states, state)
+	}
+	g.mutex.Unlock()
+
+	sort.Slice(
+		states, func(i, j int) bool {
+			if states[i].Locked != states[j].Locked {
+				return states[i].Locked
+			}
+			return states[i].LastFailureAt.After(states[j].LastFailureAt)
+		},
+	)
+	return states
+}
+
+// Close stops removing the expired entries
+func (g *Guard) Close() error {
+	close(g.stop)
+	<-g.done
+	return nil
+}
+
+// isKnownIP checks if the username successfully logged in from the IP, which must be called with the mutex locked
+func (g *Guard) isKnownIP(username, ip string, now time.Time) bool {
+	if !g.trackIPs {
+		return false
+	}
+	expiresAt, ok := g.knownIPs[knownIPKey(username, ip)]
+	return ok && now.Before(expiresAt)
+}
+
+// fail records a failure of the username or IP, which must be called with the mutex locked
+func (g *Guard) fail(kind, value string, policy Policy, now time.Time) {
+	key := kind + ":" + value
+	e, ok := g.entries[key]
+	if !ok {
+		g.evictIfFull(now)
+		e = &entry{kind: kind, value: value}
+		g.entries[key] = e
+	}
+
+	// Forget the failures that are too old
+	if now.Sub(e.lastFailureAt) > policy.Window {
+		e.failures = 0
+	}
+	e.failures++
+	e.lastFailureAt = now
+	e.blockedUntil = now.Add(policy.delay(e.failures))
+
+	// Lock out the username or IP
+	if e.failures >= policy.LockoutAfter {
+		e.lockedUntil = now.Add(policy.LockoutDuration)
+		e.blockedUntil = e.lockedUntil
+		g.logger.LockedOut(kind, value, e.failures, e.lockedUntil)
+	}
+}
+
+// evictIfFull evicts the oldest unlocked entry if the maximum number of entries has been reached, which must be
+// called with the mutex locked
+func (g *Guard) evictIfFull(now time.Time) {
+	if len(g.entries) < maxEntries {
+		return
+	}
+
+	var oldestKey string
+	var oldest *entry
+	for key, e := range g.entries {
+		if now.Before(e.lockedUntil) {
+			continue
+		}
+		if oldest == nil || e.lastFailureAt.Before(oldest.lastFailureAt) {
+			oldestKey, oldest = key, e
+		}
+	}
+	if oldest != nil {
+		delete(g.entries, oldestKey)
+	}
+}
+
+// cleanup removes periodically the entries that are no longer blocked and whose failures have been forgotten
+func (g *Guard) cleanup() {
+	defer close(g.done)
+
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-g.stop:
+			return
+		case now := <-ticker.C:
+			g.mutex.Lock()
+			for key, e := range g.entries {
+				policy := g.ipPolicy
+				if e.kind == UsernameKind {
+					policy = g.usernamePolicy
+				}
+				if !now.Before(e.blockedUntil) && now.Sub(e.lastFailureAt) > policy.Window {
+					delete(g.entries, key)
+				}
+			}
+			for key, expiresAt := range g.knownIPs {
+				if !now.Before(expiresAt) {
+					delete(g.knownIPs, key)
+				}
+			}
+			g.mutex.Unlock()
+		}
+	}
+}
+
+// normalizeUsername normalizes a username, so the attempts with different casing are tracked together
+func normalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+// usernameKey returns the key of the entry of a username
+func usernameKey(username string) string {
+	return UsernameKind + ":" + normalizeUsername(username)
+}
+
+// ipKey returns the key of the entry of an IP
+func ipKey(ip string) string {
+	return IPKind + ":" + ip
+}
+
+// knownIPKey returns the key of an IP known to a username
+func knownIPKey(username, ip string) string {
+	return normalizeUsername(username) + "\n" + ip
+}
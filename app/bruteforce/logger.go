@@ -0,0 +1,42 @@
+package bruteforce
+
+import (
+	"log/slog"
+	"time"
+)
+
+// Logger is the logger for the log-in guard
+type Logger struct {
+	logger *slog.Logger
+}
+
+// NewLogger creates a new log-in guard logger
+func NewLogger(logger *slog.Logger) (*Logger, error) {
+	// Check if the logger is nil
+	if logger == nil {
+		return nil, NilLoggerError
+	}
+
+	return &Logger{logger: logger}, nil
+}
+
+// AttemptRejected logs that a log-in attempt was rejected without calling the auth service
+func (l *Logger) AttemptRejected(kind, value string, blockedUntil time.Time) {
+	l.logger.Info(
+		"log-in attempt rejected",
+		slog.String("kind", kind),
+		slog.String("value", value),
+		slog.Time("blocked_until", blockedUntil),
+	)
+}
+
+// LockedOut logs that a username or an IP has been locked out
+func (l *Logger) LockedOut(kind, value string, failures int, lockedUntil time.Time) {
+	l.logger.Warn(
+		"log-in locked out",
+		slog.String("kind", kind),
+		slog.String("value", value),
+		slog.Int("failures", failures),
+		slog.Time("locked_until", lockedUntil),
+	)
+}
@@ -0,0 +1,42 @@
+package bruteforce
+
+import (
+	"time"
+)
+
+// Policy is the policy applied to the failed log-in attempts of a username or an IP. After BackoffAfter failures,
+// every new attempt must wait BaseDelay since the last failure, doubling with every further failure up to MaxDelay.
+// After LockoutAfter failures, the attempts are rejected for LockoutDuration. The failures are forgotten after Window
+// without any new one
+type Policy struct {
+	BackoffAfter    int
+	BaseDelay       time.Duration
+	MaxDelay        time.Duration
+	LockoutAfter    int
+	LockoutDuration time.Duration
+	Window          time.Duration
+}
+
+// Validate checks that the policy can be used
+func (p Policy) Validate() error {
+	if p.BackoffAfter <= 0 || p.LockoutAfter < p.BackoffAfter {
+		return InvalidPolicyError
+	}
+	if p.BaseDelay <= 0 || p.MaxDelay < p.BaseDelay || p.LockoutDuration <= 0 || p.Window <= 0 {
+		return InvalidPolicyError
+	}
+	return nil
+}
+
+// delay returns the time an attempt must wait since the last failure, given the number of failures
+func (p Policy) delay(failures int) time.Duration {
+	if failures < p.BackoffAfter {
+		return 0
+	}
+
+	delay := p.BaseDelay
+	for i := p.BackoffAfter; i < failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, p.MaxDelay)
+}
@@ -0,0 +1,274 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	appcodec "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/codec"
+	appjwt "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/jwt"
+	approute "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/route"
+	"google.golang.org/protobuf/proto"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Cache caches the responses of the read-heavy routes. Their responses are validated by an ETag computed from the
+// serialized protobuf message, so the clients can revalidate their copy with If-None-Match. If the in-memory cache is
+// enabled, they are also stored in memory, keyed by the route, its parameters, the caller and the negotiated format.
+// The stored responses of a route are evicted once a mutating route that invalidates it succeeds
+type Cache struct {
+	store         *MemoryStore
+	identifier    *appjwt.Identifier
+	policies      map[string]Policy
+	invalidations map[string][]string
+	logger        *Logger
+}
+
+// NewCache creates a new cache for the routes with the given route keys, like
+// "GET /api/v1/shops/products/{product-id}". The invalidations map the keys of the mutating routes, like
+// "PUT /api/v1/shops/products/{product-id}", to the keys of the cached routes they evict. The store is nil if the
+// in-memory cache is disabled
+func NewCache(
+	store *MemoryStore,
+	identifier *appjwt.Identifier,
+	routePolicies map[string]Policy,
+	routeInvalidations map[string][]string,
+	logger *Logger,
+) (*Cache, error) {
+	// Check if the identifier or the logger are nil
+	if identifier == nil {
+		return nil, NilIdentifierError
+	}
+	if logger == nil {
+		return nil, NilLoggerError
+	}
+
+	// Check the policies and normalize their route keys
+	policies := make(map[string]Policy, len(routePolicies))
+	for key, policy := range routePolicies {
+		normalizedKey, err := ParseRouteKey(key)
+		if err != nil {
+			return nil, err
+		}
+		if err = policy.Validate(); err != nil {
+			return nil, err
+		}
+		policies[normalizedKey] = policy
+	}
+
+	// Check the invalidations and normalize their route keys
+	invalidations := make(map[string][]string, len(routeInvalidations))
+	for key, routes := range routeInvalidations {
+		normalizedKey, err := ParseMutatingRouteKey(key)
+		if err != nil {
+			return nil, err
+		}
+		for _, route := range routes {
+			normalizedRoute, err := ParseRouteKey(route)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := policies[normalizedRoute]; !ok {
+				return nil, fmt.Errorf("%w: %q", UncachedRouteError, route)
+			}
+			invalidations[normalizedKey] = append(invalidations[normalizedKey], normalizedRoute)
+		}
+	}
+
+	return &Cache{
+		store:         store,
+		identifier:    identifier,
+		policies:      policies,
+		invalidations: invalidations,
+		logger:        logger,
+	}, nil
+}
+
+// ParseRouteKey parses the key of a cached route and returns it normalized, checking it is a GET route
+func ParseRouteKey(key string) (string, error) {
+	normalizedKey, err := approute.ParseKey(key)
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(normalizedKey, http.MethodGet+" ") {
+		return "", UnsafeMethodError
+	}
+	return normalizedKey, nil
+}
+
+// ParseMutatingRouteKey parses the key of a route that invalidates the cached routes and returns it normalized,
+// checking it is not a GET route
+func ParseMutatingRouteKey(key string) (string, error) {
+	normalizedKey, err := approute.ParseKey(key)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(normalizedKey, http.MethodGet+" ") {
+		return "", SafeMethodError
+	}
+	return normalizedKey, nil
+}
+
+// ETag returns the weak ETag of the given response, computed from its deterministic protobuf serialization
+func ETag(response proto.Message) (string, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(response)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`, nil
+}
+
+// Matches checks if the value of an If-None-Match header matches the given ETag, using the weak comparison
+func Matches(ifNoneMatch, etag string) bool {
+	if strings.TrimSpace(ifNoneMatch) == "*" {
+		return true
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// Handler wraps the handler of a route, serving the responses stored in the in-memory cache and storing the
+// successful responses of the handler. The routes without a TTL are handled as usual
+func (c *Cache) Handler(handler gin.HandlerFunc) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		routeKey := approute.KeyFromGinContext(ctx)
+		policy, ok := c.policies[routeKey]
+		if !ok || c.store == nil || policy.TTL == 0 {
+			handler(ctx)
+			return
+		}
+
+		// Serve the stored response, if any
+		key := c.key(ctx, routeKey)
+		if entry := c.store.Get(key); entry != nil {
+			c.serve(ctx, policy, entry)
+			return
+		}
+		generation := c.store.Generation(routeKey)
+
+		ctx.Header(StatusHeader, MissStatus)
+		writer := &recordingWriter{ResponseWriter: ctx.Writer}
+		ctx.Writer = writer
+
+		handler(ctx)
+
+		// Store the successful responses, which are the only ones with an ETag. The responses to the conditional
+		// requests are not stored, since they have no body
+		ctx.Writer = writer.ResponseWriter
+		etag := writer.Header().Get(ETagHeader)
+		if writer.Status() != http.StatusOK || etag == "" {
+			return
+		}
+		entry := &Entry{
+			Status:      http.StatusOK,
+			ContentType: writer.Header().Get("Content-Type"),
+			ETag:        etag,
+			Body:        writer.body.Bytes(),
+		}
+		c.store.Set(routeKey, key, generation, entry, policy.TTL)
+		c.logger.ResponseStored(routeKey, len(entry.Body))
+	}
+}
+
+// Invalidator returns the middleware that evicts the stored responses of the cached routes invalidated by a mutating
+// route once it succeeds, so the writes through the gateway are visible right away. The writes through the other
+// gateway instances are only visible once the stored responses expire
+func (c *Cache) Invalidator() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Next()
+
+		if c.store == nil {
+			return
+		}
+		status := ctx.Writer.Status()
+		if status < http.StatusOK || status >= http.StatusMultipleChoices {
+			return
+		}
+		routeKey := approute.KeyFromGinContext(ctx)
+		for _, route := range c.invalidations[routeKey] {
+			c.logger.ResponsesEvicted(routeKey, route, c.store.Evict(route))
+		}
+	}
+}
+
+// Revalidate sets the ETag and the caching headers of a successful response of a cached route, answering 304 Not
+// Modified if the client already has it. It returns whether the request was answered
+func (c *Cache) Revalidate(ctx *gin.Context, code int, response proto.Message) bool {
+	if code != http.StatusOK {
+		return false
+	}
+	routeKey := approute.KeyFromGinContext(ctx)
+	policy, ok := c.policies[routeKey]
+	if !ok {
+		return false
+	}
+
+	etag, err := ETag(response)
+	if err != nil {
+		c.logger.FailedToComputeETag(routeKey, err)
+		return false
+	}
+	c.setHeaders(ctx, policy, etag)
+	if !Matches(ctx.GetHeader(IfNoneMatchHeader), etag) {
+		return false
+	}
+	notModified(ctx)
+	return true
+}
+
+// serve writes a response stored in the in-memory cache, or 304 Not Modified if the client already has it
+func (c *Cache) serve(ctx *gin.Context, policy Policy, entry *Entry) {
+	c.setHeaders(ctx, policy, entry.ETag)
+	ctx.Header(StatusHeader, HitStatus)
+	if Matches(ctx.GetHeader(IfNoneMatchHeader), entry.ETag) {
+		notModified(ctx)
+		return
+	}
+	ctx.Data(entry.Status, entry.ContentType, entry.Body)
+}
+
+// setHeaders sets the ETag and the caching headers of a response. The responses depend on the caller and on the
+// format negotiated with it, so they vary by the Authorization and the Accept headers
+func (c *Cache) setHeaders(ctx *gin.Context, policy Policy, etag string) {
+	ctx.Header(ETagHeader, etag)
+	if policy.CacheControl != "" {
+		ctx.Header(CacheControlHeader, policy.CacheControl)
+	}
+	appcodec.AddVary(ctx.Writer.Header(), "Authorization")
+	appcodec.AddVary(ctx.Writer.Header(), appcodec.AcceptHeader)
+}
+
+// key returns the key of the request in the in-memory cache, made of the route key, the path parameters, the query,
+// the user ID of the caller and the format negotiated with it, in this order
+func (c *Cache) key(ctx *gin.Context, routeKey string) string {
+	var builder strings.Builder
+	builder.WriteString(routeKey)
+	builder.WriteByte('\n')
+	for index, param := range ctx.Params {
+		if index > 0 {
+			builder.WriteByte('/')
+		}
+		builder.WriteString(url.PathEscape(param.Value))
+	}
+	builder.WriteByte('\n')
+	builder.WriteString(ctx.Request.URL.Query().Encode())
+	builder.WriteByte('\n')
+	builder.WriteString(c.identifier.UserID(ctx))
+	builder.WriteByte('\n')
+	builder.WriteString(string(appcodec.FormatFromGinContext(ctx)))
+	return builder.String()
+}
+
+// notModified answers 304 Not Modified, which has no body
+func notModified(ctx *gin.Context) {
+	ctx.Status(http.StatusNotModified)
+	ctx.Writer.WriteHeaderNow()
+}
@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"time"
+)
+
+const (
+	// EnabledKey is the key of the flag that enables the in-memory response cache
+	EnabledKey = "CACHE_ENABLED"
+
+	// MaxEntriesKey is the key of the maximum number of responses stored in the in-memory cache
+	MaxEntriesKey = "CACHE_MAX_ENTRIES"
+
+	// MaxBytesKey is the key of the maximum size in bytes of the responses stored in the in-memory cache
+	MaxBytesKey = "CACHE_MAX_BYTES"
+)
+
+const (
+	// ETagHeader is the header with the validator of the response
+	ETagHeader = "ETag"
+
+	// IfNoneMatchHeader is the header with the validators of the responses the client already has
+	IfNoneMatchHeader = "If-None-Match"
+
+	// CacheControlHeader is the header with the directives the clients and the proxies cache the response with
+	CacheControlHeader = "Cache-Control"
+
+	// StatusHeader is the header that tells whether the response was served from the in-memory cache
+	StatusHeader = "X-Cache"
+
+	// HitStatus is the cache status of the responses served from the in-memory cache
+	HitStatus = "HIT"
+
+	// MissStatus is the cache status of the responses of the backend services
+	MissStatus = "MISS"
+)
+
+const (
+	// DefaultMaxEntries is the default maximum number of responses stored in the in-memory cache
+	DefaultMaxEntries = 10000
+
+	// DefaultMaxBytes is the default maximum size in bytes of the responses stored in the in-memory cache
+	DefaultMaxBytes = 64 << 20
+
+	// DefaultCacheControl is the default Cache-Control of the cached routes, which answer a different response to
+	// each caller. The clients revalidate their copy on every request, so they see the writes right away, which is
+	// cheap while the response is stored in the in-memory cache
+	DefaultCacheControl = "private, no-cache"
+
+	// DefaultTTL is the default time the responses of the cached routes are stored in the in-memory cache
+	DefaultTTL = 30 * time.Second
+)
+
+var (
+	// DefaultRoutes are the keys of the read-heavy routes cached by default
+	DefaultRoutes = []string{
+		"GET /api/v1/shops/products/{product-id}",
+		"GET /api/v1/shops/shops/products/{product-id}",
+		"GET /api/v1/shops/shops/branches/products/{branch-id}/{product-id}",
+		"GET /api/v1/shops/products/categories/{category-id}",
+		"GET /api/v1/shops/markets/categories/{category-id}",
+		"GET /api/v1/shops/shops/{business-id}",
+		"GET /api/v1/users/profiles/{username}",
+	}
+
+	// DefaultInvalidations map the keys of the mutating routes to the keys of the cached routes they evict by default.
+	// Every stored response of the evicted routes is removed, whatever the path parameters of the mutating request are
+	DefaultInvalidations = map[string][]string{
+		"PUT /api/v1/shops/products/{product-id}": {
+			"GET /api/v1/shops/products/{product-id}",
+			"GET /api/v1/shops/shops/branches/products/{branch-id}/{product-id}",
+		},
+		"PUT /api/v1/shops/shops/products/{product-id}": {
+			"GET /api/v1/shops/shops/products/{product-id}",
+		},
+		"PUT /api/v1/shops/shops/branches/products/{branch-id}": {
+			"GET /api/v1/shops/shops/branches/products/{branch-id}/{product-id}",
+		},
+		"PUT /api/v1/shops/products/categories/{category-id}": {
+			"GET /api/v1/shops/products/categories/{category-id}",
+		},
+		"PUT /api/v1/shops/markets/categories/{category-id}": {
+			"GET /api/v1/shops/markets/categories/{category-id}",
+		},
+		"PUT /api/v1/shops/shops/{business-id}": {
+			"GET /api/v1/shops/shops/{business-id}",
+		},
+		"POST /api/v1/shops/shops/profile-picture/{business-id}": {
+			"GET /api/v1/shops/shops/{business-id}",
+		},
+		"DELETE /api/v1/shops/shops/{business-id}": {
+			"GET /api/v1/shops/shops/{business-id}",
+			"GET /api/v1/shops/shops/products/{product-id}",
+			"GET /api/v1/shops/shops/branches/products/{branch-id}/{product-id}",
+		},
+		"DELETE /api/v1/shops/shops/branches/{branch-id}": {
+			"GET /api/v1/shops/shops/branches/products/{branch-id}/{product-id}",
+		},
+		"PATCH /api/v1/users/": {
+			"GET /api/v1/users/profiles/{username}",
+		},
+		"PATCH /api/v1/users/username": {
+			"GET /api/v1/users/profiles/{username}",
+		},
+		"DELETE /api/v1/users/delete-account": {
+			"GET /api/v1/users/profiles/{username}",
+		},
+	}
+)
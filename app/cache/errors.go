@@ -0,0 +1,15 @@
+package cache
+
+import (
+	"errors"
+)
+
+var (
+	NilLoggerError        = errors.New("nil logger")
+	NilIdentifierError    = errors.New("nil JWT identifier")
+	NegativeTTLError      = errors.New("negative cache TTL")
+	NonPositiveLimitError = errors.New("non-positive cache limit")
+	UnsafeMethodError     = errors.New("only the GET routes can be cached")
+	SafeMethodError       = errors.New("the GET routes cannot invalidate the cached routes")
+	UncachedRouteError    = errors.New("the invalidated route is not cached")
+)
@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"log/slog"
+)
+
+// Logger is the logger for the response cache
+type Logger struct {
+	logger *slog.Logger
+}
+
+// NewLogger creates a new cache logger
+func NewLogger(logger *slog.Logger) (*Logger, error) {
+	// Check if the logger is nil
+	if logger == nil {
+		return nil, NilLoggerError
+	}
+
+	return &Logger{logger: logger}, nil
+}
+
+// ResponseStored logs that the response of a route was stored in the in-memory cache
+func (l *Logger) ResponseStored(route string, size int) {
+	l.logger.Debug("response stored in the cache", slog.String("route", route), slog.Int("size", size))
+}
+
+// ResponsesEvicted logs that the stored responses of a cached route were evicted by a mutating route
+func (l *Logger) ResponsesEvicted(route, evictedRoute string, evicted int) {
+	l.logger.Debug(
+		"responses evicted from the cache",
+		slog.String("route", route),
+		slog.String("evicted_route", evictedRoute),
+		slog.Int("evicted", evicted),
+	)
+}
+
+// FailedToComputeETag logs that the ETag of the response of a route could not be computed
+func (l *Logger) FailedToComputeETag(route string, err error) {
+	l.logger.Error(
+		"failed to compute the ETag of the response",
+		slog.String("route", route),
+		slog.String("error", err.Error()),
+	)
+}
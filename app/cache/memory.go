@@ -0,0 +1,142 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type (
+	// Entry is a response stored in the cache
+	Entry struct {
+		Status      int
+		ContentType string
+		ETag        string
+		Body        []byte
+	}
+
+	// MemoryStore stores the responses in memory, bounded by a maximum number of entries and a maximum size in bytes.
+	// Once a bound is reached, the least recently used responses are removed first. The responses are grouped by the
+	// key of their route, so they can be evicted together
+	MemoryStore struct {
+		mutex       sync.Mutex
+		maxEntries  int
+		maxBytes    int
+		size        int
+		entries     map[string]*list.Element
+		order       *list.List
+		generations map[string]uint64
+	}
+
+	// memoryEntry is a response stored in memory
+	memoryEntry struct {
+		route     string
+		key       string
+		entry     *Entry
+		expiresAt time.Time
+	}
+)
+
+// NewMemoryStore creates a new memory store with the given bounds
+func NewMemoryStore(maxEntries, maxBytes int) (*MemoryStore, error) {
+	if maxEntries <= 0 || maxBytes <= 0 {
+		return nil, NonPositiveLimitError
+	}
+
+	return &MemoryStore{
+		maxEntries:  maxEntries,
+		maxBytes:    maxBytes,
+		entries:     make(map[string]*list.Element),
+		order:       list.New(),
+		generations: make(map[string]uint64),
+	}, nil
+}
+
+// Get returns the response with the given key, or nil if there is none or it expired
+func (m *MemoryStore) Get(key string) *Entry {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	element, ok := m.entries[key]
+	if !ok {
+		return nil
+	}
+	e := element.Value.(*memoryEntry)
+	if !time.Now().Before(e.expiresAt) {
+		m.remove(element)
+		return nil
+	}
+	m.order.MoveToFront(element)
+	return e.entry
+}
+
+// Generation returns the generation of the responses of the given route, which changes every time they are evicted
+func (m *MemoryStore) Generation(route string) uint64 {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.generations[route]
+}
+
+// Set stores the response of the given route with the given key for the given time, removing the least recently used
+// responses that do not fit anymore. The response is not stored if the responses of its route were evicted since the
+// given generation, since it may predate the write that evicted them, nor if it is bigger than the whole store
+func (m *MemoryStore) Set(route, key string, generation uint64, entry *Entry, ttl time.Duration) {
+	size := entrySize(key, entry)
+	if size > m.maxBytes {
+		return
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.generations[route] != generation {
+		return
+	}
+	if element, ok := m.entries[key]; ok {
+		m.remove(element)
+	}
+	m.entries[key] = m.order.PushFront(
+		&memoryEntry{
+			route:     route,
+			key:       key,
+			entry:     entry,
+			expiresAt: time.Now().Add(ttl),
+		},
+	)
+	m.size += size
+
+	for len(m.entries) > m.maxEntries || m.size > m.maxBytes {
+		m.remove(m.order.Back())
+	}
+}
+
+// Evict removes the responses of the given route, returning how many were removed
+func (m *MemoryStore) Evict(route string) int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.generations[route]++
+	evicted := 0
+	for element := m.order.Front(); element != nil; {
+		next := element.Next()
+		if element.Value.(*memoryEntry).route == route {
+			m.remove(element)
+			evicted++
+		}
+		element = next
+	}
+	return evicted
+}
+
+// remove removes the given element. It must be called with the mutex locked
+func (m *MemoryStore) remove(element *list.Element) {
+	e := m.order.Remove(element).(*memoryEntry)
+	delete(m.entries, e.key)
+	m.size -= entrySize(e.key, e.entry)
+}
+
+// entrySize returns the approximate size in bytes a response takes in the store
+func entrySize(key string, entry *Entry) int {
+	return len(key) + len(entry.ContentType) + len(entry.ETag) + len(entry.Body)
+}
@@ -0,0 +1,225 @@
+package cache
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+type (
+	// memoryAction is what a step does with the memory store
+	memoryAction int
+
+	// memoryStep is a response stored in or looked up from the memory store, an eviction of a route, or the lookup of
+	// the generation of a route before calling its backend service
+	memoryStep struct {
+		action memoryAction
+		route  string
+		key    string
+
+		// size is the size in bytes of the stored response, including its one character key
+		size int
+	}
+
+	// memoryCase is a sequence of steps and the keys of the responses left in the memory store
+	memoryCase struct {
+		name       string
+		maxEntries int
+		maxBytes   int
+		steps      []memoryStep
+		wantKeys   []string
+	}
+)
+
+const (
+	// setAction stores a response, with the generation of its route looked up by the last generation step, if any
+	setAction memoryAction = iota
+
+	// getAction looks up a response, making it the most recently used one
+	getAction
+
+	// evictAction evicts the responses of a route
+	evictAction
+
+	// generationAction looks up the generation of a route
+	generationAction
+)
+
+const (
+	// testRoute is the route of the test responses
+	testRoute = "GET /api/v1/shops/products/:"
+
+	// otherTestRoute is another route of the test responses
+	otherTestRoute = "GET /api/v1/users/profiles/:"
+)
+
+// set returns the step that stores a response of the given size with the given key for the test route
+func set(key string, size int) memoryStep {
+	return memoryStep{action: setAction, route: testRoute, key: key, size: size}
+}
+
+// get returns the step that looks up the response with the given key
+func get(key string) memoryStep {
+	return memoryStep{action: getAction, key: key}
+}
+
+// TestMemoryStore checks the least recently used responses are removed once the store exceeds its maximum number of
+// entries or bytes, and the responses that raced the eviction of their route are not stored
+func TestMemoryStore(t *testing.T) {
+	cases := []memoryCase{
+		{
+			name:     "stored response",
+			steps:    []memoryStep{set("a", 10)},
+			wantKeys: []string{"a"},
+		},
+		{
+			name:     "replaced response",
+			steps:    []memoryStep{set("a", 10), set("a", 20)},
+			wantKeys: []string{"a"},
+		},
+		{
+			name:       "least recently stored response over entries",
+			maxEntries: 2,
+			steps:      []memoryStep{set("a", 10), set("b", 10), set("c", 10)},
+			wantKeys:   []string{"b", "c"},
+		},
+		{
+			name:       "least recently used response over entries",
+			maxEntries: 2,
+			steps:      []memoryStep{set("a", 10), set("b", 10), get("a"), set("c", 10)},
+			wantKeys:   []string{"a", "c"},
+		},
+		{
+			name:     "responses up to bytes",
+			maxBytes: 30,
+			steps:    []memoryStep{set("a", 10), set("b", 10), set("c", 10)},
+			wantKeys: []string{"a", "b", "c"},
+		},
+		{
+			name:     "least recently stored response over bytes",
+			maxBytes: 30,
+			steps:    []memoryStep{set("a", 10), set("b", 10), set("c", 10), set("d", 10)},
+			wantKeys: []string{"b", "c", "d"},
+		},
+		{
+			name:     "least recently used response over bytes",
+			maxBytes: 30,
+			steps:    []memoryStep{set("a", 10), set("b", 10), set("c", 10), get("a"), set("d", 10)},
+			wantKeys: []string{"a", "c", "d"},
+		},
+		{
+			name:     "least recently used responses over bytes",
+			maxBytes: 30,
+			steps:    []memoryStep{set("a", 10), set("b", 10), set("c", 10), set("d", 20)},
+			wantKeys: []string{"c", "d"},
+		},
+		{
+			name:     "replaced response over bytes",
+			maxBytes: 30,
+			steps:    []memoryStep{set("a", 10), set("b", 10), set("c", 10), set("c", 20)},
+			wantKeys: []string{"b", "c"},
+		},
+		{
+			name:     "response bigger than the store",
+			maxBytes: 30,
+			steps:    []memoryStep{set("a", 10), set("b", 31)},
+			wantKeys: []string{"a"},
+		},
+		{
+			name: "evicted route",
+			steps: []memoryStep{
+				set("a", 10),
+				{action: setAction, route: otherTestRoute, key: "b", size: 10},
+				{action: evictAction, route: testRoute},
+			},
+			wantKeys: []string{"b"},
+		},
+		{
+			name: "response that raced the eviction of its route",
+			steps: []memoryStep{
+				{action: generationAction, route: testRoute},
+				{action: evictAction, route: testRoute},
+				set("a", 10),
+			},
+		},
+		{
+			name: "response after the eviction of its route",
+			steps: []memoryStep{
+				{action: evictAction, route: testRoute},
+				{action: generationAction, route: testRoute},
+				set("a", 10),
+			},
+			wantKeys: []string{"a"},
+		},
+		{
+			name: "response that raced the eviction of another route",
+			steps: []memoryStep{
+				{action: generationAction, route: testRoute},
+				{action: evictAction, route: otherTestRoute},
+				set("a", 10),
+			},
+			wantKeys: []string{"a"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(
+			c.name, func(t *testing.T) {
+				maxEntries, maxBytes := c.maxEntries, c.maxBytes
+				if maxEntries == 0 {
+					maxEntries = DefaultMaxEntries
+				}
+				if maxBytes == 0 {
+					maxBytes = DefaultMaxBytes
+				}
+				store, err := NewMemoryStore(maxEntries, maxBytes)
+				if err != nil {
+					t.Fatalf("NewMemoryStore() error = %v", err)
+				}
+
+				keys := make(map[string]bool)
+				generations := make(map[string]uint64)
+				for _, step := range c.steps {
+					switch step.action {
+					case setAction:
+						generation, ok := generations[step.route]
+						if !ok {
+							generation = store.Generation(step.route)
+						}
+						entry := &Entry{Body: []byte(strings.Repeat("a", step.size-len(step.key)))}
+						store.Set(step.route, step.key, generation, entry, time.Minute)
+						keys[step.key] = true
+					case getAction:
+						store.Get(step.key)
+					case evictAction:
+						store.Evict(step.route)
+					case generationAction:
+						generations[step.route] = store.Generation(step.route)
+					}
+				}
+
+				// The size of the store must match the responses left in it
+				size := 0
+				for _, element := range store.entries {
+					e := element.Value.(*memoryEntry)
+					size += entrySize(e.key, e.entry)
+				}
+				if store.size != size {
+					t.Errorf("size = %d, want %d", store.size, size)
+				}
+
+				var gotKeys []string
+				for key := range keys {
+					if store.Get(key) != nil {
+						gotKeys = append(gotKeys, key)
+					}
+				}
+				slices.Sort(gotKeys)
+				if !slices.Equal(gotKeys, c.wantKeys) {
+					t.Errorf("stored keys = %v, want %v", gotKeys, c.wantKeys)
+				}
+			},
+		)
+	}
+}
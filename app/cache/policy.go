@@ -0,0 +1,20 @@
+package cache
+
+import (
+	"time"
+)
+
+// Policy is the caching policy of a route. Its responses are sent with the given Cache-Control and stored in the
+// in-memory cache for the given TTL, if the cache is enabled. A zero TTL only validates the responses with their ETag
+type Policy struct {
+	CacheControl string
+	TTL          time.Duration
+}
+
+// Validate checks that the TTL is not negative
+func (p Policy) Validate() error {
+	if p.TTL < 0 {
+		return NegativeTTLError
+	}
+	return nil
+}
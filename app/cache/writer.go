@@ -0,0 +1,24 @@
+package cache
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+)
+
+// recordingWriter copies the body of the response, so it can be stored in the cache
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+// Write writes the body and copies it
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString writes the body and copies it
+func (w *recordingWriter) WriteString(data string) (int, error) {
+	w.body.WriteString(data)
+	return w.ResponseWriter.WriteString(data)
+}
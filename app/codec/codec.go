@@ -0,0 +1,176 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"strings"
+	"unicode"
+)
+
+type (
+	// Options are the options of the JSON encoding of the responses and the JSON decoding of the request bodies
+	Options struct {
+		// ProtoJSON encodes and decodes the JSON bodies in the canonical JSON mapping of protobuf, with the rest of the
+		// options. Otherwise, they are encoded and decoded with encoding/json, whose fields are named by the JSON tags
+		// of the generated messages, which are their proto names
+		ProtoJSON bool
+
+		// UseProtoNames names the protojson fields by their proto names, like product_id, instead of camelCase
+		UseProtoNames bool
+
+		// EmitDefaults writes the protojson fields with their default values, which are omitted otherwise
+		EmitDefaults bool
+
+		// EnumsAsNumbers writes the protojson enums as numbers instead of their names
+		EnumsAsNumbers bool
+	}
+
+	// Codec encodes the responses of the backend services in the format negotiated with the client, either JSON or
+	// binary protobuf, and decodes the JSON request bodies like their responses are encoded
+	Codec struct {
+		protoJSON        bool
+		marshalOptions   protojson.MarshalOptions
+		unmarshalOptions protojson.UnmarshalOptions
+	}
+)
+
+// NewCodec creates a new codec with the given options of the JSON encoding
+func NewCodec(options Options) *Codec {
+	return &Codec{
+		protoJSON: options.ProtoJSON,
+		marshalOptions: protojson.MarshalOptions{
+			UseProtoNames:   options.UseProtoNames,
+			EmitUnpopulated: options.EmitDefaults,
+			UseEnumNumbers:  options.EnumsAsNumbers,
+		},
+		unmarshalOptions: protojson.UnmarshalOptions{
+			// The unknown fields are only rejected in strict mode, like the ones of the encoding/json bodies
+			DiscardUnknown: true,
+		},
+	}
+}
+
+// IsProtoJSON checks if the JSON bodies are encoded and decoded in the canonical JSON mapping of protobuf
+func (c *Codec) IsProtoJSON() bool {
+	return c != nil && c.protoJSON
+}
+
+// EncodeJSON encodes the message as JSON, in protojson with the options of the codec or with encoding/json otherwise
+func (c *Codec) EncodeJSON(message proto.Message) ([]byte, error) {
+	if c.protoJSON {
+		return c.marshalOptions.Marshal(message)
+	}
+	return json.Marshal(message)
+}
+
+// JSONName returns the name of the JSON field of the given proto field name. The JSON tags of the generated messages
+// are their proto names, while the protojson fields are named in camelCase unless the proto names are used
+func (c *Codec) JSONName(name string) string {
+	if !c.protoJSON || c.marshalOptions.UseProtoNames {
+		return name
+	}
+
+	var builder strings.Builder
+	upper := false
+	for _, r := range name {
+		switch {
+		case r == '_':
+			upper = true
+		case upper:
+			builder.WriteRune(unicode.ToUpper(r))
+			upper = false
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
+
+// Marshal encodes the message in the given format, returning its content type
+func (c *Codec) Marshal(format Format, message proto.Message) (string, []byte, error) {
+	if format == Protobuf {
+		body, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+		return ProtobufContentType, body, err
+	}
+	body, err := c.EncodeJSON(message)
+	return JSONContentType, body, err
+}
+
+// Write writes the message as the response of the request with the given status code, in the format negotiated with
+// the client
+func (c *Codec) Write(ctx *gin.Context, code int, message proto.Message) error {
+	contentType, body, err := c.Marshal(FormatFromGinContext(ctx), message)
+	if err != nil {
+		return err
+	}
+	AddVary(ctx.Writer.Header(), AcceptHeader)
+	ctx.Data(code, contentType, body)
+	return nil
+}
+
+// DecodeProtoJSON decodes the protojson body of the request into the given message, restoring the body for the rest
+// of the handlers. The fields unknown to the message are left to the strict mode of the body middleware
+func (c *Codec) DecodeProtoJSON(ctx *gin.Context, request proto.Message) error {
+	body, err := readBody(ctx)
+	if err != nil || len(body) == 0 {
+		return err
+	}
+	if err = c.unmarshalOptions.Unmarshal(body, request); err != nil {
+		return FailedToDecodeProtoJSONBodyError
+	}
+	return nil
+}
+
+// Middleware stores the codec in the Gin context, so the request bodies are decoded like their responses are encoded
+// while the context of their backend call is prepared
+func (c *Codec) Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Set(ginCtxKey, c)
+		ctx.Next()
+	}
+}
+
+// FromGinContext returns the codec stored in the Gin context, or nil if there is none
+func FromGinContext(ctx *gin.Context) *Codec {
+	if value, ok := ctx.Get(ginCtxKey); ok {
+		if codec, ok := value.(*Codec); ok {
+			return codec
+		}
+	}
+	return nil
+}
+
+// FormatFromGinContext returns the format of the response negotiated with the client of the request
+func FormatFromGinContext(ctx *gin.Context) Format {
+	return Negotiate(ctx.GetHeader(AcceptHeader))
+}
+
+// DecodeProtobuf decodes the binary protobuf body of the request into the given message, restoring the body for the
+// rest of the handlers
+func DecodeProtobuf(ctx *gin.Context, request proto.Message) error {
+	body, err := readBody(ctx)
+	if err != nil || body == nil {
+		return err
+	}
+	if err = proto.Unmarshal(body, request); err != nil {
+		return FailedToDecodeBodyError
+	}
+	return nil
+}
+
+// readBody reads the body of the request, restoring it for the rest of the handlers, or returns nil if there is none
+func readBody(ctx *gin.Context) ([]byte, error) {
+	if ctx.Request.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		return nil, FailedToReadBodyError
+	}
+	ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
@@ -0,0 +1,113 @@
+package codec
+
+import (
+	"github.com/gin-gonic/gin"
+	pbuser "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/user"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestEncodeJSON pins the shape of the JSON responses, which keep the encoding/json shape of the generated messages by
+// default and only follow the canonical JSON mapping of protobuf when protojson is enabled
+func TestEncodeJSON(t *testing.T) {
+	response := &pbuser.GetProfileResponse{
+		Message:   "profile",
+		FirstName: "John",
+		JoinedAt:  timestamppb.New(time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)),
+	}
+
+	cases := []struct {
+		name    string
+		options Options
+		want    string
+	}{
+		{
+			name: "default",
+			want: `{"message":"profile","first_name":"John","joined_at":{"seconds":1704164645}}`,
+		},
+		{
+			name:    "protojson",
+			options: Options{ProtoJSON: true},
+			want:    `{"message":"profile","firstName":"John","joinedAt":"2024-01-02T03:04:05Z"}`,
+		},
+		{
+			name:    "protojson with proto names",
+			options: Options{ProtoJSON: true, UseProtoNames: true},
+			want:    `{"message":"profile","first_name":"John","joined_at":"2024-01-02T03:04:05Z"}`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(
+			c.name, func(t *testing.T) {
+				body, err := NewCodec(c.options).EncodeJSON(response)
+				if err != nil {
+					t.Fatalf("failed to encode the response: %v", err)
+				}
+
+				// The protojson output is not stable, so its whitespace is removed before comparing it
+				if got := strings.ReplaceAll(string(body), " ", ""); got != c.want {
+					t.Errorf("got %s, want %s", got, c.want)
+				}
+			},
+		)
+	}
+}
+
+// TestJSONName checks the name of the fields added to the JSON responses matches the one of their encoding
+func TestJSONName(t *testing.T) {
+	cases := []struct {
+		name    string
+		options Options
+		want    string
+	}{
+		{name: "default", want: "next_cursor"},
+		{name: "protojson", options: Options{ProtoJSON: true}, want: "nextCursor"},
+		{name: "protojson with proto names", options: Options{ProtoJSON: true, UseProtoNames: true}, want: "next_cursor"},
+	}
+	for _, c := range cases {
+		t.Run(
+			c.name, func(t *testing.T) {
+				if got := NewCodec(c.options).JSONName("next_cursor"); got != c.want {
+					t.Errorf("got %s, want %s", got, c.want)
+				}
+			},
+		)
+	}
+}
+
+// TestDecodeProtoJSON checks the protojson request bodies are decoded in the shape their responses are encoded with,
+// accepting both the camelCase and the proto names of the fields
+func TestDecodeProtoJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	birthdate := "2000-01-02T00:00:00Z"
+
+	for _, body := range []string{
+		`{"firstName":"John","birthdate":"` + birthdate + `"}`,
+		`{"first_name":"John","birthdate":"` + birthdate + `"}`,
+	} {
+		t.Run(
+			body, func(t *testing.T) {
+				ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+				ctx.Request = httptest.NewRequest(http.MethodPatch, "/api/v1/users/user", strings.NewReader(body))
+
+				request := &pbuser.UpdateUserRequest{}
+				if err := NewCodec(Options{ProtoJSON: true}).DecodeProtoJSON(ctx, request); err != nil {
+					t.Fatalf("failed to decode the request: %v", err)
+				}
+
+				want := &pbuser.UpdateUserRequest{
+					FirstName: proto.String("John"),
+					Birthdate: timestamppb.New(time.Date(2000, time.January, 2, 0, 0, 0, 0, time.UTC)),
+				}
+				if !proto.Equal(request, want) {
+					t.Errorf("got %v, want %v", request, want)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,56 @@
+package codec
+
+const (
+	// ProtoJSONKey is the key of the flag that encodes and decodes the JSON bodies in the canonical JSON mapping of
+	// protobuf
+	ProtoJSONKey = "JSON_PROTOJSON"
+
+	// UseProtoNamesKey is the key of the flag that names the protojson fields by their proto names instead of camelCase
+	UseProtoNamesKey = "JSON_USE_PROTO_NAMES"
+
+	// EmitDefaultsKey is the key of the flag that writes the protojson fields with their default values
+	EmitDefaultsKey = "JSON_EMIT_DEFAULTS"
+
+	// EnumsAsNumbersKey is the key of the flag that writes the enums of the protojson responses as numbers
+	EnumsAsNumbersKey = "JSON_ENUMS_AS_NUMBERS"
+
+	// JSONContentType is the content type of the JSON bodies
+	JSONContentType = "application/json"
+
+	// ProtobufContentType is the content type of the binary protobuf bodies
+	ProtobufContentType = "application/x-protobuf"
+
+	// AcceptHeader is the header with the content types accepted by the client
+	AcceptHeader = "Accept"
+
+	// VaryHeader is the header with the request headers the response depends on
+	VaryHeader = "Vary"
+
+	// ginCtxKey is the key of the codec in the Gin context
+	ginCtxKey = "codec"
+)
+
+// Format is the format of a body
+type Format string
+
+const (
+	// JSON is the format of the JSON bodies, which is the default one
+	JSON Format = "json"
+
+	// Protobuf is the format of the binary protobuf bodies
+	Protobuf Format = "protobuf"
+)
+
+var (
+	// protobufContentTypes are the content types of the binary protobuf bodies
+	protobufContentTypes = map[string]bool{
+		ProtobufContentType:    true,
+		"application/protobuf": true,
+	}
+
+	// wildcardContentTypes are the wildcards that match both the JSON and the binary protobuf content types
+	wildcardContentTypes = map[string]bool{
+		"application/*": true,
+		"*/*":           true,
+	}
+)
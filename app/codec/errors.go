@@ -0,0 +1,11 @@
+package codec
+
+import (
+	"errors"
+)
+
+var (
+	FailedToReadBodyError            = errors.New("failed to read the request body")
+	FailedToDecodeBodyError          = errors.New("failed to decode the protobuf request body")
+	FailedToDecodeProtoJSONBodyError = errors.New("failed to decode the protojson request body")
+)
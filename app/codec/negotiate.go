@@ -0,0 +1,59 @@
+package codec
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Negotiate returns the format of the response preferred by the client, as stated by the given Accept header. The
+// clients that do not prefer protobuf over JSON are answered with JSON, and protobuf is preferred over a wildcard of
+// the same quality
+func Negotiate(accept string) Format {
+	var jsonWeight, protobufWeight, wildcardWeight float64
+	for _, item := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(item))
+		if err != nil {
+			continue
+		}
+
+		weight := 1.0
+		if q, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+				weight = parsed
+			}
+		}
+		switch {
+		case protobufContentTypes[mediaType]:
+			protobufWeight = max(protobufWeight, weight)
+		case mediaType == JSONContentType:
+			jsonWeight = max(jsonWeight, weight)
+		case wildcardContentTypes[mediaType]:
+			wildcardWeight = max(wildcardWeight, weight)
+		}
+	}
+
+	if protobufWeight > 0 && protobufWeight > jsonWeight && protobufWeight >= wildcardWeight {
+		return Protobuf
+	}
+	return JSON
+}
+
+// IsProtobuf checks if the content type is the one of the binary protobuf bodies
+func IsProtobuf(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && protobufContentTypes[strings.ToLower(mediaType)]
+}
+
+// AddVary adds the request header to the Vary header of the response, unless it is already there
+func AddVary(header http.Header, name string) {
+	for _, value := range header.Values(VaryHeader) {
+		for _, item := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(item), name) {
+				return
+			}
+		}
+	}
+	header.Add(VaryHeader, name)
+}
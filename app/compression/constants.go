@@ -0,0 +1,60 @@
+package compression
+
+import (
+	"compress/gzip"
+)
+
+const (
+	// EnabledKey is the key of the flag that enables the compression of the responses
+	EnabledKey = "COMPRESSION_ENABLED"
+
+	// MinBytesKey is the key of the minimum size in bytes of the compressed responses
+	MinBytesKey = "COMPRESSION_MIN_BYTES"
+
+	// GzipEncoding is the content coding of the gzip compression
+	GzipEncoding = "gzip"
+
+	// BrotliEncoding is the content coding of the Brotli compression
+	BrotliEncoding = "br"
+
+	// AcceptEncodingHeader is the header with the content codings accepted by the client
+	AcceptEncodingHeader = "Accept-Encoding"
+
+	// ContentEncodingHeader is the header with the content coding of the response
+	ContentEncodingHeader = "Content-Encoding"
+
+	// DefaultMinBytes is the default minimum size in bytes of the compressed responses, below which the compression
+	// does not pay off
+	DefaultMinBytes = 1024
+
+	// DefaultGzipLevel is the default level of the gzip compression
+	DefaultGzipLevel = gzip.DefaultCompression
+
+	// DefaultBrotliLevel is the default level of the Brotli compression, which favors the speed since the responses
+	// are compressed on every request
+	DefaultBrotliLevel = 4
+
+	// MinBrotliLevel is the minimum level of the Brotli compression
+	MinBrotliLevel = 0
+
+	// MaxBrotliLevel is the maximum level of the Brotli compression
+	MaxBrotliLevel = 11
+)
+
+var (
+	// Encodings are the supported content codings, in order of preference when the client accepts them equally
+	Encodings = []string{BrotliEncoding, GzipEncoding}
+
+	// DefaultContentTypes are the content types compressed by default, which are the ones of the API responses and of
+	// the Swagger docs
+	DefaultContentTypes = []string{
+		"application/json",
+		"application/problem+json",
+		"application/x-protobuf",
+		"application/javascript",
+		"text/html",
+		"text/css",
+		"text/plain",
+		"image/svg+xml",
+	}
+)
@@ -0,0 +1,12 @@
+package compression
+
+import (
+	"errors"
+)
+
+var (
+	NonPositiveMinBytesError = errors.New("non-positive minimum size of the compressed responses")
+	InvalidGzipLevelError    = errors.New("invalid gzip compression level")
+	InvalidBrotliLevelError  = errors.New("invalid brotli compression level, expected between 0 and 11")
+	InvalidContentTypeError  = errors.New("invalid content type")
+)
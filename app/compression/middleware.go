@@ -0,0 +1,104 @@
+package compression
+
+import (
+	"compress/gzip"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// Middleware compresses the responses with the content coding preferred by the client, either Brotli or gzip, if
+// they are large enough and their content type is compressed
+type Middleware struct {
+	minBytes     int
+	contentTypes map[string]bool
+	pools        pools
+}
+
+// NewMiddleware creates a new compression middleware with the minimum size in bytes of the compressed responses,
+// their content types and the levels of each content coding
+func NewMiddleware(minBytes int, contentTypes []string, gzipLevel, brotliLevel int) (*Middleware, error) {
+	if minBytes <= 0 {
+		return nil, NonPositiveMinBytesError
+	}
+	if err := ValidateGzipLevel(gzipLevel); err != nil {
+		return nil, err
+	}
+	if err := ValidateBrotliLevel(brotliLevel); err != nil {
+		return nil, err
+	}
+
+	// Normalize the content types
+	normalizedContentTypes := make(map[string]bool, len(contentTypes))
+	for _, contentType := range contentTypes {
+		mediaType, err := ParseContentType(contentType)
+		if err != nil {
+			return nil, err
+		}
+		normalizedContentTypes[mediaType] = true
+	}
+
+	return &Middleware{
+		minBytes:     minBytes,
+		contentTypes: normalizedContentTypes,
+		pools:        newPools(gzipLevel, brotliLevel),
+	}, nil
+}
+
+// Handler wraps the response writer with a compressing one, which must be done before any other middleware wraps it
+// so they see the uncompressed body
+func (m *Middleware) Handler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		// The responses to the HEAD requests have no body
+		if ctx.Request.Method == http.MethodHead {
+			ctx.Next()
+			return
+		}
+
+		writer := &compressingWriter{
+			ResponseWriter: ctx.Writer,
+			middleware:     m,
+			encoding:       Negotiate(ctx.GetHeader(AcceptEncodingHeader)),
+		}
+		ctx.Writer = writer
+		defer func() {
+			writer.close()
+			ctx.Writer = writer.ResponseWriter
+		}()
+
+		ctx.Next()
+	}
+}
+
+// isCompressed checks if the responses with the given content type are compressed
+func (m *Middleware) isCompressed(contentType string) bool {
+	mediaType, err := ParseContentType(contentType)
+	return err == nil && m.contentTypes[mediaType]
+}
+
+// ParseContentType returns the media type of the content type, without its parameters and in lowercase
+func ParseContentType(contentType string) (string, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", fmt.Errorf("%w: %q", InvalidContentTypeError, contentType)
+	}
+	return strings.ToLower(mediaType), nil
+}
+
+// ValidateGzipLevel checks that the level is a valid gzip compression level
+func ValidateGzipLevel(level int) error {
+	if level < gzip.HuffmanOnly || level > gzip.BestCompression {
+		return InvalidGzipLevelError
+	}
+	return nil
+}
+
+// ValidateBrotliLevel checks that the level is a valid Brotli compression level
+func ValidateBrotliLevel(level int) error {
+	if level < MinBrotliLevel || level > MaxBrotliLevel {
+		return InvalidBrotliLevelError
+	}
+	return nil
+}
@@ -0,0 +1,302 @@
+package compression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type (
+	// benchmarkProduct is a product of the representative JSON payloads
+	benchmarkProduct struct {
+		ID                 string   `json:"id"`
+		Name               string   `json:"name"`
+		Description        string   `json:"description"`
+		Brand              string   `json:"brand"`
+		Price              float32  `json:"price"`
+		ProductCategoryIDs []string `json:"product_category_ids"`
+		Barcode            string   `json:"barcode"`
+		Weight             float32  `json:"weight"`
+		CreatedAt          string   `json:"created_at"`
+	}
+)
+
+// TestMiddleware checks which responses are compressed by the middleware, and that the compressed ones are decoded
+// back to their body
+func TestMiddleware(t *testing.T) {
+	cases := []struct {
+		name           string
+		method         string
+		status         int
+		contentType    string
+		acceptEncoding string
+		size           int
+		wantEncoding   string
+		wantVary       bool
+	}{
+		{
+			name:           "gzip",
+			method:         http.MethodGet,
+			status:         http.StatusOK,
+			contentType:    "application/json; charset=utf-8",
+			acceptEncoding: "gzip",
+			size:           4 * DefaultMinBytes,
+			wantEncoding:   GzipEncoding,
+			wantVary:       true,
+		},
+		{
+			name:           "brotli",
+			method:         http.MethodGet,
+			status:         http.StatusOK,
+			contentType:    "application/json; charset=utf-8",
+			acceptEncoding: "gzip, br",
+			size:           4 * DefaultMinBytes,
+			wantEncoding:   BrotliEncoding,
+			wantVary:       true,
+		},
+		{
+			name:           "minimum size",
+			method:         http.MethodGet,
+			status:         http.StatusOK,
+			contentType:    "application/problem+json",
+			acceptEncoding: "gzip",
+			size:           DefaultMinBytes,
+			wantEncoding:   GzipEncoding,
+			wantVary:       true,
+		},
+		{
+			name:           "below the minimum size",
+			method:         http.MethodGet,
+			status:         http.StatusOK,
+			contentType:    "application/json",
+			acceptEncoding: "gzip",
+			size:           DefaultMinBytes - 1,
+			wantVary:       true,
+		},
+		{
+			name:           "content type not allowed",
+			method:         http.MethodGet,
+			status:         http.StatusOK,
+			contentType:    "image/png",
+			acceptEncoding: "gzip",
+			size:           4 * DefaultMinBytes,
+		},
+		{
+			name:        "missing Accept-Encoding",
+			method:      http.MethodGet,
+			status:      http.StatusOK,
+			contentType: "application/json",
+			size:        4 * DefaultMinBytes,
+			wantVary:    true,
+		},
+		{
+			name:           "no content",
+			method:         http.MethodGet,
+			status:         http.StatusNoContent,
+			contentType:    "application/json",
+			acceptEncoding: "gzip",
+			size:           4 * DefaultMinBytes,
+			wantVary:       true,
+		},
+		{
+			name:           "not modified",
+			method:         http.MethodGet,
+			status:         http.StatusNotModified,
+			contentType:    "application/json",
+			acceptEncoding: "gzip",
+			size:           4 * DefaultMinBytes,
+			wantVary:       true,
+		},
+		{
+			name:           "head",
+			method:         http.MethodHead,
+			status:         http.StatusOK,
+			contentType:    "application/json",
+			acceptEncoding: "gzip",
+			size:           4 * DefaultMinBytes,
+		},
+	}
+
+	middleware, err := NewMiddleware(DefaultMinBytes, DefaultContentTypes, DefaultGzipLevel, DefaultBrotliLevel)
+	if err != nil {
+		t.Fatalf("NewMiddleware() error = %v", err)
+	}
+	gin.SetMode(gin.ReleaseMode)
+
+	for _, c := range cases {
+		t.Run(
+			c.name, func(t *testing.T) {
+				body := []byte(strings.Repeat("a", c.size))
+
+				router := gin.New()
+				router.Use(middleware.Handler())
+				router.Handle(
+					c.method, "/", func(ctx *gin.Context) {
+						ctx.Header("Content-Type", c.contentType)
+						ctx.Status(c.status)
+						_, _ = ctx.Writer.Write(body)
+					},
+				)
+
+				request := httptest.NewRequest(c.method, "/", nil)
+				if c.acceptEncoding != "" {
+					request.Header.Set(AcceptEncodingHeader, c.acceptEncoding)
+				}
+				recorder := httptest.NewRecorder()
+				router.ServeHTTP(recorder, request)
+
+				if got := recorder.Header().Get(ContentEncodingHeader); got != c.wantEncoding {
+					t.Errorf("%s = %q, want %q", ContentEncodingHeader, got, c.wantEncoding)
+				}
+				vary := recorder.Header().Values("Vary")
+				if gotVary := len(vary) == 1 && vary[0] == AcceptEncodingHeader; gotVary != c.wantVary {
+					t.Errorf("Vary = %q, want %s: %t", vary, AcceptEncodingHeader, c.wantVary)
+				}
+
+				// Check the round-trip of the body
+				if got := decode(t, c.wantEncoding, recorder.Body.Bytes()); !bytes.Equal(got, body) {
+					t.Errorf("decoded body of %d bytes, want %d bytes", len(got), len(body))
+				}
+			},
+		)
+	}
+}
+
+// decode decodes the body with the given content coding
+func decode(t *testing.T, encoding string, body []byte) []byte {
+	t.Helper()
+
+	var reader io.Reader
+	switch encoding {
+	case GzipEncoding:
+		gzipReader, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("gzip.NewReader() error = %v", err)
+		}
+		reader = gzipReader
+	case BrotliEncoding:
+		reader = brotli.NewReader(bytes.NewReader(body))
+	default:
+		return body
+	}
+
+	decoded, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("io.ReadAll() error = %v", err)
+	}
+	return decoded
+}
+
+// benchmarkPayload returns a representative JSON response with the given number of products
+func benchmarkPayload(b *testing.B, products int) []byte {
+	b.Helper()
+
+	list := make([]benchmarkProduct, products)
+	for index := range list {
+		list[index] = benchmarkProduct{
+			ID:                 fmt.Sprintf("6717a2c1f4e3b2a1c0d9%04x", index),
+			Name:               fmt.Sprintf("Product %d", index),
+			Description:        "A product sold by the branches of the businesses of the shopping mall",
+			Brand:              "Pixel Plaza",
+			Price:              float32(index%100) + 0.99,
+			ProductCategoryIDs: []string{"6717a2c1f4e3b2a1c0d90001", "6717a2c1f4e3b2a1c0d90002"},
+			Barcode:            fmt.Sprintf("7591234%06d", index),
+			Weight:             float32(index%10) + 0.5,
+			CreatedAt:          "2024-10-22T14:03:12Z",
+		}
+	}
+
+	payload, err := json.Marshal(map[string]any{"products": list, "next_cursor": "MTAw"})
+	if err != nil {
+		b.Fatalf("json.Marshal() error = %v", err)
+	}
+	return payload
+}
+
+// benchmarkCompression benchmarks the responses of the given payload compressed by the middleware with the given
+// content coding and levels, reporting the compression ratio. The identity content coding leaves them uncompressed
+func benchmarkCompression(b *testing.B, payload []byte, encoding string, gzipLevel, brotliLevel int) {
+	b.Helper()
+
+	middleware, err := NewMiddleware(DefaultMinBytes, DefaultContentTypes, gzipLevel, brotliLevel)
+	if err != nil {
+		b.Fatalf("NewMiddleware() error = %v", err)
+	}
+
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+	router.Use(middleware.Handler())
+	router.GET(
+		"/", func(ctx *gin.Context) {
+			ctx.Data(http.StatusOK, "application/json; charset=utf-8", payload)
+		},
+	)
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set(AcceptEncodingHeader, encoding)
+	wantEncoding := Negotiate(encoding)
+
+	var compressed int
+	b.SetBytes(int64(len(payload)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		if got := recorder.Header().Get(ContentEncodingHeader); got != wantEncoding {
+			b.Fatalf("%s = %q, want %q", ContentEncodingHeader, got, wantEncoding)
+		}
+		compressed = recorder.Body.Len()
+	}
+	b.ReportMetric(float64(len(payload))/float64(compressed), "ratio")
+}
+
+// BenchmarkIdentity benchmarks the uncompressed representative JSON responses, which are the baseline of the
+// compressed ones
+func BenchmarkIdentity(b *testing.B) {
+	for _, products := range []int{10, 100, 1000} {
+		payload := benchmarkPayload(b, products)
+		b.Run(
+			fmt.Sprintf("products=%d", products), func(b *testing.B) {
+				benchmarkCompression(b, payload, "identity", DefaultGzipLevel, DefaultBrotliLevel)
+			},
+		)
+	}
+}
+
+// BenchmarkGzip benchmarks the gzip compression of representative JSON responses at the default and bounding levels
+func BenchmarkGzip(b *testing.B) {
+	for _, products := range []int{10, 100, 1000} {
+		payload := benchmarkPayload(b, products)
+		for _, level := range []int{gzip.BestSpeed, DefaultGzipLevel, gzip.BestCompression} {
+			b.Run(
+				fmt.Sprintf("products=%d/level=%d", products, level), func(b *testing.B) {
+					benchmarkCompression(b, payload, GzipEncoding, level, DefaultBrotliLevel)
+				},
+			)
+		}
+	}
+}
+
+// BenchmarkBrotli benchmarks the Brotli compression of representative JSON responses at the default and bounding
+// levels
+func BenchmarkBrotli(b *testing.B) {
+	for _, products := range []int{10, 100, 1000} {
+		payload := benchmarkPayload(b, products)
+		for _, level := range []int{MinBrotliLevel, DefaultBrotliLevel, 6, MaxBrotliLevel} {
+			b.Run(
+				fmt.Sprintf("products=%d/level=%d", products, level), func(b *testing.B) {
+					benchmarkCompression(b, payload, BrotliEncoding, DefaultGzipLevel, level)
+				},
+			)
+		}
+	}
+}
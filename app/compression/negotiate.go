@@ -0,0 +1,51 @@
+package compression
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Negotiate returns the supported content coding preferred by the client, as stated by the given Accept-Encoding
+// header, or an empty string if it does not accept any of them. The codings with the same weight are picked in the
+// order of preference of the gateway
+func Negotiate(acceptEncoding string) string {
+	if acceptEncoding == "" {
+		return ""
+	}
+
+	// Parse the weight of each coding, including the wildcard
+	weights := make(map[string]float64)
+	for _, item := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(item, ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding == "" {
+			continue
+		}
+
+		weight := 1.0
+		for _, param := range strings.Split(params, ";") {
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || strings.ToLower(strings.TrimSpace(name)) != "q" {
+				continue
+			}
+			if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				weight = parsed
+			}
+		}
+		weights[coding] = weight
+	}
+
+	// Pick the coding with the highest weight, which must be positive
+	var preferred string
+	var preferredWeight float64
+	for _, encoding := range Encodings {
+		weight, ok := weights[encoding]
+		if !ok {
+			weight, ok = weights["*"]
+		}
+		if ok && weight > preferredWeight {
+			preferred, preferredWeight = encoding, weight
+		}
+	}
+	return preferred
+}
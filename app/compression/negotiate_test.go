@@ -0,0 +1,43 @@
+package compression
+
+import (
+	"testing"
+)
+
+// TestNegotiate checks the content coding negotiated for the Accept-Encoding headers
+func TestNegotiate(t *testing.T) {
+	cases := []struct {
+		name           string
+		acceptEncoding string
+		want           string
+	}{
+		{name: "missing", acceptEncoding: "", want: ""},
+		{name: "gzip", acceptEncoding: "gzip", want: GzipEncoding},
+		{name: "brotli", acceptEncoding: "br", want: BrotliEncoding},
+		{name: "case insensitive", acceptEncoding: "GZIP", want: GzipEncoding},
+		{name: "unsupported", acceptEncoding: "deflate, compress", want: ""},
+		{name: "equal weights", acceptEncoding: "gzip, deflate, br", want: BrotliEncoding},
+		{name: "higher q-value", acceptEncoding: "br;q=0.5, gzip;q=0.8", want: GzipEncoding},
+		{name: "q-value with spaces", acceptEncoding: "br ; q = 0.2, gzip ; q = 0.9", want: GzipEncoding},
+		{name: "zero q-value", acceptEncoding: "br;q=0, gzip", want: GzipEncoding},
+		{name: "every q-value zero", acceptEncoding: "br;q=0, gzip;q=0", want: ""},
+		{name: "malformed q-value", acceptEncoding: "br;q=high, gzip;q=0.5", want: BrotliEncoding},
+		{name: "identity", acceptEncoding: "identity", want: ""},
+		{name: "identity and gzip", acceptEncoding: "identity, gzip;q=0.5", want: GzipEncoding},
+		{name: "wildcard", acceptEncoding: "*", want: BrotliEncoding},
+		{name: "wildcard with lower q-value", acceptEncoding: "gzip, *;q=0.1", want: GzipEncoding},
+		{name: "wildcard excluding brotli", acceptEncoding: "br;q=0, *", want: GzipEncoding},
+		{name: "wildcard with zero q-value", acceptEncoding: "*;q=0", want: ""},
+		{name: "wildcard with zero q-value and gzip", acceptEncoding: "gzip;q=0.3, *;q=0", want: GzipEncoding},
+	}
+
+	for _, c := range cases {
+		t.Run(
+			c.name, func(t *testing.T) {
+				if got := Negotiate(c.acceptEncoding); got != c.want {
+					t.Errorf("Negotiate(%q) = %q, want %q", c.acceptEncoding, got, c.want)
+				}
+			},
+		)
+	}
+}
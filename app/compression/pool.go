@@ -0,0 +1,52 @@
+package compression
+
+import (
+	"compress/gzip"
+	"github.com/andybalholm/brotli"
+	"io"
+	"sync"
+)
+
+type (
+	// encoder is a compressing writer that can be reused for another response once closed
+	encoder interface {
+		io.WriteCloser
+		Flush() error
+		Reset(writer io.Writer)
+	}
+
+	// pools are the pools of the encoders of each content coding, which avoid allocating their large internal
+	// buffers on every response
+	pools map[string]*sync.Pool
+)
+
+// newPools creates the pools of the encoders with the given compression levels
+func newPools(gzipLevel, brotliLevel int) pools {
+	return pools{
+		GzipEncoding: {
+			New: func() any {
+				// The level is checked when the middleware is created
+				writer, _ := gzip.NewWriterLevel(io.Discard, gzipLevel)
+				return writer
+			},
+		},
+		BrotliEncoding: {
+			New: func() any {
+				return brotli.NewWriterLevel(io.Discard, brotliLevel)
+			},
+		},
+	}
+}
+
+// get returns an encoder of the given content coding that writes to the given writer
+func (p pools) get(encoding string, writer io.Writer) encoder {
+	e := p[encoding].Get().(encoder)
+	e.Reset(writer)
+	return e
+}
+
+// put returns the encoder of the given content coding to its pool
+func (p pools) put(encoding string, e encoder) {
+	e.Reset(io.Discard)
+	p[encoding].Put(e)
+}
@@ -0,0 +1,125 @@
+package compression
+
+import (
+	"bufio"
+	"bytes"
+	"github.com/gin-gonic/gin"
+	appcodec "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/codec"
+	"net"
+	"net/http"
+)
+
+// compressingWriter buffers the beginning of the response until it reaches the minimum size, and then compresses it
+// with the negotiated content coding if its content type is compressed. The smaller responses are written as is
+type compressingWriter struct {
+	gin.ResponseWriter
+	middleware *Middleware
+	encoding   string
+	buffer     bytes.Buffer
+	encoder    encoder
+	decided    bool
+	size       int
+}
+
+// Write buffers the body until the compression is decided, and then writes it compressed or as is
+func (w *compressingWriter) Write(data []byte) (int, error) {
+	w.size += len(data)
+	if w.decided {
+		return w.write(data)
+	}
+
+	w.buffer.Write(data)
+	if w.buffer.Len() >= w.middleware.minBytes {
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(data), nil
+}
+
+// WriteString buffers the body until the compression is decided, and then writes it compressed or as is
+func (w *compressingWriter) WriteString(data string) (int, error) {
+	return w.Write([]byte(data))
+}
+
+// Written checks if the body has been written, including the buffered one
+func (w *compressingWriter) Written() bool {
+	return w.buffer.Len() > 0 || w.ResponseWriter.Written()
+}
+
+// Size returns the size of the uncompressed body
+func (w *compressingWriter) Size() int {
+	if w.size == 0 {
+		return w.ResponseWriter.Size()
+	}
+	return w.size
+}
+
+// Flush writes the buffered body, compressing it if it is large enough, and flushes the encoder and the connection
+func (w *compressingWriter) Flush() {
+	if !w.decided {
+		_ = w.decide(w.buffer.Len() >= w.middleware.minBytes)
+	}
+	if w.encoder != nil {
+		_ = w.encoder.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// Hijack stops buffering the body before handing over the connection
+func (w *compressingWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.decided = true
+	return w.ResponseWriter.Hijack()
+}
+
+// decide decides whether the response is compressed, which is only possible if it is large enough, and writes the
+// buffered body
+func (w *compressingWriter) decide(largeEnough bool) error {
+	w.decided = true
+	header := w.Header()
+
+	if w.middleware.isCompressed(header.Get("Content-Type")) {
+		// The response depends on the accepted content codings, even if it ends up not being compressed
+		appcodec.AddVary(header, AcceptEncodingHeader)
+
+		if largeEnough && w.encoding != "" && header.Get(ContentEncodingHeader) == "" && bodyAllowed(w.Status()) {
+			header.Set(ContentEncodingHeader, w.encoding)
+			header.Del("Content-Length")
+			w.encoder = w.middleware.pools.get(w.encoding, w.ResponseWriter)
+		}
+	}
+
+	if w.buffer.Len() == 0 {
+		return nil
+	}
+	data := w.buffer.Bytes()
+	w.buffer = bytes.Buffer{}
+	_, err := w.write(data)
+	return err
+}
+
+// write writes the body compressed, if it is, or as is
+func (w *compressingWriter) write(data []byte) (int, error) {
+	if w.encoder != nil {
+		return w.encoder.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// close writes the rest of the body, which is not compressed if it is smaller than the minimum size, and returns
+// the encoder to its pool
+func (w *compressingWriter) close() {
+	if !w.decided {
+		_ = w.decide(false)
+	}
+	if w.encoder != nil {
+		_ = w.encoder.Close()
+		w.middleware.pools.put(w.encoding, w.encoder)
+		w.encoder = nil
+	}
+}
+
+// bodyAllowed checks if a response with the given status can have a body
+func bodyAllowed(status int) bool {
+	return status >= http.StatusOK && status != http.StatusNoContent && status != http.StatusNotModified
+}
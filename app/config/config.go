@@ -0,0 +1,613 @@
+package config
+
+import (
+	"github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app"
+	appaccesslog "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/accesslog"
+	appbody "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/body"
+	appbreaker "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/breaker"
+	appbruteforce "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/bruteforce"
+	appcache "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/cache"
+	appcompression "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/compression"
+	appcors "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/cors"
+	appcredentials "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/credentials"
+	appgrpc "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc"
+	apphealth "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/health"
+	appidempotency "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/idempotency"
+	applistener "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/listener"
+	appmetrics "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/metrics"
+	appratelimit "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/ratelimit"
+	appredis "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/redis"
+	appretry "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/retry"
+	apptimeout "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/timeout"
+	apptracing "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/tracing"
+	"net"
+	"time"
+)
+
+type (
+	// Config is the resolved configuration of the API gateway
+	Config struct {
+		Listener    ListenerConfig        `yaml:"listener" json:"listener"`
+		Swagger     SwaggerConfig         `yaml:"swagger" json:"swagger"`
+		Services    ServicesConfig        `yaml:"services" json:"services"`
+		Credentials appcredentials.Config `yaml:"credentials" json:"credentials"`
+		JWT         JWTConfig             `yaml:"jwt" json:"jwt"`
+		Health      HealthConfig          `yaml:"health" json:"health"`
+		Metrics     MetricsConfig         `yaml:"metrics" json:"metrics"`
+		Tracing     TracingConfig         `yaml:"tracing" json:"tracing"`
+		Logging     LoggingConfig         `yaml:"logging" json:"logging"`
+		Redis       appredis.Config       `yaml:"redis" json:"redis"`
+		RateLimit   RateLimitConfig       `yaml:"rate_limit" json:"rate_limit"`
+		LogIn       LogInConfig           `yaml:"log_in" json:"log_in"`
+		CORS        CORSConfig            `yaml:"cors" json:"cors"`
+		Timeouts    TimeoutsConfig        `yaml:"timeouts" json:"timeouts"`
+		Retry       RetryConfig           `yaml:"retry" json:"retry"`
+		Breakers    BreakersConfig        `yaml:"circuit_breakers" json:"circuit_breakers"`
+		Idempotency IdempotencyConfig     `yaml:"idempotency" json:"idempotency"`
+		Cache       CacheConfig           `yaml:"cache" json:"cache"`
+		Body        BodyConfig            `yaml:"body" json:"body"`
+		Compression CompressionConfig     `yaml:"compression" json:"compression"`
+		JSON        JSONConfig            `yaml:"json" json:"json"`
+
+		// LoadedVariables are the keys of the environment variables that overrode the configuration file
+		LoadedVariables []string `yaml:"-" json:"-"`
+	}
+
+	// ListenerConfig is the configuration of the HTTP listener, with the IPs and CIDRs of the proxies whose
+	// X-Forwarded-For headers are trusted to get the IP of the client. No proxy is trusted by default, so the IP of the
+	// client is the remote address of the connection
+	ListenerConfig struct {
+		Host            string   `yaml:"host" json:"host"`
+		Port            string   `yaml:"port" json:"port"`
+		ShutdownTimeout Duration `yaml:"shutdown_timeout" json:"shutdown_timeout"`
+		TrustedProxies  []string `yaml:"trusted_proxies" json:"trusted_proxies"`
+		TrustedPlatform string   `yaml:"trusted_platform" json:"trusted_platform"`
+	}
+
+	// SwaggerConfig is the configuration of the Swagger docs
+	SwaggerConfig struct {
+		Host string `yaml:"host" json:"host"`
+	}
+
+	// ServicesConfig is the configuration of the URIs of the backend gRPC services
+	ServicesConfig struct {
+		Auth    string `yaml:"auth" json:"auth"`
+		User    string `yaml:"user" json:"user"`
+		Shop    string `yaml:"shop" json:"shop"`
+		Payment string `yaml:"payment" json:"payment"`
+		Order   string `yaml:"order" json:"order"`
+	}
+
+	// JWTConfig is the configuration of the JWT validation
+	JWTConfig struct {
+		PublicKey string `yaml:"public_key" json:"public_key"`
+	}
+
+	// HealthConfig is the configuration of the backend services health checker
+	HealthConfig struct {
+		RefreshInterval Duration `yaml:"refresh_interval" json:"refresh_interval"`
+		CheckTimeout    Duration `yaml:"check_timeout" json:"check_timeout"`
+	}
+
+	// MetricsConfig is the configuration of the metrics listener
+	MetricsConfig struct {
+		Enabled bool   `yaml:"enabled" json:"enabled"`
+		Port    string `yaml:"port" json:"port"`
+	}
+
+	// TracingConfig is the configuration of the distributed tracing
+	TracingConfig struct {
+		Exporter     string  `yaml:"exporter" json:"exporter"`
+		OTLPEndpoint string  `yaml:"otlp_endpoint" json:"otlp_endpoint"`
+		SampleRatio  float64 `yaml:"sample_ratio" json:"sample_ratio"`
+		ServiceName  string  `yaml:"service_name" json:"service_name"`
+	}
+
+	// LoggingConfig is the configuration of the structured logs
+	LoggingConfig struct {
+		Level         string `yaml:"level" json:"level"`
+		RequestBodies bool   `yaml:"request_bodies" json:"request_bodies"`
+	}
+
+	// RateLimitConfig is the configuration of the rate limits, whose routes are indexed by their route key, like
+	// "POST /api/v1/auth/log-in"
+	RateLimitConfig struct {
+		Enabled bool                 `yaml:"enabled" json:"enabled"`
+		Backend string               `yaml:"backend" json:"backend"`
+		Default *RateLimit           `yaml:"default" json:"default"`
+		Routes  map[string]RateLimit `yaml:"routes" json:"routes"`
+	}
+
+	// RateLimit is a token bucket limit, which allows Requests requests every Period with bursts of up to Burst
+	// requests
+	RateLimit struct {
+		Requests int      `yaml:"requests" json:"requests"`
+		Period   Duration `yaml:"period" json:"period"`
+		Burst    int      `yaml:"burst" json:"burst"`
+	}
+
+	// LogInConfig is the configuration of the log-in brute-force protection, whose lockout state is exposed to the
+	// users with the admin role, as assigned by the auth service
+	LogInConfig struct {
+		Enabled   bool        `yaml:"enabled" json:"enabled"`
+		AdminRole string      `yaml:"admin_role" json:"admin_role"`
+		Username  LogInPolicy `yaml:"username" json:"username"`
+		IP        LogInPolicy `yaml:"ip" json:"ip"`
+	}
+
+	// TimeoutsConfig is the configuration of the deadlines of the backend calls, with the timeouts of the routes
+	// indexed by their route key, like "POST /api/v1/payments/orders/pay/{order-id}"
+	TimeoutsConfig struct {
+		Default Duration            `yaml:"default" json:"default"`
+		Routes  map[string]Duration `yaml:"routes" json:"routes"`
+	}
+
+	// RetryConfig is the configuration of the retries of the backend calls, with the policies of the RPCs indexed by
+	// their name, like GetBusiness, and the names of the RPCs that are not read-only but are safe to repeat
+	RetryConfig struct {
+		Enabled           bool                   `yaml:"enabled" json:"enabled"`
+		Default           RetryPolicy            `yaml:"default" json:"default"`
+		Methods           map[string]RetryPolicy `yaml:"methods" json:"methods"`
+		IdempotentMethods []string               `yaml:"idempotent_methods" json:"idempotent_methods"`
+	}
+
+	// RetryPolicy is the retry policy of an RPC, whose waits between attempts grow exponentially with jitter
+	RetryPolicy struct {
+		MaxAttempts    int      `yaml:"max_attempts" json:"max_attempts"`
+		InitialBackoff Duration `yaml:"initial_backoff" json:"initial_backoff"`
+		MaxBackoff     Duration `yaml:"max_backoff" json:"max_backoff"`
+		Multiplier     float64  `yaml:"multiplier" json:"multiplier"`
+		Jitter         float64  `yaml:"jitter" json:"jitter"`
+	}
+
+	// BreakersConfig is the configuration of the circuit breakers, with the policies of the backend services indexed
+	// by their name, like payment
+	BreakersConfig struct {
+		Enabled  bool                     `yaml:"enabled" json:"enabled"`
+		Default  BreakerPolicy            `yaml:"default" json:"default"`
+		Services map[string]BreakerPolicy `yaml:"services" json:"services"`
+	}
+
+	// BreakerPolicy is the circuit breaker policy of a backend service
+	BreakerPolicy struct {
+		FailureThreshold int      `yaml:"failure_threshold" json:"failure_threshold"`
+		OpenDuration     Duration `yaml:"open_duration" json:"open_duration"`
+		HalfOpenProbes   int      `yaml:"half_open_probes" json:"half_open_probes"`
+	}
+
+	// IdempotencyConfig is the configuration of the idempotency keys, with the route keys of the routes that honor
+	// them, like "POST /api/v1/payments/orders/pay/{order-id}"
+	IdempotencyConfig struct {
+		Enabled  bool     `yaml:"enabled" json:"enabled"`
+		Backend  string   `yaml:"backend" json:"backend"`
+		Routes   []string `yaml:"routes" json:"routes"`
+		TTL      Duration `yaml:"ttl" json:"ttl"`
+		LockTTL  Duration `yaml:"lock_ttl" json:"lock_ttl"`
+		Required bool     `yaml:"required" json:"required"`
+	}
+
+	// CacheConfig is the configuration of the response caching, with the policies of the cached routes indexed by
+	// their route key, like "GET /api/v1/shops/products/{product-id}", the keys of the cached routes evicted by each
+	// mutating route, like "PUT /api/v1/shops/products/{product-id}", and the bounds of the in-memory cache
+	CacheConfig struct {
+		Enabled       bool                   `yaml:"enabled" json:"enabled"`
+		MaxEntries    int                    `yaml:"max_entries" json:"max_entries"`
+		MaxBytes      int                    `yaml:"max_bytes" json:"max_bytes"`
+		Routes        map[string]CachePolicy `yaml:"routes" json:"routes"`
+		Invalidations map[string][]string    `yaml:"invalidations" json:"invalidations"`
+	}
+
+	// CachePolicy is the caching policy of a route. Its responses are sent with the given Cache-Control and stored in
+	// the in-memory cache for the given TTL, if it is enabled
+	CachePolicy struct {
+		CacheControl string   `yaml:"cache_control" json:"cache_control"`
+		TTL          Duration `yaml:"ttl" json:"ttl"`
+	}
+
+	// BodyConfig is the configuration of the request bodies, with the default maximum size in bytes, the maximum sizes
+	// of the routes indexed by their route key, like "POST /api/v1/shops/products/", and whether the bodies with
+	// unknown fields are rejected
+	BodyConfig struct {
+		MaxBytes int            `yaml:"max_bytes" json:"max_bytes"`
+		Routes   map[string]int `yaml:"routes" json:"routes"`
+		Strict   bool           `yaml:"strict" json:"strict"`
+	}
+
+	// CompressionConfig is the configuration of the compression of the responses, with the minimum size in bytes of
+	// the compressed responses, their content types and the levels of each content coding
+	CompressionConfig struct {
+		Enabled      bool     `yaml:"enabled" json:"enabled"`
+		MinBytes     int      `yaml:"min_bytes" json:"min_bytes"`
+		ContentTypes []string `yaml:"content_types" json:"content_types"`
+		GzipLevel    int      `yaml:"gzip_level" json:"gzip_level"`
+		BrotliLevel  int      `yaml:"brotli_level" json:"brotli_level"`
+	}
+
+	// JSONConfig is the configuration of the JSON bodies, which are encoded and decoded with encoding/json unless the
+	// canonical JSON mapping of protobuf is enabled, with the naming of its fields, whether the fields with their
+	// default values are written and whether the enums are written as numbers
+	JSONConfig struct {
+		ProtoJSON      bool `yaml:"protojson" json:"protojson"`
+		UseProtoNames  bool `yaml:"use_proto_names" json:"use_proto_names"`
+		EmitDefaults   bool `yaml:"emit_defaults" json:"emit_defaults"`
+		EnumsAsNumbers bool `yaml:"enums_as_numbers" json:"enums_as_numbers"`
+	}
+
+	// CORSConfig is the configuration of the CORS policies, with the default policy and the policies of the route
+	// groups indexed by their path prefix, like /api/v1/auth/roles
+	CORSConfig struct {
+		Default CORSPolicy            `yaml:"default" json:"default"`
+		Groups  map[string]CORSPolicy `yaml:"groups" json:"groups"`
+	}
+
+	// CORSPolicy is the CORS policy of a route group. Its empty methods, headers and max age are inherited from the
+	// default policy, while its origins and credentials are not
+	CORSPolicy struct {
+		AllowedOrigins   []string `yaml:"allowed_origins" json:"allowed_origins"`
+		AllowedMethods   []string `yaml:"allowed_methods" json:"allowed_methods"`
+		AllowedHeaders   []string `yaml:"allowed_headers" json:"allowed_headers"`
+		ExposedHeaders   []string `yaml:"exposed_headers" json:"exposed_headers"`
+		AllowCredentials bool     `yaml:"allow_credentials" json:"allow_credentials"`
+		MaxAge           Duration `yaml:"max_age" json:"max_age"`
+	}
+
+	// LogInPolicy is the policy applied to the failed log-in attempts of a username or an IP
+	LogInPolicy struct {
+		BackoffAfter    int      `yaml:"backoff_after" json:"backoff_after"`
+		BaseDelay       Duration `yaml:"base_delay" json:"base_delay"`
+		MaxDelay        Duration `yaml:"max_delay" json:"max_delay"`
+		LockoutAfter    int      `yaml:"lockout_after" json:"lockout_after"`
+		LockoutDuration Duration `yaml:"lockout_duration" json:"lockout_duration"`
+		Window          Duration `yaml:"window" json:"window"`
+	}
+)
+
+// newDefaultConfig creates the configuration used for the values that are not set by any source
+func newDefaultConfig() *Config {
+	return &Config{
+		Listener: ListenerConfig{
+			Host:            applistener.DefaultHost,
+			Port:            applistener.DefaultPort,
+			ShutdownTimeout: Duration(applistener.DefaultShutdownTimeout),
+		},
+		Credentials: appcredentials.Config{
+			Provider:    appcredentials.GoogleProvider,
+			Transport:   appcredentials.SystemTransport,
+			TLSCertPath: app.ServerCertPath,
+		},
+		Health: HealthConfig{
+			RefreshInterval: Duration(apphealth.DefaultRefreshInterval),
+			CheckTimeout:    Duration(apphealth.DefaultCheckTimeout),
+		},
+		Metrics: MetricsConfig{
+			Enabled: true,
+			Port:    appmetrics.DefaultPort,
+		},
+		Tracing: TracingConfig{
+			Exporter:    apptracing.NoneExporter,
+			SampleRatio: apptracing.DefaultSampleRatio,
+			ServiceName: apptracing.DefaultServiceName,
+		},
+		Logging: LoggingConfig{
+			Level: appaccesslog.DefaultLevel,
+		},
+		RateLimit: RateLimitConfig{
+			Enabled: true,
+			Backend: appratelimit.MemoryBackend,
+			Default: &RateLimit{Requests: 300, Period: Duration(time.Minute), Burst: 60},
+			Routes: map[string]RateLimit{
+				"POST /api/v1/auth/log-in":                           {Requests: 10, Period: Duration(time.Minute), Burst: 5},
+				"POST /api/v1/users/forgot-password":                 {Requests: 3, Period: Duration(10 * time.Minute), Burst: 3},
+				"POST /api/v1/users/emails/send-verification":        {Requests: 3, Period: Duration(10 * time.Minute), Burst: 3},
+				"POST /api/v1/users/phone-numbers/send-verification": {Requests: 3, Period: Duration(10 * time.Minute), Burst: 3},
+			},
+		},
+		Timeouts: TimeoutsConfig{
+			Default: Duration(apptimeout.DefaultTimeout),
+			Routes: map[string]Duration{
+				"GET /api/v1/shops/products/{product-id}":                 Duration(3 * time.Second),
+				"POST /api/v1/orders/carts/current/checkout":              Duration(20 * time.Second),
+				"POST /api/v1/payments/orders/pay/{order-id}":             Duration(30 * time.Second),
+				"POST /api/v1/payments/branch-rents/pay/{branch-rent-id}": Duration(30 * time.Second),
+			},
+		},
+		Retry: RetryConfig{
+			Enabled: true,
+			Default: RetryPolicy{
+				MaxAttempts:    appretry.DefaultMaxAttempts,
+				InitialBackoff: Duration(appretry.DefaultInitialBackoff),
+				MaxBackoff:     Duration(appretry.DefaultMaxBackoff),
+				Multiplier:     appretry.DefaultMultiplier,
+				Jitter:         appretry.DefaultJitter,
+			},
+		},
+		Breakers: BreakersConfig{
+			Enabled: true,
+			Default: BreakerPolicy{
+				FailureThreshold: appbreaker.DefaultFailureThreshold,
+				OpenDuration:     Duration(appbreaker.DefaultOpenDuration),
+				HalfOpenProbes:   appbreaker.DefaultHalfOpenProbes,
+			},
+		},
+		Idempotency: IdempotencyConfig{
+			Enabled: true,
+			Backend: appidempotency.MemoryBackend,
+			Routes:  appidempotency.DefaultRoutes,
+			TTL:     Duration(appidempotency.DefaultTTL),
+			LockTTL: Duration(appidempotency.DefaultLockTTL),
+		},
+		Cache: CacheConfig{
+			Enabled:       true,
+			MaxEntries:    appcache.DefaultMaxEntries,
+			MaxBytes:      appcache.DefaultMaxBytes,
+			Routes:        newDefaultCachePolicies(),
+			Invalidations: newDefaultCacheInvalidations(),
+		},
+		Body: BodyConfig{
+			MaxBytes: appbody.DefaultMaxBytes,
+			Routes:   newDefaultBodyLimits(),
+		},
+		Compression: CompressionConfig{
+			Enabled:      true,
+			MinBytes:     appcompression.DefaultMinBytes,
+			ContentTypes: appcompression.DefaultContentTypes,
+			GzipLevel:    appcompression.DefaultGzipLevel,
+			BrotliLevel:  appcompression.DefaultBrotliLevel,
+		},
+		CORS: CORSConfig{
+			Default: CORSPolicy{
+				AllowedMethods:   appcors.DefaultAllowedMethods,
+				AllowedHeaders:   appcors.DefaultAllowedHeaders,
+				ExposedHeaders:   appcors.DefaultExposedHeaders,
+				AllowCredentials: true,
+				MaxAge:           Duration(appcors.DefaultMaxAge),
+			},
+			Groups: newRestrictedCORSGroups(),
+		},
+		LogIn: LogInConfig{
+			Enabled:   true,
+			AdminRole: appbruteforce.DefaultAdminRole,
+			Username: LogInPolicy{
+				BackoffAfter:    3,
+				BaseDelay:       Duration(time.Second),
+				MaxDelay:        Duration(time.Minute),
+				LockoutAfter:    10,
+				LockoutDuration: Duration(15 * time.Minute),
+				Window:          Duration(time.Hour),
+			},
+			IP: LogInPolicy{
+				BackoffAfter:    20,
+				BaseDelay:       Duration(time.Second),
+				MaxDelay:        Duration(time.Minute),
+				LockoutAfter:    100,
+				LockoutDuration: Duration(30 * time.Minute),
+				Window:          Duration(time.Hour),
+			},
+		},
+	}
+}
+
+// FormattedAddress returns the address the HTTP listener binds to
+func (l *ListenerConfig) FormattedAddress() string {
+	return net.JoinHostPort(l.Host, l.Port)
+}
+
+// TrustsClientIP checks if the IP of the clients is taken from a trusted proxy or platform, instead of being the one
+// of the peer of the connection
+func (l *ListenerConfig) TrustsClientIP() bool {
+	return len(l.TrustedProxies) > 0 || l.TrustedPlatform != ""
+}
+
+// FormattedAddress returns the address the metrics listener binds to
+func (m *MetricsConfig) FormattedAddress(host string) string {
+	return net.JoinHostPort(host, m.Port)
+}
+
+// URIs returns the URIs of the backend services, indexed by the key of their URI
+func (s *ServicesConfig) URIs() map[string]string {
+	return map[string]string{
+		appgrpc.AuthServiceUriKey:    s.Auth,
+		appgrpc.UserServiceUriKey:    s.User,
+		appgrpc.ShopServiceUriKey:    s.Shop,
+		appgrpc.PaymentServiceUriKey: s.Payment,
+		appgrpc.OrderServiceUriKey:   s.Order,
+	}
+}
+
+// UsesRedis checks if any subsystem is configured with the Redis backend
+func (c *Config) UsesRedis() bool {
+	return (c.RateLimit.Enabled && c.RateLimit.Backend == appratelimit.RedisBackend) ||
+		(c.Idempotency.Enabled && c.Idempotency.Backend == appidempotency.RedisBackend)
+}
+
+// Limit returns the rate limit as used by the rate limiter
+func (r RateLimit) Limit() appratelimit.Limit {
+	return appratelimit.Limit{
+		Requests: r.Requests,
+		Period:   r.Period.Duration(),
+		Burst:    r.Burst,
+	}
+}
+
+// DefaultLimit returns the rate limit shared by the routes without their own limit, or nil if they are not limited
+func (r *RateLimitConfig) DefaultLimit() *appratelimit.Limit {
+	if r.Default == nil {
+		return nil
+	}
+	limit := r.Default.Limit()
+	return &limit
+}
+
+// RouteLimits returns the rate limits of the routes, indexed by their route key
+func (r *RateLimitConfig) RouteLimits() map[string]appratelimit.Limit {
+	limits := make(map[string]appratelimit.Limit, len(r.Routes))
+	for key, limit := range r.Routes {
+		limits[key] = limit.Limit()
+	}
+	return limits
+}
+
+// RouteTimeouts returns the timeouts of the routes, indexed by their route key
+func (t *TimeoutsConfig) RouteTimeouts() map[string]time.Duration {
+	timeouts := make(map[string]time.Duration, len(t.Routes))
+	for key, timeout := range t.Routes {
+		timeouts[key] = timeout.Duration()
+	}
+	return timeouts
+}
+
+// Policy returns the retry policy as used by the retrier
+func (r RetryPolicy) Policy() appretry.Policy {
+	return appretry.Policy{
+		MaxAttempts:    r.MaxAttempts,
+		InitialBackoff: r.InitialBackoff.Duration(),
+		MaxBackoff:     r.MaxBackoff.Duration(),
+		Multiplier:     r.Multiplier,
+		Jitter:         r.Jitter,
+	}
+}
+
+// MethodPolicies returns the retry policies of the RPCs, indexed by their name
+func (r *RetryConfig) MethodPolicies() map[string]appretry.Policy {
+	policies := make(map[string]appretry.Policy, len(r.Methods))
+	for method, policy := range r.Methods {
+		policies[method] = policy.Policy()
+	}
+	return policies
+}
+
+// Policy returns the circuit breaker policy as used by the circuit breakers
+func (b BreakerPolicy) Policy() appbreaker.Policy {
+	return appbreaker.Policy{
+		FailureThreshold: b.FailureThreshold,
+		OpenDuration:     b.OpenDuration.Duration(),
+		HalfOpenProbes:   b.HalfOpenProbes,
+	}
+}
+
+// ServicePolicies returns the circuit breaker policies of the backend services, indexed by their name
+func (b *BreakersConfig) ServicePolicies() map[string]appbreaker.Policy {
+	policies := make(map[string]appbreaker.Policy, len(b.Services))
+	for service, policy := range b.Services {
+		policies[service] = policy.Policy()
+	}
+	return policies
+}
+
+// Policy returns the caching policy as used by the response cache
+func (c CachePolicy) Policy() appcache.Policy {
+	return appcache.Policy{
+		CacheControl: c.CacheControl,
+		TTL:          c.TTL.Duration(),
+	}
+}
+
+// RoutePolicies returns the caching policies of the routes, indexed by their route key
+func (c *CacheConfig) RoutePolicies() map[string]appcache.Policy {
+	policies := make(map[string]appcache.Policy, len(c.Routes))
+	for key, policy := range c.Routes {
+		policies[key] = policy.Policy()
+	}
+	return policies
+}
+
+// newDefaultCachePolicies creates the default policies of the read-heavy routes cached by default
+func newDefaultCachePolicies() map[string]CachePolicy {
+	policies := make(map[string]CachePolicy, len(appcache.DefaultRoutes))
+	for _, key := range appcache.DefaultRoutes {
+		policies[key] = CachePolicy{
+			CacheControl: appcache.DefaultCacheControl,
+			TTL:          Duration(appcache.DefaultTTL),
+		}
+	}
+	return policies
+}
+
+// newDefaultCacheInvalidations creates a copy of the default invalidations, so the configuration file does not modify
+// the defaults when merged into them
+func newDefaultCacheInvalidations() map[string][]string {
+	invalidations := make(map[string][]string, len(appcache.DefaultInvalidations))
+	for key, routes := range appcache.DefaultInvalidations {
+		invalidations[key] = routes
+	}
+	return invalidations
+}
+
+// newDefaultBodyLimits creates a copy of the default maximum sizes of the request bodies of the routes, so the
+// configuration file does not modify the defaults when merged into them
+func newDefaultBodyLimits() map[string]int {
+	limits := make(map[string]int, len(appbody.DefaultRoutes))
+	for key, maxBytes := range appbody.DefaultRoutes {
+		limits[key] = maxBytes
+	}
+	return limits
+}
+
+// newRestrictedCORSGroups creates the default policies of the restricted route groups, which do not allow any
+// cross-origin request unless their origins are configured
+func newRestrictedCORSGroups() map[string]CORSPolicy {
+	groups := make(map[string]CORSPolicy, len(appcors.RestrictedGroups))
+	for _, prefix := range appcors.RestrictedGroups {
+		groups[prefix] = CORSPolicy{AllowCredentials: true}
+	}
+	return groups
+}
+
+// allowDevOrigins allows the local development origins in the policies without any origin
+func (c *CORSConfig) allowDevOrigins() {
+	if len(c.Default.AllowedOrigins) == 0 {
+		c.Default.AllowedOrigins = appcors.DevAllowedOrigins
+	}
+	for prefix, policy := range c.Groups {
+		if len(policy.AllowedOrigins) == 0 {
+			policy.AllowedOrigins = appcors.DevAllowedOrigins
+			c.Groups[prefix] = policy
+		}
+	}
+}
+
+// Policy returns the CORS policy as used by the CORS middleware
+func (c CORSPolicy) Policy() appcors.Policy {
+	return appcors.Policy{
+		AllowedOrigins:   c.AllowedOrigins,
+		AllowedMethods:   c.AllowedMethods,
+		AllowedHeaders:   c.AllowedHeaders,
+		ExposedHeaders:   c.ExposedHeaders,
+		AllowCredentials: c.AllowCredentials,
+		MaxAge:           c.MaxAge.Duration(),
+	}
+}
+
+// GroupPolicies returns the CORS policies of the route groups, indexed by their path prefix
+func (c *CORSConfig) GroupPolicies() map[string]appcors.Policy {
+	policies := make(map[string]appcors.Policy, len(c.Groups))
+	for prefix, policy := range c.Groups {
+		policies[prefix] = policy.Policy()
+	}
+	return policies
+}
+
+// Policy returns the log-in policy as used by the log-in guard
+func (l LogInPolicy) Policy() appbruteforce.Policy {
+	return appbruteforce.Policy{
+		BackoffAfter:    l.BackoffAfter,
+		BaseDelay:       l.BaseDelay.Duration(),
+		MaxDelay:        l.MaxDelay.Duration(),
+		LockoutAfter:    l.LockoutAfter,
+		LockoutDuration: l.LockoutDuration.Duration(),
+		Window:          l.Window.Duration(),
+	}
+}
+
+// Redacted returns a copy of the configuration whose secrets have been replaced, so it can be exposed
+func (c *Config) Redacted() *Config {
+	redacted := *c
+	redacted.LoadedVariables = nil
+	if redacted.Credentials.StaticToken != "" {
+		redacted.Credentials.StaticToken = RedactedValue
+	}
+	if redacted.Redis.Password != "" {
+		redacted.Redis.Password = RedactedValue
+	}
+	return &redacted
+}
@@ -0,0 +1,21 @@
+package config
+
+const (
+	// FileKey is the key of the path to the configuration file
+	FileKey = "CONFIG_FILE"
+
+	// FileFlag is the name of the flag with the path to the configuration file
+	FileFlag = "config"
+
+	// PortFlag is the name of the flag with the port the API gateway listens on
+	PortFlag = "port"
+
+	// SwaggerHostFlag is the name of the flag with the host shown in the Swagger docs
+	SwaggerHostFlag = "swagger-host"
+
+	// DebugPath is the path of the endpoint that exposes the resolved configuration in development mode
+	DebugPath = "/debug/config"
+
+	// RedactedValue replaces the secrets in the exposed configuration
+	RedactedValue = "[REDACTED]"
+)
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// Controller struct for the debug configuration module
+// @Summary Debug Configuration Router Group
+// @Description Router group for the resolved configuration, only available in development mode
+// @Tags debug
+// @Produce json
+// @Router /debug [group]
+type Controller struct {
+	engine *gin.Engine
+	config *Config
+}
+
+// NewController creates a new debug configuration controller
+func NewController(engine *gin.Engine, config *Config) (*Controller, error) {
+	// Check if the config is nil
+	if config == nil {
+		return nil, NilConfigError
+	}
+
+	return &Controller{
+		engine: engine,
+		config: config,
+	}, nil
+}
+
+// Initialize initializes the routes for the controller
+func (c *Controller) Initialize() {
+	c.engine.GET(DebugPath, c.getConfig)
+}
+
+// getConfig gets the resolved configuration
+// @Summary Get the resolved configuration
+// @Description Get the resolved configuration with its secrets redacted
+// @Tags debug
+// @Produce json
+// @Success 200 {object} Config
+// @Router /debug/config [get]
+func (c *Controller) getConfig(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, c.config.Redacted())
+}
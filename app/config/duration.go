@@ -0,0 +1,40 @@
+package config
+
+import (
+	"encoding/json"
+	"gopkg.in/yaml.v3"
+	"time"
+)
+
+// Duration is a time.Duration that is written as a Go duration string, like "10s", in the configuration file and in
+// the exposed configuration
+type Duration time.Duration
+
+// ParseDuration parses a Go duration string
+func ParseDuration(value string) (Duration, error) {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, InvalidDurationError
+	}
+	return Duration(duration), nil
+}
+
+// Duration returns the duration as a time.Duration
+func (d Duration) Duration() time.Duration {
+	return time.Duration(d)
+}
+
+// UnmarshalYAML parses the duration from a YAML string
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	duration, err := ParseDuration(node.Value)
+	if err != nil {
+		return err
+	}
+	*d = duration
+	return nil
+}
+
+// MarshalJSON writes the duration as a JSON string
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
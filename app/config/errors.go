@@ -0,0 +1,29 @@
+package config
+
+import (
+	"errors"
+)
+
+var (
+	NilLoggerError           = errors.New("nil logger")
+	NilConfigError           = errors.New("nil config")
+	FailedToReadFileError    = errors.New("failed to read the configuration file")
+	FailedToParseFileError   = errors.New("failed to parse the configuration file")
+	MissingValueError        = errors.New("missing value")
+	InvalidNumberError       = errors.New("invalid number")
+	InvalidURLError          = errors.New("invalid URL, expected an absolute http or https URL")
+	InvalidChoiceError       = errors.New("invalid choice")
+	RequestBodiesInProdError = errors.New("the request bodies cannot be logged in production")
+	OutOfRangeError          = errors.New("value out of range")
+	InvalidBoolError         = errors.New("invalid boolean")
+	SamePortError            = errors.New("port already used by the API listener")
+	InvalidDurationError     = errors.New("invalid duration")
+	NonPositiveDurationError = errors.New("duration must be positive")
+	InvalidPortError         = errors.New("invalid port")
+	InvalidURIError          = errors.New("invalid URI, expected host[:port] without a scheme")
+	InvalidIPError           = errors.New("invalid IP, expected an IP address or a CIDR")
+	UntrustedClientIPError   = errors.New("untrusted client IP, set the trusted proxies or the trusted platform")
+	InvalidPEMError          = errors.New("invalid PEM block")
+	InvalidPublicKeyError    = errors.New("invalid public key")
+	UnexpectedPublicKeyError = errors.New("public key is not an ED25519 key")
+)
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"flag"
+)
+
+var (
+	// fileFlag is the path to the configuration file given through the flags
+	fileFlag *string
+
+	// portFlag is the port given through the flags
+	portFlag *string
+
+	// swaggerHostFlag is the Swagger host given through the flags
+	swaggerHostFlag *string
+)
+
+// SetFlags declares the configuration flags. It must be called before the flags are parsed
+func SetFlags() {
+	fileFlag = flag.String(FileFlag, "", "Path to the YAML configuration file")
+	portFlag = flag.String(PortFlag, "", "Port the API gateway listens on")
+	swaggerHostFlag = flag.String(SwaggerHostFlag, "", "Host shown in the Swagger docs")
+}
+
+// flagValue returns the value of a flag, or an empty string if the flags were not declared
+func flagValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,248 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app"
+	appaccesslog "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/accesslog"
+	appbody "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/body"
+	appbreaker "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/breaker"
+	appbruteforce "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/bruteforce"
+	appcache "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/cache"
+	appcodec "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/codec"
+	appcompression "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/compression"
+	appcors "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/cors"
+	appcredentials "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/credentials"
+	appgrpc "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc"
+	apphealth "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/health"
+	appidempotency "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/idempotency"
+	appjwt "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/jwt"
+	applistener "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/listener"
+	appmetrics "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/metrics"
+	appratelimit "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/ratelimit"
+	appredis "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/redis"
+	appretry "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/retry"
+	apptimeout "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/timeout"
+	apptracing "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/tracing"
+	"gopkg.in/yaml.v3"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Load resolves the configuration by merging, in increasing order of precedence, the defaults, the configuration
+// file, the environment variables and the flags. Every invalid value is reported at once
+func Load(isProd bool) (*Config, error) {
+	config := newDefaultConfig()
+
+	// Load the configuration file, if any
+	path := flagValue(fileFlag)
+	if path == "" {
+		path = os.Getenv(FileKey)
+	}
+	if path != "" {
+		if err := config.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	// Override the configuration with the environment variables and the flags
+	errs := config.loadEnvironment()
+	config.loadFlags()
+
+	// Set the values that depend on other values
+	if config.Swagger.Host == "" {
+		if isProd {
+			config.Swagger.Host = app.ProdSwaggerHost
+		} else {
+			config.Swagger.Host = "localhost:" + config.Listener.Port
+		}
+	}
+	if !isProd {
+		config.CORS.allowDevOrigins()
+	}
+
+	// Validate the configuration
+	if err := config.Validate(isProd); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return config, nil
+}
+
+// loadFile loads the YAML configuration file at the given path
+func (c *Config) loadFile(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", FailedToReadFileError, path, err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err = decoder.Decode(c); err != nil {
+		return fmt.Errorf("%w: %s: %w", FailedToParseFileError, path, err)
+	}
+	return nil
+}
+
+// loadEnvironment overrides the configuration with the environment variables that are set
+func (c *Config) loadEnvironment() []error {
+	stringFields := map[string]*string{
+		applistener.PortKey:             &c.Listener.Port,
+		applistener.TrustedPlatformKey:  &c.Listener.TrustedPlatform,
+		app.SwaggerHostKey:              &c.Swagger.Host,
+		appgrpc.AuthServiceUriKey:       &c.Services.Auth,
+		appgrpc.UserServiceUriKey:       &c.Services.User,
+		appgrpc.ShopServiceUriKey:       &c.Services.Shop,
+		appgrpc.PaymentServiceUriKey:    &c.Services.Payment,
+		appgrpc.OrderServiceUriKey:      &c.Services.Order,
+		appjwt.PublicKey:                &c.JWT.PublicKey,
+		appcredentials.ProviderKey:      &c.Credentials.Provider,
+		appcredentials.StaticTokenKey:   &c.Credentials.StaticToken,
+		appcredentials.TransportKey:     &c.Credentials.Transport,
+		appcredentials.TLSCertPathKey:   &c.Credentials.TLSCertPath,
+		appcredentials.TLSServerNameKey: &c.Credentials.TLSServerName,
+		appmetrics.PortKey:              &c.Metrics.Port,
+		apptracing.ExporterKey:          &c.Tracing.Exporter,
+		apptracing.OTLPEndpointKey:      &c.Tracing.OTLPEndpoint,
+		apptracing.ServiceNameKey:       &c.Tracing.ServiceName,
+		appaccesslog.LevelKey:           &c.Logging.Level,
+		appredis.AddressKey:             &c.Redis.Address,
+		appredis.PasswordKey:            &c.Redis.Password,
+		appratelimit.BackendKey:         &c.RateLimit.Backend,
+		appidempotency.BackendKey:       &c.Idempotency.Backend,
+		appbruteforce.AdminRoleKey:      &c.LogIn.AdminRole,
+	}
+	durationFields := map[string]*Duration{
+		applistener.ShutdownTimeoutKey: &c.Listener.ShutdownTimeout,
+		apphealth.RefreshIntervalKey:   &c.Health.RefreshInterval,
+		apphealth.CheckTimeoutKey:      &c.Health.CheckTimeout,
+		apptimeout.DefaultKey:          &c.Timeouts.Default,
+	}
+
+	boolFields := map[string]*bool{
+		appmetrics.EnabledKey:         &c.Metrics.Enabled,
+		appaccesslog.RequestBodiesKey: &c.Logging.RequestBodies,
+		appratelimit.EnabledKey:       &c.RateLimit.Enabled,
+		appbruteforce.EnabledKey:      &c.LogIn.Enabled,
+		appretry.EnabledKey:           &c.Retry.Enabled,
+		appbreaker.EnabledKey:         &c.Breakers.Enabled,
+		appidempotency.EnabledKey:     &c.Idempotency.Enabled,
+		appcache.EnabledKey:           &c.Cache.Enabled,
+		appbody.StrictKey:             &c.Body.Strict,
+		appcompression.EnabledKey:     &c.Compression.Enabled,
+		appcodec.ProtoJSONKey:         &c.JSON.ProtoJSON,
+		appcodec.UseProtoNamesKey:     &c.JSON.UseProtoNames,
+		appcodec.EmitDefaultsKey:      &c.JSON.EmitDefaults,
+		appcodec.EnumsAsNumbersKey:    &c.JSON.EnumsAsNumbers,
+		appcors.AllowCredentialsKey:   &c.CORS.Default.AllowCredentials,
+	}
+
+	listFields := map[string]*[]string{
+		applistener.TrustedProxiesKey: &c.Listener.TrustedProxies,
+		appcors.AllowedOriginsKey:     &c.CORS.Default.AllowedOrigins,
+	}
+
+	intFields := map[string]*int{
+		appredis.DatabaseKey:       &c.Redis.Database,
+		appcache.MaxEntriesKey:     &c.Cache.MaxEntries,
+		appcache.MaxBytesKey:       &c.Cache.MaxBytes,
+		appbody.MaxBytesKey:        &c.Body.MaxBytes,
+		appcompression.MinBytesKey: &c.Compression.MinBytes,
+	}
+
+	floatFields := map[string]*float64{
+		apptracing.SampleRatioKey: &c.Tracing.SampleRatio,
+	}
+
+	var errs []error
+	for key, field := range stringFields {
+		if value, ok := lookupVariable(key); ok {
+			*field = value
+			c.LoadedVariables = append(c.LoadedVariables, key)
+		}
+	}
+	for key, field := range durationFields {
+		if value, ok := lookupVariable(key); ok {
+			duration, err := ParseDuration(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", key, err))
+				continue
+			}
+			*field = duration
+			c.LoadedVariables = append(c.LoadedVariables, key)
+		}
+	}
+	for key, field := range boolFields {
+		if value, ok := lookupVariable(key); ok {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", key, InvalidBoolError))
+				continue
+			}
+			*field = parsed
+			c.LoadedVariables = append(c.LoadedVariables, key)
+		}
+	}
+	for key, field := range listFields {
+		if value, ok := lookupVariable(key); ok {
+			*field = splitList(value)
+			c.LoadedVariables = append(c.LoadedVariables, key)
+		}
+	}
+	for key, field := range intFields {
+		if value, ok := lookupVariable(key); ok {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", key, InvalidNumberError))
+				continue
+			}
+			*field = parsed
+			c.LoadedVariables = append(c.LoadedVariables, key)
+		}
+	}
+	for key, field := range floatFields {
+		if value, ok := lookupVariable(key); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", key, InvalidNumberError))
+				continue
+			}
+			*field = parsed
+			c.LoadedVariables = append(c.LoadedVariables, key)
+		}
+	}
+	sort.Strings(c.LoadedVariables)
+	return errs
+}
+
+// splitList splits a comma-separated list, ignoring the empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// loadFlags overrides the configuration with the flags that are set
+func (c *Config) loadFlags() {
+	if port := flagValue(portFlag); port != "" {
+		c.Listener.Port = port
+	}
+	if swaggerHost := flagValue(swaggerHostFlag); swaggerHost != "" {
+		c.Swagger.Host = swaggerHost
+	}
+}
+
+// lookupVariable returns the value of the environment variable and whether it is set and not empty
+func lookupVariable(key string) (string, bool) {
+	value, ok := os.LookupEnv(key)
+	return value, ok && value != ""
+}
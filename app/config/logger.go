@@ -0,0 +1,41 @@
+package config
+
+import (
+	"log/slog"
+)
+
+// Logger is the logger for the configuration
+type Logger struct {
+	logger *slog.Logger
+}
+
+// NewLogger creates a new configuration logger
+func NewLogger(logger *slog.Logger) (*Logger, error) {
+	// Check if the logger is nil
+	if logger == nil {
+		return nil, NilLoggerError
+	}
+
+	return &Logger{logger: logger}, nil
+}
+
+// ConfigLoaded logs that the configuration has been resolved and which environment variables were used
+func (l *Logger) ConfigLoaded(config *Config) {
+	l.logger.Info("configuration loaded", slog.Any("variables", config.LoadedVariables))
+}
+
+// WeakConfig logs a setting that does not prevent the gateway from starting but weakens it
+func (l *Logger) WeakConfig(warning error) {
+	l.logger.Warn("weak configuration", slog.String("warning", warning.Error()))
+}
+
+// InvalidConfig logs every error found while resolving the configuration
+func (l *Logger) InvalidConfig(err error) {
+	errs := []error{err}
+	if joinedErr, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joinedErr.Unwrap()
+	}
+	for _, err = range errs {
+		l.logger.Error("invalid configuration", slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	approute "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/route"
+)
+
+// CheckRoutes checks that every route key of the configuration is the key of one of the registered routes, so a typo
+// does not silently disable the policy of a route. It returns every unknown key, each one prefixed by the name of its
+// field. The malformed keys are left to Validate
+func (c *Config) CheckRoutes(routes gin.RoutesInfo) error {
+	registered := approute.Keys(routes)
+
+	var errs []error
+	check := func(field, key string) {
+		normalizedKey, err := approute.ParseKey(key)
+		if err == nil && !registered[normalizedKey] {
+			errs = append(errs, fmt.Errorf("%s: %w: %q", field, approute.UnknownRouteError, key))
+		}
+	}
+
+	// Check the rate limits of the routes
+	for key := range c.RateLimit.Routes {
+		check("rate_limit.routes["+key+"]", key)
+	}
+
+	// Check the timeouts of the backend calls
+	for key := range c.Timeouts.Routes {
+		check("timeouts.routes["+key+"]", key)
+	}
+
+	// Check the cached routes
+	for key := range c.Cache.Routes {
+		check("cache.routes["+key+"]", key)
+	}
+
+	// Check the mutating routes that evict the cached ones
+	for key := range c.Cache.Invalidations {
+		check("cache.invalidations["+key+"]", key)
+	}
+
+	// Check the maximum sizes of the request bodies
+	for key := range c.Body.Routes {
+		check("body.routes["+key+"]", key)
+	}
+
+	// Check the routes that honor the idempotency keys
+	for i, key := range c.Idempotency.Routes {
+		check(fmt.Sprintf("idempotency.routes[%d]", i), key)
+	}
+	return errors.Join(errs...)
+}
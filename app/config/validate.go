@@ -0,0 +1,405 @@
+package config
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	appaccesslog "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/accesslog"
+	appbody "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/body"
+	appbreaker "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/breaker"
+	appcache "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/cache"
+	appcompression "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/compression"
+	appcors "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/cors"
+	appgrpc "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc"
+	appidempotency "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/idempotency"
+	appratelimit "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/ratelimit"
+	appretry "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/retry"
+	approute "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/route"
+	apptracing "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/tracing"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Warnings checks the configuration for the settings that do not prevent the gateway from starting but weaken it, and
+// returns every one found, each one prefixed by the name of its field
+func (c *Config) Warnings(isProd bool) []error {
+	var warnings []error
+
+	// The anonymous callers are keyed by their IP, which is the one of the proxy in front of the gateway unless its
+	// forwarded headers are trusted, so every anonymous caller shares the same rate limits, and the log-in attempts are
+	// not limited by IP
+	if isProd && (c.RateLimit.Enabled || c.LogIn.Enabled) && !c.Listener.TrustsClientIP() {
+		warnings = append(warnings, fmt.Errorf("%s: %w", "listener.trusted_proxies", UntrustedClientIPError))
+	}
+	return warnings
+}
+
+// Validate checks the configuration and returns every error found, each one prefixed by the name of its field
+func (c *Config) Validate(isProd bool) error {
+	var errs []error
+	add := func(field string, err error) {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", field, err))
+		}
+	}
+
+	// Validate the listener
+	add("listener.host", validateRequired(c.Listener.Host))
+	add("listener.port", validatePort(c.Listener.Port))
+	add("listener.shutdown_timeout", validatePositiveDuration(c.Listener.ShutdownTimeout))
+	for i, proxy := range c.Listener.TrustedProxies {
+		add(fmt.Sprintf("listener.trusted_proxies[%d]", i), validateIPOrCIDR(proxy))
+	}
+
+	// Validate the backend services URIs
+	add("services.auth", validateURI(c.Services.Auth))
+	add("services.user", validateURI(c.Services.User))
+	add("services.shop", validateURI(c.Services.Shop))
+	add("services.payment", validateURI(c.Services.Payment))
+	add("services.order", validateURI(c.Services.Order))
+
+	// Validate the credentials
+	credentialsErr := c.Credentials.Validate(isProd)
+	if joinedErr, ok := credentialsErr.(interface{ Unwrap() []error }); ok {
+		for _, err := range joinedErr.Unwrap() {
+			add("credentials", err)
+		}
+	} else {
+		add("credentials", credentialsErr)
+	}
+
+	// Validate the JWT public key
+	add("jwt.public_key", validateEd25519PublicKey(c.JWT.PublicKey))
+
+	// Validate the health checker
+	add("health.refresh_interval", validatePositiveDuration(c.Health.RefreshInterval))
+	add("health.check_timeout", validatePositiveDuration(c.Health.CheckTimeout))
+
+	// Validate the metrics listener
+	if c.Metrics.Enabled {
+		add("metrics.port", validatePort(c.Metrics.Port))
+		if c.Metrics.Port == c.Listener.Port {
+			add("metrics.port", SamePortError)
+		}
+	}
+
+	// Validate the tracing
+	add(
+		"tracing.exporter", validateChoice(
+			c.Tracing.Exporter,
+			apptracing.NoneExporter,
+			apptracing.StdoutExporter,
+			apptracing.OTLPExporter,
+		),
+	)
+	if c.Tracing.Exporter == apptracing.OTLPExporter {
+		add("tracing.otlp_endpoint", validateURL(c.Tracing.OTLPEndpoint))
+	}
+	add("tracing.sample_ratio", validateRange(c.Tracing.SampleRatio, 0, 1))
+	add("tracing.service_name", validateRequired(c.Tracing.ServiceName))
+
+	// Validate the logs
+	add("logging.level", validateChoice(c.Logging.Level, appaccesslog.Levels...))
+	if isProd && c.Logging.RequestBodies {
+		add("logging.request_bodies", RequestBodiesInProdError)
+	}
+
+	// Validate the rate limits
+	if c.RateLimit.Enabled {
+		add(
+			"rate_limit.backend", validateChoice(
+				c.RateLimit.Backend,
+				appratelimit.MemoryBackend,
+				appratelimit.RedisBackend,
+			),
+		)
+		if c.RateLimit.Default != nil {
+			add("rate_limit.default", validateRateLimit(*c.RateLimit.Default))
+		}
+		for key, limit := range c.RateLimit.Routes {
+			field := "rate_limit.routes[" + key + "]"
+			if _, err := approute.ParseKey(key); err != nil {
+				add(field, err)
+			}
+			add(field, validateRateLimit(limit))
+		}
+	}
+
+	// Validate the log-in brute-force protection
+	if c.LogIn.Enabled {
+		if strings.TrimSpace(c.LogIn.AdminRole) == "" {
+			add("log_in.admin_role", MissingValueError)
+		}
+		add("log_in.username", c.LogIn.Username.Policy().Validate())
+		add("log_in.ip", c.LogIn.IP.Policy().Validate())
+	}
+
+	// Validate the timeouts of the backend calls
+	add("timeouts.default", validatePositiveDuration(c.Timeouts.Default))
+	for key, timeout := range c.Timeouts.Routes {
+		field := "timeouts.routes[" + key + "]"
+		if _, err := approute.ParseKey(key); err != nil {
+			add(field, err)
+		}
+		add(field, validatePositiveDuration(timeout))
+	}
+
+	// Validate the retries of the backend calls
+	if c.Retry.Enabled {
+		add("retry.default", c.Retry.Default.Policy().Validate())
+		for method, policy := range c.Retry.Methods {
+			field := "retry.methods[" + method + "]"
+			add(field, appretry.ValidateMethod(method))
+			add(field, policy.Policy().Validate())
+		}
+		for i, method := range c.Retry.IdempotentMethods {
+			add(fmt.Sprintf("retry.idempotent_methods[%d]", i), appretry.ValidateMethod(method))
+		}
+	}
+
+	// Validate the circuit breakers of the backend services
+	if c.Breakers.Enabled {
+		add("circuit_breakers.default", c.Breakers.Default.Policy().Validate())
+		for service, policy := range c.Breakers.Services {
+			field := "circuit_breakers.services[" + service + "]"
+			if !isServiceName(service) {
+				add(field, appbreaker.UnknownServiceError)
+			}
+			add(field, policy.Policy().Validate())
+		}
+	}
+
+	// Validate the idempotency keys
+	if c.Idempotency.Enabled {
+		add(
+			"idempotency.backend", validateChoice(
+				c.Idempotency.Backend,
+				appidempotency.MemoryBackend,
+				appidempotency.RedisBackend,
+			),
+		)
+		for i, key := range c.Idempotency.Routes {
+			if _, err := approute.ParseKey(key); err != nil {
+				add(fmt.Sprintf("idempotency.routes[%d]", i), err)
+			}
+		}
+		add("idempotency.ttl", validatePositiveDuration(c.Idempotency.TTL))
+		add("idempotency.lock_ttl", validatePositiveDuration(c.Idempotency.LockTTL))
+	}
+
+	// Validate the response caching
+	cachedRoutes := make(map[string]bool, len(c.Cache.Routes))
+	for key, policy := range c.Cache.Routes {
+		field := "cache.routes[" + key + "]"
+		normalizedKey, err := appcache.ParseRouteKey(key)
+		add(field, err)
+		add(field, policy.Policy().Validate())
+		cachedRoutes[normalizedKey] = true
+	}
+	for key, routes := range c.Cache.Invalidations {
+		field := "cache.invalidations[" + key + "]"
+		if _, err := appcache.ParseMutatingRouteKey(key); err != nil {
+			add(field, err)
+		}
+		for _, route := range routes {
+			normalizedRoute, err := appcache.ParseRouteKey(route)
+			if err != nil {
+				add(field, err)
+				continue
+			}
+			if !cachedRoutes[normalizedRoute] {
+				add(field, fmt.Errorf("%w: %q", appcache.UncachedRouteError, route))
+			}
+		}
+	}
+	if c.Cache.Enabled {
+		if c.Cache.MaxEntries <= 0 {
+			add("cache.max_entries", appcache.NonPositiveLimitError)
+		}
+		if c.Cache.MaxBytes <= 0 {
+			add("cache.max_bytes", appcache.NonPositiveLimitError)
+		}
+	}
+
+	// Validate the request bodies
+	if c.Body.MaxBytes <= 0 {
+		add("body.max_bytes", appbody.NonPositiveLimitError)
+	}
+	for key, maxBytes := range c.Body.Routes {
+		field := "body.routes[" + key + "]"
+		if _, err := approute.ParseKey(key); err != nil {
+			add(field, err)
+		}
+		if maxBytes <= 0 {
+			add(field, appbody.NonPositiveLimitError)
+		}
+	}
+
+	// Validate the compression of the responses
+	if c.Compression.Enabled {
+		if c.Compression.MinBytes <= 0 {
+			add("compression.min_bytes", appcompression.NonPositiveMinBytesError)
+		}
+		for i, contentType := range c.Compression.ContentTypes {
+			_, err := appcompression.ParseContentType(contentType)
+			add(fmt.Sprintf("compression.content_types[%d]", i), err)
+		}
+		add("compression.gzip_level", appcompression.ValidateGzipLevel(c.Compression.GzipLevel))
+		add("compression.brotli_level", appcompression.ValidateBrotliLevel(c.Compression.BrotliLevel))
+	}
+
+	// Validate the CORS policies
+	add("cors.default", c.CORS.Default.Policy().Validate())
+	for prefix, policy := range c.CORS.Groups {
+		field := "cors.groups[" + prefix + "]"
+		if !strings.HasPrefix(prefix, "/") {
+			add(field, appcors.InvalidGroupError)
+		}
+		add(field, policy.Policy().Validate())
+	}
+
+	// Validate the Redis server, if any subsystem uses it
+	if c.UsesRedis() {
+		add("redis.address", validateURI(c.Redis.Address))
+		add("redis.db", validateRange(float64(c.Redis.Database), 0, 15))
+	}
+
+	return errors.Join(errs...)
+}
+
+// isServiceName checks if the value is the name of a backend service
+func isServiceName(value string) bool {
+	for _, name := range appgrpc.ServiceNames {
+		if value == name {
+			return true
+		}
+	}
+	return false
+}
+
+// validateRateLimit checks that the rate limit has a positive number of requests, period and burst
+func validateRateLimit(limit RateLimit) error {
+	return limit.Limit().Validate()
+}
+
+// validateChoice checks that the value is one of the given choices
+func validateChoice(value string, choices ...string) error {
+	for _, choice := range choices {
+		if value == choice {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w %q, expected one of %s", InvalidChoiceError, value, strings.Join(choices, ", "))
+}
+
+// validateRange checks that the value is between the given bounds, both included
+func validateRange(value, min, max float64) error {
+	if value < min || value > max {
+		return fmt.Errorf("%w, expected between %v and %v", OutOfRangeError, min, max)
+	}
+	return nil
+}
+
+// validateURL checks that the value is an absolute http or https URL
+func validateURL(value string) error {
+	if value == "" {
+		return MissingValueError
+	}
+	parsed, err := url.Parse(value)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return InvalidURLError
+	}
+	return nil
+}
+
+// validateRequired checks that the value is not empty
+func validateRequired(value string) error {
+	if value == "" {
+		return MissingValueError
+	}
+	return nil
+}
+
+// validatePositiveDuration checks that the duration is positive
+func validatePositiveDuration(duration Duration) error {
+	if duration <= 0 {
+		return NonPositiveDurationError
+	}
+	return nil
+}
+
+// validatePort checks that the value is a valid TCP port
+func validatePort(value string) error {
+	if value == "" {
+		return MissingValueError
+	}
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		return InvalidPortError
+	}
+	return nil
+}
+
+// validateIPOrCIDR checks that the value is an IP address or a CIDR
+func validateIPOrCIDR(value string) error {
+	if value == "" {
+		return MissingValueError
+	}
+	if net.ParseIP(value) == nil {
+		if _, _, err := net.ParseCIDR(value); err != nil {
+			return InvalidIPError
+		}
+	}
+	return nil
+}
+
+// validateURI checks that the value is a host with an optional port, as expected by the gRPC client
+func validateURI(value string) error {
+	if value == "" {
+		return MissingValueError
+	}
+	if strings.Contains(value, "://") || strings.ContainsAny(value, "/ ") {
+		return InvalidURIError
+	}
+
+	// Check the port, if any
+	host := value
+	if strings.Contains(value, ":") {
+		var port string
+		var err error
+		if host, port, err = net.SplitHostPort(value); err != nil {
+			return InvalidURIError
+		}
+		if validatePort(port) != nil {
+			return InvalidURIError
+		}
+	}
+	if host == "" {
+		return InvalidURIError
+	}
+	return nil
+}
+
+// validateEd25519PublicKey checks that the value is a PEM encoded ED25519 public key
+func validateEd25519PublicKey(value string) error {
+	if value == "" {
+		return MissingValueError
+	}
+
+	block, _ := pem.Decode([]byte(value))
+	if block == nil {
+		return InvalidPEMError
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return InvalidPublicKeyError
+	}
+	if _, ok := publicKey.(ed25519.PublicKey); !ok {
+		return UnexpectedPublicKeyError
+	}
+	return nil
+}
@@ -6,4 +6,10 @@ const (
 
 	// ServerKeyPath is the path to the server key
 	ServerKeyPath = "certificates/server.pem"
+
+	// SwaggerHostKey is the key of the host shown in the Swagger docs
+	SwaggerHostKey = "SWAGGER_HOST"
+
+	// ProdSwaggerHost is the default host shown in the Swagger docs in production
+	ProdSwaggerHost = "uru-databases-2-api-gateway-246064477369.us-central1.run.app"
 )
@@ -0,0 +1,66 @@
+package cors
+
+import (
+	appidempotency "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/idempotency"
+	"time"
+)
+
+const (
+	// AllowedOriginsKey is the key of the comma-separated origins allowed by the default policy
+	AllowedOriginsKey = "CORS_ALLOWED_ORIGINS"
+
+	// AllowCredentialsKey is the key of the flag that allows the credentials in the default policy
+	AllowCredentialsKey = "CORS_ALLOW_CREDENTIALS"
+
+	// DefaultMaxAge is the default time the browsers can cache the result of a preflight request
+	DefaultMaxAge = 12 * time.Hour
+
+	// AnyOrigin is the origin that allows every origin, which cannot be used with credentials
+	AnyOrigin = "*"
+
+	// wildcard is the wildcard of the subdomains and the ports in the allowed origins
+	wildcard = "*"
+)
+
+var (
+	// DefaultAllowedMethods are the default methods allowed in the cross-origin requests
+	DefaultAllowedMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+
+	// DefaultAllowedHeaders are the default non-simple headers allowed in the cross-origin requests
+	DefaultAllowedHeaders = []string{
+		"Origin",
+		"Content-Type",
+		"Content-Length",
+		"Accept",
+		"Authorization",
+		"X-Request-ID",
+		"Idempotency-Key",
+		"If-None-Match",
+	}
+
+	// DefaultExposedHeaders are the default response headers exposed to the cross-origin requests
+	DefaultExposedHeaders = []string{
+		"X-Request-ID",
+		"Retry-After",
+		"RateLimit-Limit",
+		"RateLimit-Remaining",
+		"RateLimit-Reset",
+		"RateLimit-Policy",
+		"ETag",
+		"X-Cache",
+		"Link",
+		appidempotency.ReplayedHeader,
+	}
+
+	// DevAllowedOrigins are the origins allowed by default in development mode
+	DevAllowedOrigins = []string{"http://localhost:*", "http://127.0.0.1:*"}
+
+	// RestrictedGroups are the route groups with a stricter default policy, which do not allow any cross-origin
+	// request outside development mode
+	RestrictedGroups = []string{
+		"/api/v1/auth/roles",
+		"/api/v1/auth/permissions",
+		"/api/v1/auth/role-permissions",
+		"/api/v1/auth/user-roles",
+	}
+)
@@ -0,0 +1,12 @@
+package cors
+
+import (
+	"errors"
+)
+
+var (
+	InvalidOriginError        = errors.New("invalid origin, expected a scheme and a host with an optional port")
+	AnyOriginCredentialsError = errors.New("every origin cannot be allowed with credentials")
+	InvalidGroupError         = errors.New("invalid route group, expected a path starting with '/'")
+	NegativeMaxAgeError       = errors.New("negative preflight max age")
+)
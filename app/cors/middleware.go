@@ -0,0 +1,71 @@
+package cors
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"sort"
+	"strings"
+)
+
+type (
+	// Middleware applies the CORS policy of the route group of every request, or the default policy if it does not
+	// belong to any
+	Middleware struct {
+		defaultHandler gin.HandlerFunc
+		groups         []group
+	}
+
+	// group is a route group with its own CORS policy
+	group struct {
+		prefix  string
+		handler gin.HandlerFunc
+	}
+)
+
+// NewMiddleware creates a new CORS middleware with the default policy and the policies of the route groups, indexed
+// by their path prefix, like /api/v1/auth/roles
+func NewMiddleware(defaultPolicy Policy, groupPolicies map[string]Policy) (*Middleware, error) {
+	defaultHandler, err := defaultPolicy.handler()
+	if err != nil {
+		return nil, fmt.Errorf("default: %w", err)
+	}
+
+	groups := make([]group, 0, len(groupPolicies))
+	for prefix, policy := range groupPolicies {
+		if !strings.HasPrefix(prefix, "/") {
+			return nil, fmt.Errorf("%s: %w", prefix, InvalidGroupError)
+		}
+		handler, err := policy.inherit(defaultPolicy).handler()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", prefix, err)
+		}
+		groups = append(groups, group{prefix: strings.TrimSuffix(prefix, "/"), handler: handler})
+	}
+
+	// Sort the groups from the most to the least specific, so the longest matching prefix wins
+	sort.Slice(
+		groups, func(i, j int) bool {
+			return len(groups[i].prefix) > len(groups[j].prefix)
+		},
+	)
+
+	return &Middleware{
+		defaultHandler: defaultHandler,
+		groups:         groups,
+	}, nil
+}
+
+// Handler applies the CORS policy of the request. It matches the raw path, since the preflight requests do not
+// match any route
+func (m *Middleware) Handler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		path := ctx.Request.URL.Path
+		for _, g := range m.groups {
+			if path == g.prefix || strings.HasPrefix(path, g.prefix+"/") {
+				g.handler(ctx)
+				return
+			}
+		}
+		m.defaultHandler(ctx)
+	}
+}
@@ -0,0 +1,58 @@
+package cors
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// origin is an allowed origin, whose host may start with a wildcard subdomain, like https://*.example.com, and whose
+// port may be a wildcard, like http://localhost:*
+type origin struct {
+	scheme string
+	host   string
+	port   string
+}
+
+// parseOrigin parses an allowed origin
+func parseOrigin(value string) (origin, error) {
+	scheme, hostPort, ok := strings.Cut(value, "://")
+	if !ok || scheme == "" || hostPort == "" || strings.ContainsAny(hostPort, "/?#") {
+		return origin{}, fmt.Errorf("%w: %q", InvalidOriginError, value)
+	}
+
+	host, port := hostPort, ""
+	if index := strings.LastIndex(hostPort, ":"); index != -1 && !strings.HasSuffix(hostPort, "]") {
+		host, port = hostPort[:index], hostPort[index+1:]
+	}
+	if host == "" || (strings.Contains(host, wildcard) && !strings.HasPrefix(host, wildcard+".")) ||
+		strings.Count(host, wildcard) > 1 {
+		return origin{}, fmt.Errorf("%w: %q", InvalidOriginError, value)
+	}
+
+	return origin{
+		scheme: strings.ToLower(scheme),
+		host:   strings.ToLower(host),
+		port:   port,
+	}, nil
+}
+
+// matches checks if the origin sent by the browser is allowed by this origin
+func (o origin) matches(value string) bool {
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Path != "" || !strings.EqualFold(parsed.Scheme, o.scheme) {
+		return false
+	}
+
+	// Check the port, which must be the same unless it is a wildcard
+	if o.port != wildcard && parsed.Port() != o.port {
+		return false
+	}
+
+	// Check the host, which must be a subdomain of the allowed domain if it is a wildcard
+	host := strings.ToLower(parsed.Hostname())
+	if suffix, ok := strings.CutPrefix(o.host, wildcard); ok {
+		return len(host) > len(suffix) && strings.HasSuffix(host, suffix)
+	}
+	return host == o.host
+}
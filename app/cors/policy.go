@@ -0,0 +1,95 @@
+package cors
+
+import (
+	gincors "github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+// Policy is the CORS policy of a route group. The empty methods, headers and max age are inherited from the default
+// policy, while the origins and the credentials are never inherited
+type Policy struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// Validate checks that the policy can be used
+func (p Policy) Validate() error {
+	for _, allowedOrigin := range p.AllowedOrigins {
+		if allowedOrigin == AnyOrigin {
+			if p.AllowCredentials {
+				return AnyOriginCredentialsError
+			}
+			continue
+		}
+		if _, err := parseOrigin(allowedOrigin); err != nil {
+			return err
+		}
+	}
+	if p.MaxAge < 0 {
+		return NegativeMaxAgeError
+	}
+	return nil
+}
+
+// inherit returns a copy of the policy with its empty methods, headers and max age taken from the given policy
+func (p Policy) inherit(parent Policy) Policy {
+	if len(p.AllowedMethods) == 0 {
+		p.AllowedMethods = parent.AllowedMethods
+	}
+	if len(p.AllowedHeaders) == 0 {
+		p.AllowedHeaders = parent.AllowedHeaders
+	}
+	if len(p.ExposedHeaders) == 0 {
+		p.ExposedHeaders = parent.ExposedHeaders
+	}
+	if p.MaxAge == 0 {
+		p.MaxAge = parent.MaxAge
+	}
+	return p
+}
+
+// handler creates the CORS handler of the policy, which rejects the requests from the origins that are not allowed
+// with 403 Forbidden
+func (p Policy) handler() (gin.HandlerFunc, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Parse the allowed origins
+	allowAll := false
+	origins := make([]origin, 0, len(p.AllowedOrigins))
+	for _, allowedOrigin := range p.AllowedOrigins {
+		if allowedOrigin == AnyOrigin {
+			allowAll = true
+			continue
+		}
+		parsed, _ := parseOrigin(allowedOrigin)
+		origins = append(origins, parsed)
+	}
+
+	config := gincors.Config{
+		AllowMethods:     p.AllowedMethods,
+		AllowHeaders:     p.AllowedHeaders,
+		ExposeHeaders:    p.ExposedHeaders,
+		AllowCredentials: p.AllowCredentials,
+		MaxAge:           p.MaxAge,
+	}
+	if allowAll {
+		config.AllowAllOrigins = true
+	} else {
+		config.AllowOriginFunc = func(value string) bool {
+			for _, allowedOrigin := range origins {
+				if allowedOrigin.matches(value) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	return gincors.New(config), nil
+}
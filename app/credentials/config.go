@@ -0,0 +1,52 @@
+package credentials
+
+import (
+	"errors"
+)
+
+// Config is the configuration of the credentials used to connect to the backend services
+type Config struct {
+	Provider      string `yaml:"provider" json:"provider"`
+	StaticToken   string `yaml:"static_token" json:"static_token"`
+	Transport     string `yaml:"transport" json:"transport"`
+	TLSCertPath   string `yaml:"tls_cert_path" json:"tls_cert_path"`
+	TLSServerName string `yaml:"tls_server_name" json:"tls_server_name"`
+}
+
+// Validate checks the credentials configuration and returns every error found. Production must never run without
+// the service account credentials or over an untrusted transport, while the other modes can run against local
+// stand-in services
+func (c *Config) Validate(isProd bool) error {
+	var errs []error
+
+	// Check if the provider is valid
+	switch c.Provider {
+	case GoogleProvider, NoneProvider:
+	case StaticProvider:
+		if c.StaticToken == "" {
+			errs = append(errs, MissingStaticTokenError)
+		}
+	default:
+		errs = append(errs, InvalidProviderError)
+	}
+
+	// Check if the transport is valid
+	switch c.Transport {
+	case SystemTransport, InsecureTransport:
+	case SelfSignedTransport:
+		if c.TLSCertPath == "" {
+			errs = append(errs, MissingTLSCertPathError)
+		}
+	default:
+		errs = append(errs, InvalidTransportError)
+	}
+
+	// Check the production restrictions
+	if isProd && c.Provider != GoogleProvider {
+		errs = append(errs, ProviderNotAllowedInProdError)
+	}
+	if isProd && c.Transport != SystemTransport {
+		errs = append(errs, TransportNotAllowedInProdError)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,40 @@
+package credentials
+
+const (
+	// ProviderKey is the key of the per-RPC credentials provider used to authenticate the calls to the backend services
+	ProviderKey = "GRPC_CREDENTIALS_PROVIDER"
+
+	// StaticTokenKey is the key of the bearer token sent to the backend services by the static provider
+	StaticTokenKey = "GRPC_STATIC_TOKEN"
+
+	// TransportKey is the key of the transport security used to connect to the backend services
+	TransportKey = "GRPC_TRANSPORT"
+
+	// TLSCertPathKey is the key of the path to the certificate trusted by the self-signed transport
+	TLSCertPathKey = "GRPC_TLS_CERT_PATH"
+
+	// TLSServerNameKey is the key of the server name override used by the self-signed transport
+	TLSServerNameKey = "GRPC_TLS_SERVER_NAME"
+)
+
+const (
+	// GoogleProvider authenticates every call with a Google ID token of the service account
+	GoogleProvider = "google"
+
+	// StaticProvider authenticates every call with a static bearer token
+	StaticProvider = "static"
+
+	// NoneProvider does not attach any per-RPC credentials to the calls
+	NoneProvider = "none"
+)
+
+const (
+	// SystemTransport uses TLS with the system certificates pool
+	SystemTransport = "system"
+
+	// SelfSignedTransport uses TLS trusting the certificate at the configured path
+	SelfSignedTransport = "self-signed"
+
+	// InsecureTransport disables the transport security
+	InsecureTransport = "insecure"
+)
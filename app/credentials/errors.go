@@ -0,0 +1,14 @@
+package credentials
+
+import (
+	"errors"
+)
+
+var (
+	InvalidProviderError           = errors.New("invalid gRPC credentials provider")
+	InvalidTransportError          = errors.New("invalid gRPC transport")
+	MissingTLSCertPathError        = errors.New("missing certificate path for the self-signed gRPC transport")
+	MissingStaticTokenError        = errors.New("missing static token for the static gRPC credentials provider")
+	ProviderNotAllowedInProdError  = errors.New("only the google gRPC credentials provider is allowed in production")
+	TransportNotAllowedInProdError = errors.New("only the system gRPC transport is allowed in production")
+)
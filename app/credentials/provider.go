@@ -0,0 +1,99 @@
+package credentials
+
+import (
+	"context"
+	commongcloud "github.com/pixel-plaza-dev/uru-databases-2-go-service-common/cloud/gcloud"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc/credentials/oauth"
+)
+
+type (
+	// Provider provides the token source used to authenticate the calls to a backend service
+	Provider interface {
+		// TokenSource returns the token source for the service at the given URI, or nil if the calls to the
+		// service must not carry any per-RPC credentials
+		TokenSource(ctx context.Context, uri string) (*oauth.TokenSource, error)
+	}
+
+	// googleProvider authenticates the calls with Google ID tokens of the service account
+	googleProvider struct {
+		loadTokenSource func(ctx context.Context, audience string) (*oauth.TokenSource, error)
+	}
+
+	// staticProvider authenticates the calls with a static bearer token
+	staticProvider struct {
+		tokenSource *oauth.TokenSource
+	}
+
+	// noneProvider does not authenticate the calls
+	noneProvider struct{}
+)
+
+// NewProvider creates the provider selected by the configuration
+func NewProvider(ctx context.Context, config *Config) (Provider, error) {
+	switch config.Provider {
+	case GoogleProvider:
+		return NewGoogleProvider(ctx)
+	case StaticProvider:
+		return NewStaticProvider(config.StaticToken)
+	case NoneProvider:
+		return NewNoneProvider(), nil
+	default:
+		return nil, InvalidProviderError
+	}
+}
+
+// NewGoogleProvider creates a new provider that loads the Google Cloud service account credentials
+func NewGoogleProvider(ctx context.Context) (Provider, error) {
+	// Load Google Cloud service account credentials
+	googleCredentials, err := commongcloud.LoadGoogleCredentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &googleProvider{
+		loadTokenSource: func(ctx context.Context, audience string) (*oauth.TokenSource, error) {
+			return commongcloud.LoadServiceAccountCredentials(ctx, audience, googleCredentials)
+		},
+	}, nil
+}
+
+// TokenSource returns the service account token source whose ID tokens target the given URI
+func (g *googleProvider) TokenSource(ctx context.Context, uri string) (*oauth.TokenSource, error) {
+	return g.loadTokenSource(ctx, "https://"+uri)
+}
+
+// NewStaticProvider creates a new provider that sends the given bearer token to every service
+func NewStaticProvider(token string) (Provider, error) {
+	// Check if the token is empty
+	if token == "" {
+		return nil, MissingStaticTokenError
+	}
+
+	return &staticProvider{
+		tokenSource: &oauth.TokenSource{
+			TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token, TokenType: "Bearer"}),
+		},
+	}, nil
+}
+
+// TokenSource returns the static token source
+func (s *staticProvider) TokenSource(context.Context, string) (*oauth.TokenSource, error) {
+	return s.tokenSource, nil
+}
+
+// NewNoneProvider creates a new provider that does not authenticate the calls
+func NewNoneProvider() Provider {
+	return &noneProvider{}
+}
+
+// TokenSource returns nil, since the calls are not authenticated
+func (n *noneProvider) TokenSource(context.Context, string) (*oauth.TokenSource, error) {
+	return nil, nil
+}
+
+// NewAnonymousTokenSource creates a token source with an empty token, for the components that always require one
+// even when the calls are not authenticated
+func NewAnonymousTokenSource() *oauth.TokenSource {
+	return &oauth.TokenSource{TokenSource: oauth2.StaticTokenSource(&oauth2.Token{})}
+}
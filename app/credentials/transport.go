@@ -0,0 +1,23 @@
+package credentials
+
+import (
+	commontls "github.com/pixel-plaza-dev/uru-databases-2-go-service-common/http/tls"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// LoadTransportCredentials loads the transport credentials selected by the configuration
+func LoadTransportCredentials(config *Config) (credentials.TransportCredentials, error) {
+	switch config.Transport {
+	case SystemTransport:
+		// Load system certificates pool
+		return commontls.LoadSystemCredentials()
+	case SelfSignedTransport:
+		// Trust the self-signed certificate of the local services
+		return credentials.NewClientTLSFromFile(config.TLSCertPath, config.TLSServerName)
+	case InsecureTransport:
+		return insecure.NewCredentials(), nil
+	default:
+		return nil, InvalidTransportError
+	}
+}
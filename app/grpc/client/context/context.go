@@ -0,0 +1,86 @@
+package context
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	appaccesslog "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/accesslog"
+	appbody "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/body"
+	appcodec "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/codec"
+	appidempotency "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/idempotency"
+	apprequestid "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/requestid"
+	appretry "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/retry"
+	apptimeout "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/timeout"
+	apptracing "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/tracing"
+	appvalidation "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/validation"
+	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+// PrepareCtx prepares the gRPC context of a request like the common one does, and carries over the request-scoped
+// values set by the gateway middlewares, like the deadline, the request ID and the trace of the request. The binary
+// protobuf request bodies, and the JSON ones if the codec uses protojson, are decoded by the gateway, while the rest of
+// the JSON ones are left to the common one. The request body is checked for unknown fields in strict mode, and
+// validated with the rules of its message, before the backend service is called
+func PrepareCtx(ctx *gin.Context, request proto.Message) (context.Context, error) {
+	// Decode the binary protobuf request bodies, and the JSON ones in the protojson mapping the responses are encoded
+	// with, so the common context preparation does not bind them with encoding/json
+	bodyRequest := request
+	if request != nil {
+		codec := appcodec.FromGinContext(ctx)
+		switch {
+		case appcodec.IsProtobuf(ctx.GetHeader("Content-Type")):
+			if err := appcodec.DecodeProtobuf(ctx, request); err != nil {
+				return nil, err
+			}
+			bodyRequest = nil
+		case codec.IsProtoJSON():
+			if err := codec.DecodeProtoJSON(ctx, request); err != nil {
+				return nil, err
+			}
+			bodyRequest = nil
+		}
+	}
+
+	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, bodyRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reject the unknown fields of the request body, in strict mode
+	if err = appbody.CheckUnknownFields(ctx, request); err != nil {
+		return nil, err
+	}
+
+	// Validate the request body, whose path parameters are set later by the handler
+	if validator := appvalidation.FromGinContext(ctx); validator != nil && request != nil {
+		if err = validator.Validate(request); err != nil {
+			return nil, err
+		}
+	}
+
+	// Bound the backend call by the deadline of the route, cancelling it if the client disconnects
+	grpcCtx = apptimeout.Bind(grpcCtx, ctx.Request.Context())
+
+	// Carry over the request ID, which is added to the outgoing metadata
+	if id := apprequestid.FromGinContext(ctx); id != "" {
+		grpcCtx = apprequestid.NewContext(grpcCtx, id)
+	}
+
+	// Carry over the idempotency key claimed by the idempotency middleware, which allows retrying the non-idempotent
+	// calls
+	if key := appidempotency.KeyFromGinContext(ctx); key != "" {
+		grpcCtx = appretry.ContextWithIdempotencyKey(grpcCtx, key)
+	}
+
+	// Record the outcome of the backend calls in the access log of the request
+	if recorder := appaccesslog.RecorderFromGinContext(ctx); recorder != nil {
+		grpcCtx = appaccesslog.ContextWithRecorder(grpcCtx, recorder)
+	}
+
+	// Continue the trace of the request
+	if span := apptracing.SpanFromGinContext(ctx); span != nil {
+		grpcCtx = trace.ContextWithSpan(grpcCtx, span)
+	}
+	return grpcCtx, nil
+}
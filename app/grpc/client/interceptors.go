@@ -0,0 +1,29 @@
+package client
+
+import (
+	apprequestid "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/requestid"
+	appretry "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/retry"
+	"google.golang.org/grpc"
+	"slices"
+)
+
+// UnaryClientInterceptors chains the interceptors of the calls to a backend service around its authentication
+// interceptor, which may be nil. The authentication interceptor replaces the outgoing metadata of every attempt, so the
+// metadata added by the gateway, like the request ID and the idempotency key, is appended right after it, before the
+// interceptors that must see the metadata the backend service receives
+func UnaryClientInterceptors(
+	beforeAuth []grpc.UnaryClientInterceptor,
+	auth grpc.UnaryClientInterceptor,
+	afterAuth []grpc.UnaryClientInterceptor,
+) []grpc.UnaryClientInterceptor {
+	interceptors := slices.Clone(beforeAuth)
+	if auth != nil {
+		interceptors = append(interceptors, auth)
+	}
+	interceptors = append(
+		interceptors,
+		apprequestid.UnaryClientInterceptor(),
+		appretry.IdempotencyKeyUnaryClientInterceptor(),
+	)
+	return append(interceptors, afterAuth...)
+}
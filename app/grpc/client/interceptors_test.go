@@ -0,0 +1,214 @@
+package client
+
+import (
+	"context"
+	apprequestid "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/requestid"
+	appretry "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/retry"
+	clientauthinterceptor "github.com/pixel-plaza-dev/uru-databases-2-go-service-common/http/grpc/client/interceptor/auth"
+	pbtypesgrpc "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/grpc"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/credentials/oauth"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"io"
+	"log/slog"
+	"net"
+	"slices"
+	"testing"
+	"time"
+)
+
+type (
+	// metadataCase is a call to a backend service, failing the given number of times before succeeding, and the
+	// metadata the backend service must receive on every attempt
+	metadataCase struct {
+		name           string
+		authenticated  bool
+		interceptions  map[pbtypesgrpc.Method]pbtypesgrpc.Interception
+		outgoing       metadata.MD
+		idempotencyKey string
+		failures       int
+		want           metadata.MD
+	}
+)
+
+const (
+	// gcloudToken is the ID token of the gateway sent to the backend services
+	gcloudToken = "gcloud-token"
+
+	// jwtToken is the access token of the caller
+	jwtToken = "jwt-token"
+
+	// requestID is the ID of the request the call is made for
+	requestID = "request-id"
+
+	// idempotencyKey is the idempotency key of the request the call is made for
+	idempotencyKey = "idempotency-key"
+)
+
+// dialBackend starts a backend service that records the metadata of the calls it receives, failing the given number
+// of them as unavailable, and connects to it with the given interceptors
+func dialBackend(t *testing.T, failures int, interceptors []grpc.UnaryClientInterceptor) (
+	healthpb.HealthClient,
+	*[]metadata.MD,
+) {
+	t.Helper()
+
+	received := new([]metadata.MD)
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(
+			func(
+				ctx context.Context,
+				req interface{},
+				info *grpc.UnaryServerInfo,
+				handler grpc.UnaryHandler,
+			) (interface{}, error) {
+				md, _ := metadata.FromIncomingContext(ctx)
+				*received = append(*received, md)
+				if len(*received) <= failures {
+					return nil, status.Error(codes.Unavailable, "cold start")
+				}
+				return handler(ctx, req)
+			},
+		),
+	)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(
+			func(ctx context.Context, _ string) (net.Conn, error) {
+				return listener.DialContext(ctx)
+			},
+		),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(interceptors...),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return healthpb.NewHealthClient(conn), received
+}
+
+// TestUnaryClientInterceptors checks the backend services receive the metadata added by the gateway along with the
+// one of the authentication interceptor, which replaces the outgoing metadata of the call, on every retried attempt
+func TestUnaryClientInterceptors(t *testing.T) {
+	logger, err := appretry.NewLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("NewLogger() error = %v", err)
+	}
+	retrier, err := appretry.NewRetrier(
+		appretry.Policy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, Multiplier: 1},
+		nil,
+		nil,
+		logger,
+	)
+	if err != nil {
+		t.Fatalf("NewRetrier() error = %v", err)
+	}
+
+	cases := []metadataCase{
+		{
+			name:     "unauthenticated connection",
+			outgoing: metadata.Pairs("authorization", "Bearer "+jwtToken),
+			want: metadata.Pairs(
+				"authorization", "Bearer "+jwtToken,
+				apprequestid.MetadataKey, requestID,
+			),
+		},
+		{
+			name:          "method without interception",
+			authenticated: true,
+			interceptions: map[pbtypesgrpc.Method]pbtypesgrpc.Interception{},
+			want: metadata.Pairs(
+				"x-serverless-authorization", "Bearer "+gcloudToken,
+				apprequestid.MetadataKey, requestID,
+			),
+		},
+		{
+			name:          "method with access token interception",
+			authenticated: true,
+			interceptions: map[pbtypesgrpc.Method]pbtypesgrpc.Interception{
+				pbtypesgrpc.NewMethod("Check"): pbtypesgrpc.AccessToken,
+			},
+			outgoing: metadata.Pairs("authorization", "Bearer "+jwtToken),
+			want: metadata.Pairs(
+				"x-serverless-authorization", "Bearer "+gcloudToken,
+				"authorization", "Bearer "+jwtToken,
+				apprequestid.MetadataKey, requestID,
+			),
+		},
+		{
+			name:           "retried method with idempotency key",
+			authenticated:  true,
+			interceptions:  map[pbtypesgrpc.Method]pbtypesgrpc.Interception{},
+			idempotencyKey: idempotencyKey,
+			failures:       2,
+			want: metadata.Pairs(
+				"x-serverless-authorization", "Bearer "+gcloudToken,
+				apprequestid.MetadataKey, requestID,
+				appretry.IdempotencyKeyMetadataKey, idempotencyKey,
+			),
+		},
+	}
+	for _, c := range cases {
+		t.Run(
+			c.name, func(t *testing.T) {
+				var auth grpc.UnaryClientInterceptor
+				if c.authenticated {
+					interceptor, err := clientauthinterceptor.NewInterceptor(
+						&oauth.TokenSource{TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: gcloudToken})},
+						&c.interceptions,
+					)
+					if err != nil {
+						t.Fatalf("NewInterceptor() error = %v", err)
+					}
+					auth = interceptor.Authenticate()
+				}
+				client, received := dialBackend(
+					t,
+					c.failures,
+					UnaryClientInterceptors(
+						[]grpc.UnaryClientInterceptor{retrier.UnaryClientInterceptor("health")},
+						auth,
+						nil,
+					),
+				)
+
+				ctx := apprequestid.NewContext(context.Background(), requestID)
+				if c.outgoing != nil {
+					ctx = metadata.NewOutgoingContext(ctx, c.outgoing)
+				}
+				if c.idempotencyKey != "" {
+					ctx = appretry.ContextWithIdempotencyKey(ctx, c.idempotencyKey)
+				}
+				if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+					t.Fatalf("Check() error = %v", err)
+				}
+
+				if len(*received) != c.failures+1 {
+					t.Fatalf("received %d attempts, want %d", len(*received), c.failures+1)
+				}
+				for attempt, md := range *received {
+					for key, values := range c.want {
+						if got := md.Get(key); !slices.Equal(got, values) {
+							t.Errorf("attempt %d received %s = %v, want %v", attempt+1, key, got, values)
+						}
+					}
+				}
+			},
+		)
+	}
+}
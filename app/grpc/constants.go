@@ -16,3 +16,12 @@ const (
 	// PaymentServiceUriKey is the key of the payment service URI
 	PaymentServiceUriKey = "PAYMENT_SERVICE_HOST"
 )
+
+// ServiceNames are the names of the backend services, indexed by the key of their URI
+var ServiceNames = map[string]string{
+	UserServiceUriKey:    "user",
+	AuthServiceUriKey:    "auth",
+	ShopServiceUriKey:    "shop",
+	OrderServiceUriKey:   "order",
+	PaymentServiceUriKey: "payment",
+}
@@ -0,0 +1,225 @@
+package health
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"sync"
+	"time"
+)
+
+type (
+	// Service is a backend gRPC service whose connection is probed by the health checker
+	Service struct {
+		Name string
+		Conn *grpc.ClientConn
+	}
+
+	// ServiceStatus is the last known status of a backend service
+	ServiceStatus struct {
+		Status    string    `json:"status"`
+		State     string    `json:"state"`
+		Error     string    `json:"error,omitempty"`
+		CheckedAt time.Time `json:"checked_at"`
+	}
+
+	// Report is the readiness report of the API gateway
+	Report struct {
+		Status    string                   `json:"status"`
+		Services  map[string]ServiceStatus `json:"services"`
+		CheckedAt time.Time                `json:"checked_at"`
+	}
+
+	// Checker periodically probes every backend service in the background and caches the results, so the probes
+	// served to the load balancer never wait for a backend
+	Checker struct {
+		services        []Service
+		refreshInterval time.Duration
+		checkTimeout    time.Duration
+		logger          *Logger
+		mutex           sync.RWMutex
+		report          Report
+		cancel          context.CancelFunc
+		done            chan struct{}
+	}
+)
+
+// NewChecker creates a new health checker
+func NewChecker(
+	services []Service,
+	refreshInterval time.Duration,
+	checkTimeout time.Duration,
+	logger *Logger,
+) (*Checker, error) {
+	// Check if the logger or any connection are nil
+	if logger == nil {
+		return nil, NilLoggerError
+	}
+	for _, service := range services {
+		if service.Conn == nil {
+			return nil, NilConnectionError
+		}
+	}
+
+	// Check if the interval and the timeout are valid
+	if refreshInterval <= 0 {
+		return nil, InvalidIntervalError
+	}
+	if checkTimeout <= 0 {
+		return nil, InvalidTimeoutError
+	}
+
+	// Every service is unknown until the first check finishes
+	statuses := make(map[string]ServiceStatus, len(services))
+	for _, service := range services {
+		statuses[service.Name] = ServiceStatus{Status: StatusUnknown}
+	}
+
+	return &Checker{
+		services:        services,
+		refreshInterval: refreshInterval,
+		checkTimeout:    checkTimeout,
+		logger:          logger,
+		report:          Report{Status: StatusUnknown, Services: statuses},
+	}, nil
+}
+
+// Start runs a first check and keeps refreshing the cached report in the background until the checker is closed
+func (c *Checker) Start() error {
+	c.mutex.Lock()
+	if c.done != nil {
+		c.mutex.Unlock()
+		return CheckerAlreadyStartedError
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.done = make(chan struct{})
+	c.mutex.Unlock()
+
+	go func() {
+		defer close(c.done)
+
+		ticker := time.NewTicker(c.refreshInterval)
+		defer ticker.Stop()
+
+		for {
+			c.refresh(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// Close stops the background refresh and waits for the running check to finish
+func (c *Checker) Close() error {
+	c.mutex.RLock()
+	cancel, done := c.cancel, c.done
+	c.mutex.RUnlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	<-done
+	c.logger.CheckerStopped()
+	return nil
+}
+
+// Report returns the cached readiness report
+func (c *Checker) Report() Report {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Copy the services map so the caller cannot race with the next refresh
+	services := make(map[string]ServiceStatus, len(c.report.Services))
+	for name, status := range c.report.Services {
+		services[name] = status
+	}
+	return Report{Status: c.report.Status, Services: services, CheckedAt: c.report.CheckedAt}
+}
+
+// refresh checks every service concurrently and replaces the cached report
+func (c *Checker) refresh(ctx context.Context) {
+	statuses := make([]ServiceStatus, len(c.services))
+
+	var wg sync.WaitGroup
+	for i, service := range c.services {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statuses[i] = c.check(ctx, service.Conn)
+		}()
+	}
+	wg.Wait()
+
+	// Skip the update if the checker was closed while the services were being checked
+	if ctx.Err() != nil {
+		return
+	}
+
+	// Build the new report
+	report := Report{
+		Status:    StatusReady,
+		Services:  make(map[string]ServiceStatus, len(c.services)),
+		CheckedAt: time.Now(),
+	}
+	for i, service := range c.services {
+		report.Services[service.Name] = statuses[i]
+		if statuses[i].Status != StatusReady {
+			report.Status = StatusNotReady
+		}
+	}
+
+	// Store the report and log the services whose status changed
+	c.mutex.Lock()
+	previous := c.report
+	c.report = report
+	c.mutex.Unlock()
+
+	for _, service := range c.services {
+		if previous.Services[service.Name].Status != report.Services[service.Name].Status {
+			c.logger.ServiceStatusChanged(service.Name, report.Services[service.Name])
+		}
+	}
+}
+
+// check probes a single connection. The service is ready if the connection is READY or if the standard
+// grpc.health.v1 check reports it as serving
+func (c *Checker) check(ctx context.Context, conn *grpc.ClientConn) ServiceStatus {
+	state := conn.GetState()
+	status := ServiceStatus{State: state.String(), CheckedAt: time.Now()}
+
+	if state == connectivity.Ready {
+		status.Status = StatusReady
+		return status
+	}
+
+	// Idle connections do not reconnect on their own
+	if state == connectivity.Idle {
+		conn.Connect()
+	}
+
+	// Fall back to the standard health check
+	checkCtx, cancel := context.WithTimeout(ctx, c.checkTimeout)
+	defer cancel()
+
+	response, err := healthpb.NewHealthClient(conn).Check(checkCtx, &healthpb.HealthCheckRequest{})
+	status.State = conn.GetState().String()
+	switch {
+	case err != nil:
+		status.Status = StatusNotReady
+		status.Error = err.Error()
+	case response.GetStatus() != healthpb.HealthCheckResponse_SERVING:
+		status.Status = StatusNotReady
+		status.Error = ServiceNotServingError.Error()
+	default:
+		status.Status = StatusReady
+	}
+	return status
+}
@@ -0,0 +1,45 @@
+package health
+
+import (
+	"time"
+)
+
+const (
+	// LivenessPath is the path of the liveness probe endpoint
+	LivenessPath = "/healthz"
+
+	// ReadinessPath is the path of the readiness probe endpoint
+	ReadinessPath = "/readyz"
+
+	// LivenessRouteKey is the route key of the liveness probe endpoint
+	LivenessRouteKey = "GET " + LivenessPath
+
+	// ReadinessRouteKey is the route key of the readiness probe endpoint
+	ReadinessRouteKey = "GET " + ReadinessPath
+
+	// RefreshIntervalKey is the key of the interval between two background checks of the backend services
+	RefreshIntervalKey = "HEALTH_REFRESH_INTERVAL"
+
+	// CheckTimeoutKey is the key of the timeout of the health check of a single backend service
+	CheckTimeoutKey = "HEALTH_CHECK_TIMEOUT"
+
+	// DefaultRefreshInterval is the default interval between two background checks of the backend services
+	DefaultRefreshInterval = 10 * time.Second
+
+	// DefaultCheckTimeout is the default timeout of the health check of a single backend service
+	DefaultCheckTimeout = 2 * time.Second
+)
+
+const (
+	// StatusUp is the status reported when the process is alive
+	StatusUp = "up"
+
+	// StatusReady is the status reported when every backend service is reachable
+	StatusReady = "ready"
+
+	// StatusNotReady is the status reported when at least one backend service is unreachable
+	StatusNotReady = "not_ready"
+
+	// StatusUnknown is the status reported before the first check has finished
+	StatusUnknown = "unknown"
+)
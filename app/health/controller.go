@@ -0,0 +1,69 @@
+package health
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// Controller struct for the health module
+// @Summary Health Router Group
+// @Description Router group for the liveness and readiness probes
+// @Tags health
+// @Produce json
+// @Router / [group]
+type Controller struct {
+	engine  *gin.Engine
+	checker *Checker
+}
+
+// LivenessResponse is the response of the liveness probe
+type LivenessResponse struct {
+	Status string `json:"status"`
+}
+
+// NewController creates a new health controller
+func NewController(engine *gin.Engine, checker *Checker) (*Controller, error) {
+	// Check if the checker is nil
+	if checker == nil {
+		return nil, NilCheckerError
+	}
+
+	return &Controller{
+		engine:  engine,
+		checker: checker,
+	}, nil
+}
+
+// Initialize initializes the routes for the controller
+func (c *Controller) Initialize() {
+	c.engine.GET(LivenessPath, c.liveness)
+	c.engine.GET(ReadinessPath, c.readiness)
+}
+
+// liveness reports that the process is up
+// @Summary Liveness probe
+// @Description Report that the API gateway process is up
+// @Tags health
+// @Produce json
+// @Success 200 {object} LivenessResponse
+// @Router /healthz [get]
+func (c *Controller) liveness(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, LivenessResponse{Status: StatusUp})
+}
+
+// readiness reports the cached status of every backend service
+// @Summary Readiness probe
+// @Description Report whether the API gateway can reach every backend gRPC service
+// @Tags health
+// @Produce json
+// @Success 200 {object} Report
+// @Failure 503 {object} Report
+// @Router /readyz [get]
+func (c *Controller) readiness(ctx *gin.Context) {
+	report := c.checker.Report()
+	if report.Status != StatusReady {
+		ctx.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+	ctx.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,15 @@
+package health
+
+import (
+	"errors"
+)
+
+var (
+	NilLoggerError             = errors.New("nil logger")
+	NilConnectionError         = errors.New("nil connection")
+	NilCheckerError            = errors.New("nil health checker")
+	InvalidIntervalError       = errors.New("invalid health check refresh interval")
+	InvalidTimeoutError        = errors.New("invalid health check timeout")
+	CheckerAlreadyStartedError = errors.New("health checker already started")
+	ServiceNotServingError     = errors.New("service is not serving")
+)
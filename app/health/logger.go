@@ -0,0 +1,40 @@
+package health
+
+import (
+	"log/slog"
+)
+
+// Logger is the logger for the health checker
+type Logger struct {
+	logger *slog.Logger
+}
+
+// NewLogger creates a new health checker logger
+func NewLogger(logger *slog.Logger) (*Logger, error) {
+	// Check if the logger is nil
+	if logger == nil {
+		return nil, NilLoggerError
+	}
+
+	return &Logger{logger: logger}, nil
+}
+
+// ServiceStatusChanged logs that the status of a backend service has changed
+func (l *Logger) ServiceStatusChanged(name string, status ServiceStatus) {
+	attrs := []any{
+		slog.String("service", name),
+		slog.String("status", status.Status),
+		slog.String("state", status.State),
+	}
+	if status.Error != "" {
+		attrs = append(attrs, slog.String("error", status.Error))
+		l.logger.Warn("service status changed", attrs...)
+		return
+	}
+	l.logger.Info("service status changed", attrs...)
+}
+
+// CheckerStopped logs that the background health checker has been stopped
+func (l *Logger) CheckerStopped() {
+	l.logger.Info("health checker stopped")
+}
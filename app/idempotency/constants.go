@@ -0,0 +1,70 @@
+package idempotency
+
+import (
+	"time"
+)
+
+const (
+	// EnabledKey is the key of the flag that enables the idempotency keys
+	EnabledKey = "IDEMPOTENCY_ENABLED"
+
+	// BackendKey is the key of the backend the idempotency records are stored in
+	BackendKey = "IDEMPOTENCY_BACKEND"
+
+	// MemoryBackend stores the idempotency records in the memory of each gateway instance
+	MemoryBackend = "memory"
+
+	// RedisBackend stores the idempotency records in Redis, sharing them between the gateway instances
+	RedisBackend = "redis"
+)
+
+const (
+	// Header is the header a client sends to make a non-idempotent request safe to repeat
+	Header = "Idempotency-Key"
+
+	// ReplayedHeader is the header set on the responses replayed from an idempotency record
+	ReplayedHeader = "Idempotent-Replayed"
+
+	// MaxKeyLength is the maximum length of an idempotency key
+	MaxKeyLength = 255
+)
+
+const (
+	// KeyReusedErrorCode is the error code of the requests whose idempotency key was used with a different body
+	KeyReusedErrorCode = "IDEMPOTENCY_KEY_REUSED"
+
+	// InProgressErrorCode is the error code of the requests whose idempotency key is used by a request in progress
+	InProgressErrorCode = "IDEMPOTENCY_KEY_IN_PROGRESS"
+)
+
+const (
+	// DefaultTTL is the default time the responses are replayed for
+	DefaultTTL = 24 * time.Hour
+
+	// DefaultLockTTL is the default time a request holds the lock of its idempotency key, which must be longer than
+	// the deadline of the routes
+	DefaultLockTTL = time.Minute
+)
+
+var (
+	// DefaultRoutes are the keys of the routes that honor the idempotency keys by default
+	DefaultRoutes = []string{
+		"POST /api/v1/orders/carts/current/checkout",
+		"POST /api/v1/payments/orders/pay/{order-id}",
+		"POST /api/v1/payments/branch-rents/pay/{branch-rent-id}",
+	}
+)
+
+const (
+	// keyPrefix is the prefix of the keys of the idempotency records
+	keyPrefix = "idempotency:"
+
+	// lockSuffix is the suffix of the keys of the locks of the idempotency records
+	lockSuffix = ":lock"
+
+	// cleanupInterval is the interval the expired records and locks are removed from the memory store at
+	cleanupInterval = time.Minute
+
+	// ginCtxKey is the key of the claimed idempotency key in the Gin context
+	ginCtxKey = "idempotency_key"
+)
@@ -0,0 +1,20 @@
+package idempotency
+
+import (
+	"errors"
+)
+
+var (
+	NilLoggerError            = errors.New("nil logger")
+	NilStoreError             = errors.New("nil idempotency store")
+	NilIdentifierError        = errors.New("nil JWT identifier")
+	NilRedisClientError       = errors.New("nil Redis client")
+	NonPositiveTTLError       = errors.New("non-positive idempotency TTL")
+	UnexpectedRedisReplyError = errors.New("unexpected Redis reply")
+	InvalidKeyError           = errors.New("invalid idempotency key, expected up to 255 printable ASCII characters")
+	MissingKeyError           = errors.New("missing Idempotency-Key header")
+	KeyReusedError            = errors.New("the idempotency key was already used with a different request")
+	RequestInProgressError    = errors.New("a request with the same idempotency key is still in progress")
+	StoreUnavailableError     = errors.New("the idempotency store is unavailable")
+	FailedToReadBodyError     = errors.New("failed to read the request body")
+)
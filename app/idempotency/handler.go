@@ -0,0 +1,29 @@
+package idempotency
+
+import (
+	"github.com/gin-gonic/gin"
+	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
+	pbtypesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
+)
+
+// RouteHandler creates the endpoints like the wrapped route handler does, wrapping the handlers of the authenticated
+// ones with the idempotency middleware, so the stored responses are only looked up once the caller was authenticated
+type RouteHandler struct {
+	commonhandler.Handler
+	middleware *Middleware
+}
+
+// RouteHandler returns a route handler that honors the idempotency keys of the authenticated endpoints created by the
+// given route handler
+func (m *Middleware) RouteHandler(handler commonhandler.Handler) *RouteHandler {
+	return &RouteHandler{Handler: handler, middleware: m}
+}
+
+// CreateAuthenticatedEndpoint creates the authenticated endpoint, whose handler runs after its authentication
+func (r *RouteHandler) CreateAuthenticatedEndpoint(mapper *pbtypesrest.Mapper, handler gin.HandlerFunc) (
+	string,
+	gin.HandlerFunc,
+	gin.HandlerFunc,
+) {
+	return r.Handler.CreateAuthenticatedEndpoint(mapper, r.middleware.Handler(handler))
+}
@@ -0,0 +1,35 @@
+package idempotency
+
+import (
+	"log/slog"
+)
+
+// Logger is the logger for the idempotency keys
+type Logger struct {
+	logger *slog.Logger
+}
+
+// NewLogger creates a new idempotency logger
+func NewLogger(logger *slog.Logger) (*Logger, error) {
+	// Check if the logger is nil
+	if logger == nil {
+		return nil, NilLoggerError
+	}
+
+	return &Logger{logger: logger}, nil
+}
+
+// ResponseReplayed logs that the stored response of an idempotency key was replayed
+func (l *Logger) ResponseReplayed(route string, status int) {
+	l.logger.Info("idempotent response replayed", slog.String("route", route), slog.Int("status", status))
+}
+
+// KeyReused logs that an idempotency key was reused with a different request
+func (l *Logger) KeyReused(route string) {
+	l.logger.Warn("idempotency key reused with a different request", slog.String("route", route))
+}
+
+// StoreFailed logs that the idempotency store failed
+func (l *Logger) StoreFailed(route string, err error) {
+	l.logger.Error("idempotency store failed", slog.String("route", route), slog.String("error", err.Error()))
+}
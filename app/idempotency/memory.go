@@ -0,0 +1,125 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type (
+	// MemoryStore stores the idempotency records in memory, so they are not shared between the gateway instances
+	MemoryStore struct {
+		mutex   sync.Mutex
+		records map[string]*memoryRecord
+		locks   map[string]*memoryLock
+		stop    chan struct{}
+		done    chan struct{}
+	}
+
+	// memoryRecord is an idempotency record stored in memory
+	memoryRecord struct {
+		record    *Record
+		expiresAt time.Time
+	}
+
+	// memoryLock is the lock of an idempotency key stored in memory
+	memoryLock struct {
+		token     string
+		expiresAt time.Time
+	}
+)
+
+// NewMemoryStore creates a new memory store, which removes the expired records and locks in the background until it
+// is closed
+func NewMemoryStore() *MemoryStore {
+	store := &MemoryStore{
+		records: make(map[string]*memoryRecord),
+		locks:   make(map[string]*memoryLock),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go store.cleanup()
+	return store
+}
+
+// Get returns the record with the given key, or nil if there is none
+func (m *MemoryStore) Get(_ context.Context, key string) (*Record, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	r, ok := m.records[key]
+	if !ok || !time.Now().Before(r.expiresAt) {
+		return nil, nil
+	}
+	return r.record, nil
+}
+
+// Lock locks the given key for the given time, returning the token that unlocks it, or false if it is already locked
+func (m *MemoryStore) Lock(_ context.Context, key string, ttl time.Duration) (string, bool, error) {
+	now := time.Now()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if l, ok := m.locks[key]; ok && now.Before(l.expiresAt) {
+		return "", false, nil
+	}
+	token := newLockToken()
+	m.locks[key] = &memoryLock{token: token, expiresAt: now.Add(ttl)}
+	return token, true, nil
+}
+
+// Unlock unlocks the given key, if it is still locked with the given token
+func (m *MemoryStore) Unlock(_ context.Context, key, token string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if l, ok := m.locks[key]; ok && l.token == token {
+		delete(m.locks, key)
+	}
+	return nil
+}
+
+// Save saves the record with the given key for the given time
+func (m *MemoryStore) Save(_ context.Context, key string, record *Record, ttl time.Duration) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.records[key] = &memoryRecord{record: record, expiresAt: time.Now().Add(ttl)}
+	return nil
+}
+
+// Close stops removing the expired records and locks
+func (m *MemoryStore) Close() error {
+	close(m.stop)
+	<-m.done
+	return nil
+}
+
+// cleanup removes the expired records and locks periodically
+func (m *MemoryStore) cleanup() {
+	defer close(m.done)
+
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stop:
+			return
+		case now := <-ticker.C:
+			m.mutex.Lock()
+			for key, r := range m.records {
+				if !now.Before(r.expiresAt) {
+					delete(m.records, key)
+				}
+			}
+			for key, l := range m.locks {
+				if !now.Before(l.expiresAt) {
+					delete(m.locks, key)
+				}
+			}
+			m.mutex.Unlock()
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/gin-gonic/gin"
+	appjwt "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/jwt"
+	appproblem "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	approute "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/route"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Middleware honors the idempotency keys of the non-idempotent requests, replaying the stored response of a request
+// that was already handled instead of handling it again
+type Middleware struct {
+	store      Store
+	identifier *appjwt.Identifier
+	routes     map[string]bool
+	ttl        time.Duration
+	lockTTL    time.Duration
+	required   bool
+	logger     *Logger
+}
+
+// NewMiddleware creates a new idempotency middleware for the routes with the given route keys, like
+// "POST /api/v1/payments/orders/pay/{order-id}", whose responses are replayed for the given TTL. The requests of these
+// routes without an idempotency key are rejected if the key is required
+func NewMiddleware(
+	store Store,
+	identifier *appjwt.Identifier,
+	routes []string,
+	ttl time.Duration,
+	lockTTL time.Duration,
+	required bool,
+	logger *Logger,
+) (*Middleware, error) {
+	// Check if the store, the identifier or the logger are nil
+	if store == nil {
+		return nil, NilStoreError
+	}
+	if identifier == nil {
+		return nil, NilIdentifierError
+	}
+	if logger == nil {
+		return nil, NilLoggerError
+	}
+
+	// Check the TTLs and normalize the route keys
+	if ttl <= 0 || lockTTL <= 0 {
+		return nil, NonPositiveTTLError
+	}
+	normalizedRoutes := make(map[string]bool, len(routes))
+	for _, key := range routes {
+		normalizedKey, err := approute.ParseKey(key)
+		if err != nil {
+			return nil, err
+		}
+		normalizedRoutes[normalizedKey] = true
+	}
+
+	return &Middleware{
+		store:      store,
+		identifier: identifier,
+		routes:     normalizedRoutes,
+		ttl:        ttl,
+		lockTTL:    lockTTL,
+		required:   required,
+		logger:     logger,
+	}, nil
+}
+
+// IsValidKey checks if the value can be used as an idempotency key
+func IsValidKey(key string) bool {
+	if key == "" || len(key) > MaxKeyLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x20 || key[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// Handler wraps the handler of an authenticated route, replaying the stored response of the requests whose
+// idempotency key was already used by the same user with the same body. It rejects the reuse of a key with a different
+// body with 422 Unprocessable Entity, and the requests whose key is used by a request still in progress with 409
+// Conflict. The responses of the server errors are not stored, so the request can be repeated with the same key. The
+// handler runs after the authentication of the route, so the responses are only replayed to the callers whose token
+// was validated
+func (m *Middleware) Handler(handler gin.HandlerFunc) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		routeKey := approute.KeyFromGinContext(ctx)
+		if !m.routes[routeKey] {
+			handler(ctx)
+			return
+		}
+
+		// Check the idempotency key
+		key := ctx.GetHeader(Header)
+		if key == "" {
+			if m.required {
+				appproblem.New(http.StatusBadRequest, appproblem.InvalidArgumentCode, MissingKeyError).Abort(ctx)
+				return
+			}
+			handler(ctx)
+			return
+		}
+		if !IsValidKey(key) {
+			appproblem.New(http.StatusBadRequest, appproblem.InvalidArgumentCode, InvalidKeyError).Abort(ctx)
+			return
+		}
+
+		// The token was already validated by the authentication of the route, so its user ID identifies the caller
+		userID := m.identifier.UserID(ctx)
+		if userID == "" {
+			handler(ctx)
+			return
+		}
+
+		// Read the body to hash it, restoring it for the handlers
+		body, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			appproblem.New(http.StatusBadRequest, appproblem.InvalidArgumentCode, FailedToReadBodyError).Abort(ctx)
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+		fingerprint := hash(body)
+		storeKey := keyPrefix + hash([]byte(routeKey+"\n"+userID+"\n"+key))
+
+		// Replay the stored response, if any
+		requestCtx := ctx.Request.Context()
+		if m.replay(ctx, routeKey, storeKey, fingerprint) {
+			return
+		}
+
+		// Lock the key, so the concurrent duplicates are not handled at the same time
+		token, locked, err := m.store.Lock(requestCtx, storeKey, m.lockTTL)
+		if err != nil {
+			m.unavailable(ctx, routeKey, err)
+			return
+		}
+		if !locked {
+			appproblem.New(http.StatusConflict, InProgressErrorCode, RequestInProgressError).
+				WithRetryAfter(time.Second).
+				Abort(ctx)
+			return
+		}
+
+		// The stores are still used once the request context is done, to save its response and release its lock
+		storeCtx := context.WithoutCancel(requestCtx)
+		defer func() {
+			if err := m.store.Unlock(storeCtx, storeKey, token); err != nil {
+				m.logger.StoreFailed(routeKey, err)
+			}
+		}()
+
+		// The request may have been handled between the lookup and the lock
+		if m.replay(ctx, routeKey, storeKey, fingerprint) {
+			return
+		}
+
+		// The key is claimed by this request, so its backend calls can be retried
+		ctx.Set(ginCtxKey, key)
+
+		writer := &recordingWriter{ResponseWriter: ctx.Writer}
+		ctx.Writer = writer
+
+		handler(ctx)
+
+		ctx.Writer = writer.ResponseWriter
+		status := writer.Status()
+		if !writer.Written() || status >= http.StatusInternalServerError {
+			return
+		}
+
+		record := &Record{
+			Fingerprint: fingerprint,
+			Status:      status,
+			ContentType: writer.Header().Get("Content-Type"),
+			Body:        writer.body.Bytes(),
+		}
+		if err = m.store.Save(storeCtx, storeKey, record, m.ttl); err != nil {
+			m.logger.StoreFailed(routeKey, err)
+		}
+	}
+}
+
+// KeyFromGinContext returns the idempotency key claimed by the request, or an empty string if there is none. Only
+// the keys validated and locked by the middleware are stored, so the header sent by the client is not trusted by
+// itself
+func KeyFromGinContext(ctx *gin.Context) string {
+	return ctx.GetString(ginCtxKey)
+}
+
+// replay writes the stored response of the key, if any, and returns whether the request was answered
+func (m *Middleware) replay(ctx *gin.Context, routeKey, storeKey, fingerprint string) bool {
+	record, err := m.store.Get(ctx.Request.Context(), storeKey)
+	if err != nil {
+		m.unavailable(ctx, routeKey, err)
+		return true
+	}
+	if record == nil {
+		return false
+	}
+
+	if record.Fingerprint != fingerprint {
+		m.logger.KeyReused(routeKey)
+		appproblem.New(http.StatusUnprocessableEntity, KeyReusedErrorCode, KeyReusedError).Abort(ctx)
+		return true
+	}
+
+	m.logger.ResponseReplayed(routeKey, record.Status)
+	ctx.Header(ReplayedHeader, strconv.FormatBool(true))
+	ctx.Data(record.Status, record.ContentType, record.Body)
+	ctx.Abort()
+	return true
+}
+
+// unavailable rejects the request because the idempotency store failed, since handling it could repeat it
+func (m *Middleware) unavailable(ctx *gin.Context, routeKey string, err error) {
+	m.logger.StoreFailed(routeKey, err)
+	appproblem.New(http.StatusServiceUnavailable, appproblem.UnavailableCode, StoreUnavailableError).Abort(ctx)
+}
+
+// hash returns the hex encoded SHA-256 hash of the data
+func hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,292 @@
+package idempotency
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	appjwt "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/jwt"
+	commonjwt "github.com/pixel-plaza-dev/uru-databases-2-go-service-common/crypto/jwt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type (
+	// userIDParser parses the tokens of the test requests, which are the user IDs of their callers
+	userIDParser struct{}
+
+	// requestStep is a request to the idempotent route and the response it must get
+	requestStep struct {
+		user string
+		key  string
+		body string
+
+		// hold keeps the handler of the request running until the last step of the case was sent
+		hold bool
+
+		wantStatus   int
+		wantCode     string
+		wantCall     int
+		wantReplayed bool
+	}
+
+	// middlewareCase is a sequence of requests to the idempotent route and the number of times it must be handled
+	middlewareCase struct {
+		name      string
+		required  bool
+		steps     []requestStep
+		wantCalls int32
+	}
+
+	// testResponse is the body of the responses of the test handler and of the problems
+	testResponse struct {
+		Call int    `json:"call"`
+		Code string `json:"code"`
+	}
+)
+
+const (
+	// testRoute is the route the idempotency keys are honored for
+	testRoute = "/api/v1/payments/orders/pay/:orderId"
+
+	// testPath is the path of the test requests
+	testPath = "/api/v1/payments/orders/pay/order-id"
+
+	// failBody is the body of the test requests that fail with a server error
+	failBody = "fail"
+)
+
+// GetClaims returns the claims of the caller whose user ID is the token
+func (userIDParser) GetClaims(token string) (*jwt.MapClaims, error) {
+	return &jwt.MapClaims{commonjwt.UserIdClaim: token}, nil
+}
+
+// newTestEngine creates a router whose idempotent route is handled by the given handler, wrapped by a new middleware
+// backed by the given store
+func newTestEngine(t *testing.T, store Store, required bool, handler gin.HandlerFunc) *gin.Engine {
+	t.Helper()
+
+	identifier, err := appjwt.NewIdentifier(userIDParser{})
+	if err != nil {
+		t.Fatalf("NewIdentifier() error = %v", err)
+	}
+	logger, err := NewLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("NewLogger() error = %v", err)
+	}
+	middleware, err := NewMiddleware(
+		store,
+		identifier,
+		[]string{"POST /api/v1/payments/orders/pay/{order-id}"},
+		time.Minute,
+		time.Minute,
+		required,
+		logger,
+	)
+	if err != nil {
+		t.Fatalf("NewMiddleware() error = %v", err)
+	}
+
+	engine := gin.New()
+	engine.POST(testRoute, middleware.Handler(handler))
+	return engine
+}
+
+// TestMiddlewareHandler checks the requests are handled once per user and idempotency key, and the responses of the
+// repeated requests are replayed
+func TestMiddlewareHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cases := []middlewareCase{
+		{
+			name: "first request",
+			steps: []requestStep{
+				{user: "user-id", key: "key", body: "order", wantStatus: http.StatusCreated, wantCall: 1},
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "replayed request",
+			steps: []requestStep{
+				{user: "user-id", key: "key", body: "order", wantStatus: http.StatusCreated, wantCall: 1},
+				{
+					user:         "user-id",
+					key:          "key",
+					body:         "order",
+					wantStatus:   http.StatusCreated,
+					wantCall:     1,
+					wantReplayed: true,
+				},
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "key reused with another body",
+			steps: []requestStep{
+				{user: "user-id", key: "key", body: "order", wantStatus: http.StatusCreated, wantCall: 1},
+				{
+					user:       "user-id",
+					key:        "key",
+					body:       "another order",
+					wantStatus: http.StatusUnprocessableEntity,
+					wantCode:   KeyReusedErrorCode,
+				},
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "key used by another user",
+			steps: []requestStep{
+				{user: "user-id", key: "key", body: "order", wantStatus: http.StatusCreated, wantCall: 1},
+				{user: "another-user-id", key: "key", body: "order", wantStatus: http.StatusCreated, wantCall: 2},
+			},
+			wantCalls: 2,
+		},
+		{
+			name: "request in progress",
+			steps: []requestStep{
+				{user: "user-id", key: "key", body: "order", hold: true, wantStatus: http.StatusCreated, wantCall: 1},
+				{
+					user:       "user-id",
+					key:        "key",
+					body:       "order",
+					wantStatus: http.StatusConflict,
+					wantCode:   InProgressErrorCode,
+				},
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "server error not stored",
+			steps: []requestStep{
+				{user: "user-id", key: "key", body: failBody, wantStatus: http.StatusInternalServerError, wantCall: 1},
+				{user: "user-id", key: "key", body: failBody, wantStatus: http.StatusInternalServerError, wantCall: 2},
+			},
+			wantCalls: 2,
+		},
+		{
+			name: "missing key",
+			steps: []requestStep{
+				{user: "user-id", body: "order", wantStatus: http.StatusCreated, wantCall: 1},
+				{user: "user-id", body: "order", wantStatus: http.StatusCreated, wantCall: 2},
+			},
+			wantCalls: 2,
+		},
+		{
+			name:     "missing required key",
+			required: true,
+			steps: []requestStep{
+				{user: "user-id", body: "order", wantStatus: http.StatusBadRequest, wantCode: "INVALID_ARGUMENT"},
+			},
+		},
+		{
+			name: "invalid key",
+			steps: []requestStep{
+				{
+					user:       "user-id",
+					key:        "key\x01",
+					body:       "order",
+					wantStatus: http.StatusBadRequest,
+					wantCode:   "INVALID_ARGUMENT",
+				},
+			},
+		},
+	}
+
+	for _, s := range newTestStores(t) {
+		for _, c := range cases {
+			t.Run(
+				s.name+"/"+c.name, func(t *testing.T) {
+					var calls atomic.Int32
+					entered := make(chan struct{})
+					release := make(chan struct{})
+					handler := func(ctx *gin.Context) {
+						call := calls.Add(1)
+						if ctx.GetHeader("Hold") != "" {
+							entered <- struct{}{}
+							<-release
+						}
+
+						body, _ := io.ReadAll(ctx.Request.Body)
+						status := http.StatusCreated
+						if string(body) == failBody {
+							status = http.StatusInternalServerError
+						}
+						ctx.JSON(status, testResponse{Call: int(call)})
+					}
+					engine := newTestEngine(t, s.store, c.required, handler)
+
+					// The keys are prefixed, so the cases do not share the records of the Redis store
+					var held sync.WaitGroup
+					for index, step := range c.steps {
+						request := httptest.NewRequest(http.MethodPost, testPath, strings.NewReader(step.body))
+						request.Header.Set("Authorization", "Bearer "+step.user)
+						if step.key != "" {
+							request.Header.Set(Header, c.name+"/"+step.key)
+						}
+
+						check := func(recorder *httptest.ResponseRecorder) {
+							checkResponse(t, index, recorder, step)
+						}
+						if !step.hold {
+							recorder := httptest.NewRecorder()
+							engine.ServeHTTP(recorder, request)
+							check(recorder)
+							continue
+						}
+
+						request.Header.Set("Hold", strconv.FormatBool(true))
+						held.Add(1)
+						go func() {
+							defer held.Done()
+							recorder := httptest.NewRecorder()
+							engine.ServeHTTP(recorder, request)
+							check(recorder)
+						}()
+						<-entered
+					}
+					close(release)
+					held.Wait()
+
+					if got := calls.Load(); got != c.wantCalls {
+						t.Errorf("handler calls = %d, want %d", got, c.wantCalls)
+					}
+				},
+			)
+		}
+	}
+}
+
+// checkResponse checks the response of the request of the given step
+func checkResponse(t *testing.T, index int, recorder *httptest.ResponseRecorder, step requestStep) {
+	t.Helper()
+
+	if recorder.Code != step.wantStatus {
+		t.Errorf("step %d: status = %d, want %d", index, recorder.Code, step.wantStatus)
+	}
+	if replayed := recorder.Header().Get(ReplayedHeader) != ""; replayed != step.wantReplayed {
+		t.Errorf("step %d: replayed = %v, want %v", index, replayed, step.wantReplayed)
+	}
+
+	var response testResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Errorf("step %d: Unmarshal() error = %v", index, err)
+		return
+	}
+	if response.Call != step.wantCall || response.Code != step.wantCode {
+		t.Errorf(
+			"step %d: response = %+v, want call %d and code %q",
+			index,
+			response,
+			step.wantCall,
+			step.wantCode,
+		)
+	}
+}
@@ -0,0 +1,75 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/go-redis/redis/v8"
+	"time"
+)
+
+// unlockScript deletes a lock only if it still holds the given token
+var unlockScript = redis.NewScript(
+	`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`,
+)
+
+// RedisStore stores the idempotency records in Redis, so they are shared between the gateway instances
+type RedisStore struct {
+	client redis.UniversalClient
+}
+
+// NewRedisStore creates a new Redis store
+func NewRedisStore(client redis.UniversalClient) (*RedisStore, error) {
+	// Check if the client is nil
+	if client == nil {
+		return nil, NilRedisClientError
+	}
+
+	return &RedisStore{client: client}, nil
+}
+
+// Get returns the record with the given key, or nil if there is none
+func (r *RedisStore) Get(ctx context.Context, key string) (*Record, error) {
+	reply, err := r.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var record Record
+	if err = json.Unmarshal(reply, &record); err != nil {
+		return nil, UnexpectedRedisReplyError
+	}
+	return &record, nil
+}
+
+// Lock locks the given key for the given time, returning the token that unlocks it, or false if it is already locked
+func (r *RedisStore) Lock(ctx context.Context, key string, ttl time.Duration) (string, bool, error) {
+	token := newLockToken()
+	locked, err := r.client.SetNX(ctx, key+lockSuffix, token, ttl).Result()
+	if err != nil || !locked {
+		return "", false, err
+	}
+	return token, true, nil
+}
+
+// Unlock unlocks the given key, if it is still locked with the given token
+func (r *RedisStore) Unlock(ctx context.Context, key, token string) error {
+	return unlockScript.Run(ctx, r.client, []string{key + lockSuffix}, token).Err()
+}
+
+// Save saves the record with the given key for the given time
+func (r *RedisStore) Save(ctx context.Context, key string, record *Record, ttl time.Duration) error {
+	encoded, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, key, encoded, ttl).Err()
+}
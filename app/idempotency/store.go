@@ -0,0 +1,42 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+type (
+	// Record is the stored response of a request with an idempotency key, along with the hash of its body
+	Record struct {
+		Fingerprint string `json:"fingerprint"`
+		Status      int    `json:"status"`
+		ContentType string `json:"content_type"`
+		Body        []byte `json:"body"`
+	}
+
+	// Store stores the idempotency records and the locks of the requests in progress
+	Store interface {
+		// Get returns the record with the given key, or nil if there is none
+		Get(ctx context.Context, key string) (*Record, error)
+
+		// Lock locks the given key for the given time, returning the token that unlocks it, or false if it is
+		// already locked
+		Lock(ctx context.Context, key string, ttl time.Duration) (string, bool, error)
+
+		// Unlock unlocks the given key, if it is still locked with the given token
+		Unlock(ctx context.Context, key, token string) error
+
+		// Save saves the record with the given key for the given time
+		Save(ctx context.Context, key string, record *Record, ttl time.Duration) error
+	}
+)
+
+// newLockToken generates a random token for a lock, so a request never releases a lock taken by another one after its
+// own lock expired
+func newLockToken() string {
+	token := make([]byte, 16)
+	_, _ = rand.Read(token)
+	return hex.EncodeToString(token)
+}
@@ -0,0 +1,228 @@
+package idempotency
+
+import (
+	"context"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type (
+	// testStore is a store and the function that moves its clock forward, so its records and locks expire
+	testStore struct {
+		name    string
+		store   Store
+		advance func(time.Duration)
+	}
+
+	// lockStep is a lock or an unlock of the key, or the expiration of its lock
+	lockStep struct {
+		unlock bool
+		expire bool
+
+		// token is the index of the token returned by a previous lock that is unlocked, or -1 for a token that never
+		// locked the key
+		token int
+
+		wantLocked bool
+	}
+
+	// lockCase is a sequence of locks and unlocks of the same key
+	lockCase struct {
+		name  string
+		steps []lockStep
+	}
+
+	// recordCase is a record saved under a key and the record returned by the lookup of the key
+	recordCase struct {
+		name   string
+		save   *Record
+		expire bool
+		want   *Record
+	}
+)
+
+const (
+	// testLockTTL is the time the test locks are held for
+	testLockTTL = 20 * time.Millisecond
+
+	// testTTL is the time the test records are stored for
+	testTTL = 20 * time.Millisecond
+)
+
+// newTestStores creates a memory store and a Redis store backed by an in-memory Redis server
+func newTestStores(t *testing.T) []testStore {
+	t.Helper()
+
+	memoryStore := NewMemoryStore()
+	t.Cleanup(func() { _ = memoryStore.Close() })
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	redisStore, err := NewRedisStore(client)
+	if err != nil {
+		t.Fatalf("NewRedisStore() error = %v", err)
+	}
+
+	return []testStore{
+		{
+			name:  "memory",
+			store: memoryStore,
+			advance: func(duration time.Duration) {
+				time.Sleep(duration)
+			},
+		},
+		{
+			name:    "redis",
+			store:   redisStore,
+			advance: server.FastForward,
+		},
+	}
+}
+
+// TestStoreLock checks a key is only locked by one request at a time, and only unlocked with the token of its lock
+func TestStoreLock(t *testing.T) {
+	cases := []lockCase{
+		{
+			name: "lock",
+			steps: []lockStep{
+				{wantLocked: true},
+			},
+		},
+		{
+			name: "lock already locked",
+			steps: []lockStep{
+				{wantLocked: true},
+				{wantLocked: false},
+			},
+		},
+		{
+			name: "unlock with token",
+			steps: []lockStep{
+				{wantLocked: true},
+				{unlock: true, token: 0},
+				{wantLocked: true},
+			},
+		},
+		{
+			name: "unlock with another token",
+			steps: []lockStep{
+				{wantLocked: true},
+				{unlock: true, token: -1},
+				{wantLocked: false},
+			},
+		},
+		{
+			name: "lock expired",
+			steps: []lockStep{
+				{wantLocked: true},
+				{expire: true},
+				{wantLocked: true},
+			},
+		},
+		{
+			name: "unlock with token of expired lock",
+			steps: []lockStep{
+				{wantLocked: true},
+				{expire: true},
+				{wantLocked: true},
+				{unlock: true, token: 0},
+				{wantLocked: false},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	for _, s := range newTestStores(t) {
+		for _, c := range cases {
+			t.Run(
+				s.name+"/"+c.name, func(t *testing.T) {
+					key := keyPrefix + c.name
+
+					var tokens []string
+					for index, step := range c.steps {
+						switch {
+						case step.expire:
+							s.advance(2 * testLockTTL)
+						case step.unlock:
+							token := "unknown-token"
+							if step.token >= 0 {
+								token = tokens[step.token]
+							}
+							if err := s.store.Unlock(ctx, key, token); err != nil {
+								t.Fatalf("step %d: Unlock() error = %v", index, err)
+							}
+						default:
+							token, locked, err := s.store.Lock(ctx, key, testLockTTL)
+							if err != nil {
+								t.Fatalf("step %d: Lock() error = %v", index, err)
+							}
+							if locked != step.wantLocked {
+								t.Fatalf("step %d: Lock() locked = %v, want %v", index, locked, step.wantLocked)
+							}
+							if locked {
+								tokens = append(tokens, token)
+							}
+						}
+					}
+				},
+			)
+		}
+	}
+}
+
+// TestStoreRecords checks the saved records are returned until they expire
+func TestStoreRecords(t *testing.T) {
+	record := &Record{
+		Fingerprint: hash([]byte(`{"order_id":"order-id"}`)),
+		Status:      201,
+		ContentType: "application/json",
+		Body:        []byte(`{"payment_id":"payment-id"}`),
+	}
+
+	cases := []recordCase{
+		{
+			name: "missing record",
+		},
+		{
+			name: "saved record",
+			save: record,
+			want: record,
+		},
+		{
+			name:   "expired record",
+			save:   record,
+			expire: true,
+		},
+	}
+
+	ctx := context.Background()
+	for _, s := range newTestStores(t) {
+		for _, c := range cases {
+			t.Run(
+				s.name+"/"+c.name, func(t *testing.T) {
+					key := keyPrefix + c.name
+					if c.save != nil {
+						if err := s.store.Save(ctx, key, c.save, testTTL); err != nil {
+							t.Fatalf("Save() error = %v", err)
+						}
+					}
+					if c.expire {
+						s.advance(2 * testTTL)
+					}
+
+					got, err := s.store.Get(ctx, key)
+					if err != nil {
+						t.Fatalf("Get() error = %v", err)
+					}
+					if !reflect.DeepEqual(got, c.want) {
+						t.Errorf("Get() = %+v, want %+v", got, c.want)
+					}
+				},
+			)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package jwt
+
+import (
+	"github.com/gin-gonic/gin"
+	appproblem "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	commongin "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin"
+	commonginmiddlewareauth "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/middleware/auth"
+	pbtypesgrpc "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/grpc"
+	pbtypesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
+	"net/http"
+	"strings"
+)
+
+// Authentication wraps the common authentication middleware, answering the requests of the authenticated routes
+// without a bearer token with a problem, since the common one answers them with a bare error
+type Authentication struct {
+	authentication commonginmiddlewareauth.Authentication
+}
+
+// NewAuthentication creates a new authentication that wraps the given one
+func NewAuthentication(authentication commonginmiddlewareauth.Authentication) (*Authentication, error) {
+	// Check if the authentication is nil
+	if authentication == nil {
+		return nil, NilAuthenticationError
+	}
+
+	return &Authentication{authentication: authentication}, nil
+}
+
+// Authenticate returns the middleware that authenticates the requests of the route of the given mapper
+func (a *Authentication) Authenticate(
+	mapper *pbtypesrest.Mapper,
+	grpcInterceptions *map[pbtypesgrpc.Method]pbtypesgrpc.Interception,
+) gin.HandlerFunc {
+	authenticate := a.authentication.Authenticate(mapper, grpcInterceptions)
+	return func(ctx *gin.Context) {
+		if requiresToken(mapper, grpcInterceptions) && !hasBearerToken(ctx) {
+			appproblem.New(http.StatusUnauthorized, appproblem.UnauthenticatedCode, MissingBearerTokenError).Abort(ctx)
+			return
+		}
+		authenticate(ctx)
+	}
+}
+
+// requiresToken checks if the RPC of the mapper is intercepted, so its route requires a token
+func requiresToken(
+	mapper *pbtypesrest.Mapper,
+	grpcInterceptions *map[pbtypesgrpc.Method]pbtypesgrpc.Interception,
+) bool {
+	if mapper == nil || grpcInterceptions == nil {
+		return false
+	}
+	interception, ok := (*grpcInterceptions)[mapper.GRPCMethod]
+	return ok && interception != pbtypesgrpc.None
+}
+
+// hasBearerToken checks if the request has a bearer token in its Authorization header
+func hasBearerToken(ctx *gin.Context) bool {
+	parts := strings.Split(ctx.GetHeader(commongin.AuthorizationHeaderKey), " ")
+	return len(parts) >= 2 && parts[0] == commongin.BearerPrefix
+}
@@ -4,3 +4,8 @@ const (
 	// PublicKey is the key of the JWT public key
 	PublicKey = "JWT_PUBLIC_KEY"
 )
+
+const (
+	// userIDKey is the key of the user ID of the parsed JWT in the Gin context
+	userIDKey = "jwt.user_id"
+)
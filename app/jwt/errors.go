@@ -0,0 +1,11 @@
+package jwt
+
+import (
+	"errors"
+)
+
+var (
+	NilClaimsParserError    = errors.New("nil JWT claims parser")
+	NilAuthenticationError  = errors.New("nil authentication")
+	MissingBearerTokenError = errors.New("missing or invalid bearer token in the Authorization header")
+)
@@ -0,0 +1,75 @@
+package jwt
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	commonginctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/context"
+	commonjwt "github.com/pixel-plaza-dev/uru-databases-2-go-service-common/crypto/jwt"
+	"strings"
+)
+
+type (
+	// ClaimsParser parses the claims of a JWT, checking its signing method, its signature and its time claims, like
+	// the validator of the authentication middleware does before asking the auth service if the token was revoked
+	ClaimsParser interface {
+		GetClaims(token string) (*jwt.MapClaims, error)
+	}
+
+	// Identifier identifies the caller of a request by the user ID of its JWT. The claims validated by the
+	// authentication middleware are used once the request was authenticated, while before that, or on the
+	// unauthenticated routes, the token is parsed by the parser of the authentication middleware, without checking if
+	// it was revoked, so it must only be used to key the requests, like in the logs or the rate limits
+	Identifier struct {
+		parser ClaimsParser
+	}
+)
+
+// NewIdentifier creates a new identifier that parses the tokens with the given parser
+func NewIdentifier(parser ClaimsParser) (*Identifier, error) {
+	// Check if the parser is nil
+	if parser == nil {
+		return nil, NilClaimsParserError
+	}
+
+	return &Identifier{parser: parser}, nil
+}
+
+// UserID returns the user ID of the bearer token of the request, or an empty string if there is no token or it is not
+// valid. The user ID of the tokens parsed before the authentication is cached in the Gin context
+func (i *Identifier) UserID(ctx *gin.Context) string {
+	// Prefer the claims validated by the authentication middleware
+	if claims, err := commonginctx.GetCtxTokenClaims(ctx); err == nil {
+		return userID(claims)
+	}
+
+	if id, ok := ctx.Get(userIDKey); ok {
+		return id.(string)
+	}
+
+	id := i.parse(ctx.GetHeader("Authorization"))
+	ctx.Set(userIDKey, id)
+	return id
+}
+
+// parse returns the user ID of the given authorization header value, or an empty string if it is not valid
+func (i *Identifier) parse(authorization string) string {
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok {
+		return ""
+	}
+
+	claims, err := i.parser.GetClaims(strings.TrimSpace(token))
+	if err != nil {
+		return ""
+	}
+	return userID(claims)
+}
+
+// userID returns the user ID claim of the given claims, or an empty string if there is none
+func userID(claims *jwt.MapClaims) string {
+	if claims == nil {
+		return ""
+	}
+	id, _ := (*claims)[commonjwt.UserIdClaim].(string)
+	return id
+}
@@ -0,0 +1,176 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	commonginctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/context"
+	commonflag "github.com/pixel-plaza-dev/uru-databases-2-go-service-common/config/flag"
+	commonjwt "github.com/pixel-plaza-dev/uru-databases-2-go-service-common/crypto/jwt"
+	commonjwtvalidator "github.com/pixel-plaza-dev/uru-databases-2-go-service-common/crypto/jwt/validator"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type (
+	// revokingTokenValidator rejects every token, as if the auth service had revoked them, which the identifier must
+	// not ask for
+	revokingTokenValidator struct{}
+
+	// identifierCase is the authorization of a request and the user ID it must be identified by
+	identifierCase struct {
+		name          string
+		authorization string
+		claims        *jwt.MapClaims
+		want          string
+	}
+)
+
+// IsTokenValid rejects the token
+func (revokingTokenValidator) IsTokenValid(string, string, bool) (bool, error) {
+	return false, nil
+}
+
+// newParser creates the parser of the authentication middleware with a new Ed25519 key pair, returning its private key
+func newParser(t *testing.T) (ClaimsParser, ed25519.PrivateKey) {
+	t.Helper()
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		t.Fatalf("MarshalPKIXPublicKey() error = %v", err)
+	}
+
+	parser, err := commonjwtvalidator.NewEd25519Validator(
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}),
+		revokingTokenValidator{},
+		commonflag.NewModeFlag(commonflag.ModeProd, []string{commonflag.ModeDev, commonflag.ModeProd}),
+	)
+	if err != nil {
+		t.Fatalf("NewEd25519Validator() error = %v", err)
+	}
+	return parser, privateKey
+}
+
+// sign signs the given claims with the given method and key
+func sign(t *testing.T, method jwt.SigningMethod, key interface{}, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+	return token
+}
+
+// TestIdentifierUserID checks the requests are identified by the user ID of the claims validated by the
+// authentication middleware, or else of the tokens the parser of the authentication middleware accepts
+func TestIdentifierUserID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	parser, privateKey := newParser(t)
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+
+	now := time.Now()
+	claims := func(mutate func(jwt.MapClaims)) jwt.MapClaims {
+		tokenClaims := jwt.MapClaims{
+			"exp":                 now.Add(time.Hour).Unix(),
+			"iat":                 now.Unix(),
+			commonjwt.IdClaim:     "jwt-id",
+			commonjwt.UserIdClaim: "user-id",
+		}
+		if mutate != nil {
+			mutate(tokenClaims)
+		}
+		return tokenClaims
+	}
+
+	cases := []identifierCase{
+		{
+			name:          "valid token",
+			authorization: "Bearer " + sign(t, jwt.SigningMethodEdDSA, privateKey, claims(nil)),
+			want:          "user-id",
+		},
+		{
+			name: "expired token",
+			authorization: "Bearer " + sign(
+				t, jwt.SigningMethodEdDSA, privateKey, claims(
+					func(tokenClaims jwt.MapClaims) {
+						tokenClaims["exp"] = now.Add(-time.Minute).Unix()
+					},
+				),
+			),
+		},
+		{
+			name: "token not valid yet",
+			authorization: "Bearer " + sign(
+				t, jwt.SigningMethodEdDSA, privateKey, claims(
+					func(tokenClaims jwt.MapClaims) {
+						tokenClaims["nbf"] = now.Add(time.Hour).Unix()
+					},
+				),
+			),
+		},
+		{
+			name:          "token signed by another key",
+			authorization: "Bearer " + sign(t, jwt.SigningMethodEdDSA, otherKey, claims(nil)),
+		},
+		{
+			name:          "token signed with another method",
+			authorization: "Bearer " + sign(t, jwt.SigningMethodHS256, []byte("secret"), claims(nil)),
+		},
+		{
+			name: "token without user ID",
+			authorization: "Bearer " + sign(
+				t, jwt.SigningMethodEdDSA, privateKey, claims(
+					func(tokenClaims jwt.MapClaims) {
+						delete(tokenClaims, commonjwt.UserIdClaim)
+					},
+				),
+			),
+		},
+		{
+			name:          "missing bearer prefix",
+			authorization: sign(t, jwt.SigningMethodEdDSA, privateKey, claims(nil)),
+		},
+		{
+			name:   "claims validated by the authentication middleware",
+			claims: &jwt.MapClaims{commonjwt.UserIdClaim: "validated-user-id"},
+			authorization: "Bearer " + sign(
+				t, jwt.SigningMethodEdDSA, privateKey, claims(nil),
+			),
+			want: "validated-user-id",
+		},
+	}
+
+	identifier, err := NewIdentifier(parser)
+	if err != nil {
+		t.Fatalf("NewIdentifier() error = %v", err)
+	}
+	for _, c := range cases {
+		t.Run(
+			c.name, func(t *testing.T) {
+				ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+				ctx.Request = httptest.NewRequest(http.MethodGet, "/api/v1/users/profile", nil)
+				ctx.Request.Header.Set("Authorization", c.authorization)
+				if c.claims != nil {
+					commonginctx.SetCtxTokenClaims(ctx, c.claims)
+				}
+
+				if got := identifier.UserID(ctx); got != c.want {
+					t.Errorf("UserID() = %q, want %q", got, c.want)
+				}
+			},
+		)
+	}
+}
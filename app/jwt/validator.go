@@ -0,0 +1,38 @@
+package jwt
+
+import (
+	"github.com/golang-jwt/jwt/v5"
+	commonjwtvalidator "github.com/pixel-plaza-dev/uru-databases-2-go-service-common/crypto/jwt/validator"
+	pbtypesgrpc "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Validator wraps a JWT validator, so the tokens it rejects are answered by the response handler of the
+// authentication middleware, which only handles the gRPC status errors
+type Validator struct {
+	commonjwtvalidator.Validator
+}
+
+// NewValidator creates a new validator that wraps the given one
+func NewValidator(validator commonjwtvalidator.Validator) (*Validator, error) {
+	// Check if the validator is nil
+	if validator == nil {
+		return nil, commonjwtvalidator.NilValidatorError
+	}
+
+	return &Validator{Validator: validator}, nil
+}
+
+// GetValidatedClaims parses, validates and returns the claims of the given token, turning the errors of the rejected
+// tokens into Unauthenticated gRPC status errors
+func (v *Validator) GetValidatedClaims(token string, interception pbtypesgrpc.Interception) (*jwt.MapClaims, error) {
+	claims, err := v.Validator.GetValidatedClaims(token, interception)
+	if err == nil {
+		return claims, nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return nil, err
+	}
+	return nil, status.Error(codes.Unauthenticated, err.Error())
+}
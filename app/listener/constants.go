@@ -1,6 +1,30 @@
 package listener
 
+import (
+	"time"
+)
+
 const (
 	// PortKey is the key of the default port for the API gateway
 	PortKey = "PORT"
+
+	// DefaultHost is the default host the API gateway listens on
+	DefaultHost = "0.0.0.0"
+
+	// DefaultPort is the default port the API gateway listens on
+	DefaultPort = "8080"
+
+	// ShutdownTimeoutKey is the key of the graceful shutdown timeout for the API gateway
+	ShutdownTimeoutKey = "SHUTDOWN_TIMEOUT"
+
+	// DefaultShutdownTimeout is the default time given to in-flight requests to finish before the server is stopped
+	DefaultShutdownTimeout = 10 * time.Second
+
+	// TrustedProxiesKey is the key of the comma-separated IPs and CIDRs of the proxies whose forwarded headers are
+	// trusted to get the IP of the client
+	TrustedProxiesKey = "TRUSTED_PROXIES"
+
+	// TrustedPlatformKey is the key of the header set by the platform in front of the gateway with the IP of the
+	// client, like CF-Connecting-IP, which is trusted over the forwarded headers
+	TrustedPlatformKey = "TRUSTED_PLATFORM"
 )
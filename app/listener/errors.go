@@ -0,0 +1,11 @@
+package listener
+
+import (
+	"errors"
+)
+
+var (
+	NilLoggerError              = errors.New("nil logger")
+	NilHandlerError             = errors.New("nil handler")
+	InvalidShutdownTimeoutError = errors.New("invalid shutdown timeout")
+)
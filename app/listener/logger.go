@@ -0,0 +1,70 @@
+package listener
+
+import (
+	"log/slog"
+	"os"
+	"time"
+)
+
+// Logger is the logger for the listener
+type Logger struct {
+	logger *slog.Logger
+}
+
+// NewLogger creates a new listener logger
+func NewLogger(logger *slog.Logger) (*Logger, error) {
+	// Check if the logger is nil
+	if logger == nil {
+		return nil, NilLoggerError
+	}
+
+	return &Logger{logger: logger}, nil
+}
+
+// ServerStarted logs that the server has started listening on the given port
+func (l *Logger) ServerStarted(port string) {
+	l.logger.Info("server started", slog.String("port", port))
+}
+
+// FailedToListen logs that the server could not listen on the given port
+func (l *Logger) FailedToListen(port string, err error) {
+	l.logger.Error("failed to listen", slog.String("port", port), slog.String("error", err.Error()))
+}
+
+// ServerFailed logs that the server stopped listening due to an error
+func (l *Logger) ServerFailed(err error) {
+	l.logger.Error("server failed", slog.String("error", err.Error()))
+}
+
+// ShutdownSignalReceived logs that a shutdown signal has been received
+func (l *Logger) ShutdownSignalReceived(signal os.Signal, timeout time.Duration) {
+	l.logger.Info(
+		"shutdown signal received, draining in-flight requests",
+		slog.String("signal", signal.String()),
+		slog.Duration("timeout", timeout),
+	)
+}
+
+// ServerStopped logs that the server has stopped accepting and serving requests
+func (l *Logger) ServerStopped() {
+	l.logger.Info("server stopped")
+}
+
+// FailedToDrain logs that the in-flight requests could not be drained before the deadline
+func (l *Logger) FailedToDrain(err error) {
+	l.logger.Error("failed to drain in-flight requests", slog.String("error", err.Error()))
+}
+
+// ConnectionClosed logs that a backend connection has been closed
+func (l *Logger) ConnectionClosed(name string) {
+	l.logger.Info("connection closed", slog.String("connection", name))
+}
+
+// FailedToCloseConnection logs that a backend connection could not be closed
+func (l *Logger) FailedToCloseConnection(name string, err error) {
+	l.logger.Error(
+		"failed to close connection",
+		slog.String("connection", name),
+		slog.String("error", err.Error()),
+	)
+}
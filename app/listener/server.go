@@ -0,0 +1,141 @@
+package listener
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+type (
+	// Connection is a named backend connection that must be closed when the server stops
+	Connection struct {
+		Name   string
+		Closer io.Closer
+	}
+
+	// Server is the HTTP server of the API gateway with graceful shutdown support
+	Server struct {
+		httpServer      *http.Server
+		port            string
+		shutdownTimeout time.Duration
+		connections     []Connection
+		logger          *Logger
+	}
+)
+
+// NewServer creates a new server that listens on the given formatted port. The connections are closed in the given
+// order once every in-flight request has been drained
+func NewServer(
+	handler http.Handler,
+	port string,
+	formattedPort string,
+	shutdownTimeout time.Duration,
+	connections []Connection,
+	logger *Logger,
+) (*Server, error) {
+	// Check if the handler or the logger are nil
+	if handler == nil {
+		return nil, NilHandlerError
+	}
+	if logger == nil {
+		return nil, NilLoggerError
+	}
+
+	// Check if the shutdown timeout is valid
+	if shutdownTimeout <= 0 {
+		return nil, InvalidShutdownTimeoutError
+	}
+
+	return &Server{
+		httpServer: &http.Server{
+			Addr:    formattedPort,
+			Handler: handler,
+		},
+		port:            port,
+		shutdownTimeout: shutdownTimeout,
+		connections:     connections,
+		logger:          logger,
+	}, nil
+}
+
+// Run starts the server and blocks until it receives a SIGINT or SIGTERM signal, then stops accepting new
+// connections, drains the in-flight requests and closes the backend connections
+func (s *Server) Run() error {
+	// Trap the shutdown signals
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	// Bind the port before reporting the server as started, so a port in use is reported as a failure
+	listener, err := net.Listen("tcp", s.httpServer.Addr)
+	if err != nil {
+		s.logger.FailedToListen(s.port, err)
+		if closeErr := s.closeConnections(); closeErr != nil {
+			return errors.Join(err, closeErr)
+		}
+		return err
+	}
+
+	// Start the server
+	serverErrors := make(chan error, 1)
+	go func() {
+		if err := s.httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErrors <- err
+		}
+		close(serverErrors)
+	}()
+	s.logger.ServerStarted(s.port)
+
+	// Wait for a shutdown signal or for the server to fail
+	var runErr error
+	select {
+	case err := <-serverErrors:
+		s.logger.ServerFailed(err)
+		runErr = err
+	case receivedSignal := <-signals:
+		s.logger.ShutdownSignalReceived(receivedSignal, s.shutdownTimeout)
+		runErr = s.shutdown()
+	}
+
+	// Close the backend connections once no handler can use them
+	if err := s.closeConnections(); err != nil && runErr == nil {
+		runErr = err
+	}
+	return runErr
+}
+
+// shutdown stops accepting new connections and waits for the in-flight requests until the shutdown timeout
+func (s *Server) shutdown() error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		s.logger.FailedToDrain(err)
+
+		// Forcefully close the remaining connections
+		_ = s.httpServer.Close()
+		return err
+	}
+	s.logger.ServerStopped()
+	return nil
+}
+
+// closeConnections closes the backend connections in order, logging every failure instead of stopping at the first
+func (s *Server) closeConnections() error {
+	var errs []error
+	for _, connection := range s.connections {
+		if err := connection.Closer.Close(); err != nil {
+			s.logger.FailedToCloseConnection(connection.Name, err)
+			errs = append(errs, err)
+			continue
+		}
+		s.logger.ConnectionClosed(connection.Name)
+	}
+	return errors.Join(errs...)
+}
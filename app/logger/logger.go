@@ -1,13 +1,38 @@
 package logger
 
 import (
+	appaccesslog "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/accesslog"
+	appbreaker "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/breaker"
+	appbruteforce "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/bruteforce"
+	appcache "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/cache"
+	appconfig "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/config"
+	apphealth "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/health"
+	appidempotency "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/idempotency"
+	applistener "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/listener"
+	appmetrics "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/metrics"
+	appratelimit "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/ratelimit"
+	appretry "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/retry"
+	apptracing "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/tracing"
 	authmiddleware "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/middleware/auth"
 	commongcloud "github.com/pixel-plaza-dev/uru-databases-2-go-service-common/cloud/gcloud"
 	commonenv "github.com/pixel-plaza-dev/uru-databases-2-go-service-common/config/env"
 	commonflag "github.com/pixel-plaza-dev/uru-databases-2-go-service-common/config/flag"
-	commonoutgoingctx "github.com/pixel-plaza-dev/uru-databases-2-go-service-common/http/grpc/client/interceptor/outgoing-ctx"
-	commonlistener "github.com/pixel-plaza-dev/uru-databases-2-go-service-common/http/listener"
 	commonlogger "github.com/pixel-plaza-dev/uru-databases-2-go-service-common/utils/logger"
+	"log/slog"
+	"os"
+)
+
+var (
+	// Level is the minimum level of the structured logs, which is set from the configuration
+	Level = new(slog.LevelVar)
+
+	// handler writes the structured logs as JSON lines to the standard output, redacting the sensitive data
+	handler = slog.NewJSONHandler(
+		os.Stdout, &slog.HandlerOptions{
+			Level:       Level,
+			ReplaceAttr: appaccesslog.RedactAttr,
+		},
+	)
 )
 
 var (
@@ -15,7 +40,7 @@ var (
 	FlagLogger, _ = commonflag.NewLogger(commonlogger.NewDefaultLogger("Flag"))
 
 	// ListenerLogger is the logger for the listener
-	ListenerLogger, _ = commonlistener.NewLogger(commonlogger.NewDefaultLogger("Net Listener"))
+	ListenerLogger, _ = applistener.NewLogger(NewLogger("Net Listener"))
 
 	// EnvironmentLogger is the logger for the environment
 	EnvironmentLogger, _ = commonenv.NewLogger(commonlogger.NewDefaultLogger("Environment"))
@@ -23,9 +48,49 @@ var (
 	// GCloudLogger is the logger for the Google Cloud
 	GCloudLogger, _ = commongcloud.NewLogger(commonlogger.NewDefaultLogger("Google Cloud"))
 
-	// OutgoingCtxLogger is the logger for the outgoing context
-	OutgoingCtxLogger, _ = commonoutgoingctx.NewLogger(commonlogger.NewDefaultLogger("Outgoing Context"))
+	// AccessLogger is the logger for the access logs and the outgoing backend calls
+	AccessLogger, _ = appaccesslog.NewLogger(NewLogger("Access"))
+
+	// ConfigLogger is the logger for the configuration
+	ConfigLogger, _ = appconfig.NewLogger(NewLogger("Config"))
+
+	// HealthLogger is the logger for the health checker
+	HealthLogger, _ = apphealth.NewLogger(NewLogger("Health"))
+
+	// MetricsLogger is the logger for the metrics listener
+	MetricsLogger, _ = appmetrics.NewLogger(NewLogger("Metrics"))
+
+	// TracingLogger is the logger for the tracer
+	TracingLogger, _ = apptracing.NewLogger(NewLogger("Tracing"))
+
+	// RateLimitLogger is the logger for the rate limiter
+	RateLimitLogger, _ = appratelimit.NewLogger(NewLogger("Rate Limit"))
+
+	// LogInGuardLogger is the logger for the log-in guard
+	LogInGuardLogger, _ = appbruteforce.NewLogger(NewLogger("Log-in Guard"))
+
+	// RetryLogger is the logger for the retries of the backend calls
+	RetryLogger, _ = appretry.NewLogger(NewLogger("Retry"))
+
+	// BreakerLogger is the logger for the circuit breakers of the backend services
+	BreakerLogger, _ = appbreaker.NewLogger(NewLogger("Circuit Breaker"))
+
+	// IdempotencyLogger is the logger for the idempotency keys
+	IdempotencyLogger, _ = appidempotency.NewLogger(NewLogger("Idempotency"))
+
+	// CacheLogger is the logger for the response cache
+	CacheLogger, _ = appcache.NewLogger(NewLogger("Cache"))
 
 	// AuthMiddlewareLogger is the logger for the Auth middleware
 	AuthMiddlewareLogger, _ = authmiddleware.NewLogger(commonlogger.NewDefaultLogger("Auth Middleware"))
 )
+
+// NewLogger creates a new structured logger tagged with the given name
+func NewLogger(name string) *slog.Logger {
+	return slog.New(handler).With(slog.String("logger", name))
+}
+
+// SetLevel sets the minimum level of the structured logs
+func SetLevel(level string) error {
+	return Level.UnmarshalText([]byte(level))
+}
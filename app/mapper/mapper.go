@@ -0,0 +1,45 @@
+package mapper
+
+import (
+	pbconfiggrpcauth "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/grpc/auth"
+	pbconfiggrpcorder "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/grpc/order"
+	pbconfiggrpcshop "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/grpc/shop"
+	typesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
+)
+
+// Mappers of the routes whose common endpoint lacks the path parameters their RPC needs. They map the same RPCs, so
+// the routes are authenticated and intercepted like the common ones
+var (
+	// AddUserRoleMapper maps the addition of a role to a user by the ID of the user, like /:user-id
+	AddUserRoleMapper = typesrest.NewMapper(
+		typesrest.NewRelativeEndpoint(typesrest.UserId),
+		pbconfiggrpcauth.AddUserRole,
+	)
+
+	// RevokeUserRoleMapper maps the revocation of a role of a user by the ID of the user, like /:user-id
+	RevokeUserRoleMapper = typesrest.NewMapper(
+		typesrest.NewRelativeEndpoint(typesrest.UserId),
+		pbconfiggrpcauth.RevokeUserRole,
+	)
+
+	// GetBranchProductMapper maps the branch products by the ID of their branch and of their product, like
+	// /:branch-id/:product-id
+	GetBranchProductMapper = typesrest.NewMapper(
+		typesrest.NewRelativeEndpoint(typesrest.BranchId, typesrest.ProductId),
+		pbconfiggrpcshop.GetBranchProduct,
+	)
+
+	// RemoveProductFromCartMapper maps the removal of a branch product from the current cart by its ID, like
+	// /:branch-product-id
+	RemoveProductFromCartMapper = typesrest.NewMapper(
+		typesrest.NewRelativeEndpoint(typesrest.BranchProductId),
+		pbconfiggrpcorder.RemoveProductFromCart,
+	)
+)
+
+var (
+	// GetLockoutsMapper maps the lockout state of the log-in brute-force protection, like /lockouts. It has no RPC of
+	// its own, so it is authenticated like the listing of the roles of a user, which the handler calls to check if the
+	// caller has the admin role
+	GetLockoutsMapper = typesrest.NewMapper(typesrest.NewEndpoint("lockouts"), pbconfiggrpcauth.GetUserRoles)
+)
@@ -0,0 +1,21 @@
+package metrics
+
+const (
+	// EnabledKey is the key of the flag that enables the metrics listener
+	EnabledKey = "METRICS_ENABLED"
+
+	// PortKey is the key of the port of the metrics listener
+	PortKey = "METRICS_PORT"
+
+	// DefaultPort is the default port of the metrics listener
+	DefaultPort = "9090"
+
+	// Path is the path the metrics are exposed on
+	Path = "/metrics"
+
+	// UnmatchedRoute is the route label of the requests that did not match any route
+	UnmatchedRoute = "unmatched"
+)
+
+// DefaultBuckets are the default latency histogram buckets, in seconds
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
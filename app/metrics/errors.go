@@ -0,0 +1,10 @@
+package metrics
+
+import (
+	"errors"
+)
+
+var (
+	NilLoggerError     = errors.New("nil logger")
+	NilRegistererError = errors.New("nil metrics registerer")
+)
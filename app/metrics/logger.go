@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"log/slog"
+)
+
+// Logger is the logger for the metrics listener
+type Logger struct {
+	logger *slog.Logger
+}
+
+// NewLogger creates a new metrics listener logger
+func NewLogger(logger *slog.Logger) (*Logger, error) {
+	// Check if the logger is nil
+	if logger == nil {
+		return nil, NilLoggerError
+	}
+
+	return &Logger{logger: logger}, nil
+}
+
+// ServerStarted logs that the metrics listener has started on the given address
+func (l *Logger) ServerStarted(address string) {
+	l.logger.Info("metrics server started", slog.String("address", address), slog.String("path", Path))
+}
+
+// ServerFailed logs that the metrics listener stopped due to an error
+func (l *Logger) ServerFailed(err error) {
+	l.logger.Error("metrics server failed", slog.String("error", err.Error()))
+}
+
+// ServerStopped logs that the metrics listener has stopped
+func (l *Logger) ServerStopped() {
+	l.logger.Info("metrics server stopped")
+}
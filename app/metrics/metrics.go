@@ -0,0 +1,116 @@
+package metrics
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"strconv"
+	"time"
+)
+
+// Metrics records the HTTP requests served by the API gateway and the gRPC calls made to the backend services
+type Metrics struct {
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	grpcRequests *prometheus.CounterVec
+	grpcDuration *prometheus.HistogramVec
+}
+
+// NewMetrics creates the API gateway metrics and registers them with the given registerer
+func NewMetrics(registerer prometheus.Registerer) (*Metrics, error) {
+	// Check if the registerer is nil
+	if registerer == nil {
+		return nil, NilRegistererError
+	}
+
+	metrics := &Metrics{
+		httpRequests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "http_requests_total",
+				Help: "Total number of HTTP requests, by route template and status code",
+			},
+			[]string{"method", "route", "status"},
+		),
+		httpDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "http_request_duration_seconds",
+				Help:    "Latency of the HTTP requests, by route template and status code",
+				Buckets: DefaultBuckets,
+			},
+			[]string{"method", "route", "status"},
+		),
+		grpcRequests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "grpc_client_handled_total",
+				Help: "Total number of gRPC calls made to the backend services, by method and status code",
+			},
+			[]string{"service", "method", "code"},
+		),
+		grpcDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "grpc_client_handling_seconds",
+				Help:    "Latency of the gRPC calls made to the backend services, by method and status code",
+				Buckets: DefaultBuckets,
+			},
+			[]string{"service", "method", "code"},
+		),
+	}
+
+	for _, collector := range []prometheus.Collector{
+		metrics.httpRequests,
+		metrics.httpDuration,
+		metrics.grpcRequests,
+		metrics.grpcDuration,
+	} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+	return metrics, nil
+}
+
+// HTTPMiddleware records the count and latency of the HTTP requests. The route label is the route template, like
+// /api/v1/shops/products/:product-id, so the cardinality does not grow with the requested paths
+func (m *Metrics) HTTPMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = UnmatchedRoute
+		}
+		statusCode := strconv.Itoa(ctx.Writer.Status())
+
+		m.httpRequests.WithLabelValues(ctx.Request.Method, route, statusCode).Inc()
+		m.httpDuration.WithLabelValues(ctx.Request.Method, route, statusCode).Observe(time.Since(start).Seconds())
+	}
+}
+
+// UnaryClientInterceptor records the count and latency of the gRPC calls made to the given backend service, except
+// the health checks of the readiness probe, which are not calls of the API
+func (m *Metrics) UnaryClientInterceptor(service string) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if method == healthpb.Health_Check_FullMethodName {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		code := status.Code(err).String()
+
+		m.grpcRequests.WithLabelValues(service, method, code).Inc()
+		m.grpcDuration.WithLabelValues(service, method, code).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Server is the listener that exposes the metrics, separated from the API so it is never publicly routed
+type Server struct {
+	httpServer *http.Server
+	logger     *Logger
+}
+
+// NewServer creates a new metrics listener on the given address, which exposes the metrics of the default registry
+func NewServer(address string, logger *Logger) (*Server, error) {
+	// Check if the logger is nil
+	if logger == nil {
+		return nil, NilLoggerError
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(Path, promhttp.Handler())
+
+	return &Server{
+		httpServer: &http.Server{
+			Addr:              address,
+			Handler:           mux,
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+		logger: logger,
+	}, nil
+}
+
+// Start binds the address of the metrics listener and serves the metrics in the background
+func (s *Server) Start() error {
+	// Bind the address before reporting the listener as started, so an address in use is reported as a failure
+	listener, err := net.Listen("tcp", s.httpServer.Addr)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		if err := s.httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.ServerFailed(err)
+		}
+	}()
+	s.logger.ServerStarted(listener.Addr().String())
+	return nil
+}
+
+// Close stops the metrics listener, waiting briefly for the running scrapes to finish
+func (s *Server) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		return err
+	}
+	s.logger.ServerStopped()
+	return nil
+}
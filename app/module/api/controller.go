@@ -3,9 +3,9 @@ package api
 import (
 	"github.com/gin-gonic/gin"
 	modulev1 "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	authmiddleware "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/middleware/auth"
 	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbconfigrestapi "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api"
 )
 
@@ -20,7 +20,7 @@ type Controller struct {
 	engine          *gin.Engine
 	route           *gin.RouterGroup
 	authMiddleware  authmiddleware.Authentication
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 	v1Controller    *modulev1.Controller
 }
 
@@ -28,7 +28,7 @@ type Controller struct {
 func NewController(
 	engine *gin.Engine,
 	authMiddleware authmiddleware.Authentication,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the API controller
 	route := engine.Group(pbconfigrestapi.Base.String())
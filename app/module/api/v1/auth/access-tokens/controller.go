@@ -2,13 +2,13 @@ package access_tokens
 
 import (
 	"github.com/gin-gonic/gin"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
+	appparams "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/params"
+	_ "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbauth "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/auth"
 	pbconfigrestaccesstokens "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/auth/access-tokens"
-	pbtypesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
 	"net/http"
 )
 
@@ -23,7 +23,7 @@ type Controller struct {
 	route           *gin.RouterGroup
 	client          pbauth.AuthClient
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new access tokens controller
@@ -31,7 +31,7 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pbauth.AuthClient,
 	routeHandler commonhandler.Handler,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the access tokens controller
 	route := baseRoute.Group(pbconfigrestaccesstokens.Base.String())
@@ -64,21 +64,25 @@ func (c *Controller) Initialize() {
 // @Produce json
 // @Param jwt-id path string true "JWT ID"
 // @Success 200 {object} pbauth.IsAccessTokenValidResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Router /api/v1/auth/access-tokens/valid/{jwt-id} [get]
 func (c *Controller) isAccessTokenValid(ctx *gin.Context) {
 	var request pbauth.IsAccessTokenValidRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Add the JWT Identifier to the request
-	request.JwtId = ctx.Param(pbtypesrest.JwtId.String())
+	request.JwtId, err = appparams.JwtID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Check if the access token is valid
 	response, err := c.client.IsAccessTokenValid(grpcCtx, &request)
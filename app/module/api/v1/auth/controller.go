@@ -2,23 +2,26 @@ package auth
 
 import (
 	"github.com/gin-gonic/gin"
+	appbruteforce "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/bruteforce"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
 	moduleauthaccesstokens "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/auth/access-tokens"
 	moduleauthpermissions "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/auth/permissions"
 	moduleauthrefreshtokens "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/auth/refresh-tokens"
 	moduleauthrolepermissions "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/auth/role-permissions"
 	moduleauthroles "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/auth/roles"
 	moduleauthuserroles "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/auth/user-roles"
+	_ "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appproblem "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	apptypes "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/types"
 	authmiddleware "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/middleware/auth"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbauth "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/auth"
 	pbconfiggrpcauth "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/grpc/auth"
 	pbconfigrestauth "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/auth"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"net/http"
+	"time"
 )
 
 // Controller struct for the auth module
@@ -33,21 +36,29 @@ type Controller struct {
 	client          pbauth.AuthClient
 	authentication  authmiddleware.Authentication
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
+	logInGuard      *appbruteforce.Guard
+	adminRole       string
 }
 
-// NewController creates a new auth controller
+// NewController creates a new auth controller, whose log-in attempts are checked by the log-in guard, if any, whose
+// lockout state is exposed to the users with the given admin role
 func NewController(
 	apiRoute *gin.RouterGroup,
 	client pbauth.AuthClient,
 	authentication authmiddleware.Authentication,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
+	logInGuard *appbruteforce.Guard,
+	adminRole string,
 ) *Controller {
 	// Create a new route for the auth controller
 	route := apiRoute.Group(pbconfigrestauth.Base.String())
 
-	// Create the route handler
-	routeHandler := commonhandler.NewDefaultHandler(authentication, &pbconfiggrpcauth.Interceptions)
+	// Create the route handler, which replays the responses of the repeated non-idempotent requests once they are
+	// authenticated
+	routeHandler := responseHandler.RouteHandler(
+		commonhandler.NewDefaultHandler(authentication, &pbconfiggrpcauth.Interceptions),
+	)
 
 	// Create a new auth controller
 	return &Controller{
@@ -56,6 +67,8 @@ func NewController(
 		authentication:  authentication,
 		routeHandler:    routeHandler,
 		responseHandler: responseHandler,
+		logInGuard:      logInGuard,
+		adminRole:       adminRole,
 	}
 }
 
@@ -105,6 +118,19 @@ func (c *Controller) initializeChildren() {
 	} {
 		controller.Initialize()
 	}
+
+	// Expose the lockout state of the log-in guard to the users with the admin role
+	if c.logInGuard != nil {
+		lockoutsController := appbruteforce.NewController(
+			c.route,
+			c.logInGuard,
+			c.client,
+			c.routeHandler,
+			c.responseHandler,
+			c.adminRole,
+		)
+		lockoutsController.Initialize()
+	}
 }
 
 // logIn logs in a user
@@ -115,23 +141,61 @@ func (c *Controller) initializeChildren() {
 // @Produce json
 // @Param request body LogInRequest true "Log In Request"
 // @Success 200 {object} LogInResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 401 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Router /api/v1/auth/log-in [post]
 func (c *Controller) logIn(ctx *gin.Context) {
 	var request pbauth.LogInRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
+	// Check if the username and the client IP are allowed to attempt to log in, answering like an invalid attempt
+	// otherwise, after the time the auth service takes to answer one, so a locked out account cannot be told apart
+	// from wrong credentials
+	clientIP := ctx.ClientIP()
+	if c.logInGuard != nil && !c.logInGuard.Allow(request.Username, clientIP) {
+		timer := time.NewTimer(c.logInGuard.RejectionDelay())
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Request.Context().Done():
+		}
+
+		appproblem.New(
+			http.StatusUnauthorized,
+			appproblem.UnauthenticatedCode,
+			appbruteforce.InvalidCredentialsError,
+		).Write(ctx)
+		return
+	}
+
 	// Log in the user
+	startedAt := time.Now()
 	response, err := c.client.LogIn(
 		grpcCtx, &request,
 	)
+
+	// Record the outcome of the attempt
+	if c.logInGuard != nil {
+		if appbruteforce.IsFailedAttempt(err) {
+			c.logInGuard.Failure(request.Username, clientIP, time.Since(startedAt))
+			appproblem.New(
+				http.StatusUnauthorized,
+				appproblem.UnauthenticatedCode,
+				appbruteforce.InvalidCredentialsError,
+			).Write(ctx)
+			return
+		}
+		if err == nil {
+			c.logInGuard.Success(request.Username, clientIP)
+		}
+	}
 	c.responseHandler.HandleResponse(ctx, http.StatusOK, response, err)
 }
 
@@ -142,13 +206,13 @@ func (c *Controller) logIn(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Success 200 {object} pbauth.LogOutResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/auth/log-out [post]
 func (c *Controller) logOut(ctx *gin.Context) {
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, nil)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, nil)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
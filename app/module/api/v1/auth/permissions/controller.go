@@ -2,13 +2,14 @@ package permissions
 
 import (
 	"github.com/gin-gonic/gin"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
+	apppagination "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/pagination"
+	appparams "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/params"
+	_ "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbauth "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/auth"
 	pbconfigrestpermissions "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/auth/permissions"
-	pbtypesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"net/http"
 )
@@ -24,7 +25,7 @@ type Controller struct {
 	route           *gin.RouterGroup
 	client          pbauth.AuthClient
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new permissions controller
@@ -32,7 +33,7 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pbauth.AuthClient,
 	routeHandler commonhandler.Handler,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the permissions controller
 	route := baseRoute.Group(pbconfigrestpermissions.Base.String())
@@ -83,15 +84,15 @@ func (c *Controller) Initialize() {
 // @Produce json
 // @Param request body pbauth.AddPermissionRequest true "Add Permission Request"
 // @Success 201 {object} pbauth.AddPermissionResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/auth/permissions/ [post]
 func (c *Controller) addPermission(ctx *gin.Context) {
 	var request pbauth.AddPermissionRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -108,14 +109,25 @@ func (c *Controller) addPermission(ctx *gin.Context) {
 // @Tags v1 auth permissions
 // @Accept json
 // @Produce json
+// @Param limit query int false "Maximum number of items of the page" minimum(1) maximum(100) default(20)
+// @Param cursor query string false "Cursor of the page, as returned in next_cursor"
+// @Param sort query string false "Field the items are sorted by, prefixed by - for descending order"
 // @Success 200 {object} pbauth.GetPermissionsResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Header 200 {string} Link "Links to the first and the next pages"
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/auth/permissions/ [get]
 func (c *Controller) getPermissions(ctx *gin.Context) {
+	// Parse the requested page
+	page, err := apppagination.Parse(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
+
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, nil)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, nil)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 
@@ -124,7 +136,7 @@ func (c *Controller) getPermissions(ctx *gin.Context) {
 
 	// Get all permissions
 	response, err := c.client.GetPermissions(grpcCtx, &emptypb.Empty{})
-	c.responseHandler.HandleResponse(ctx, http.StatusOK, response, err)
+	c.responseHandler.HandlePageResponse(ctx, http.StatusOK, response, page, false, err)
 }
 
 // revokePermission revokes a permission
@@ -135,15 +147,15 @@ func (c *Controller) getPermissions(ctx *gin.Context) {
 // @Produce json
 // @Param permission-id path string true "Permission ID"
 // @Success 200 {object} pbauth.RevokePermissionResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/auth/permissions/{permission-id} [delete]
 func (c *Controller) revokePermission(ctx *gin.Context) {
 	var request pbauth.RevokePermissionRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 
@@ -151,7 +163,11 @@ func (c *Controller) revokePermission(ctx *gin.Context) {
 	}
 
 	// Add the permission ID to the request
-	request.PermissionId = ctx.Param(pbtypesrest.PermissionId.String())
+	request.PermissionId, err = appparams.PermissionID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Revoke a permission
 	response, err := c.client.RevokePermission(grpcCtx, &request)
@@ -166,15 +182,15 @@ func (c *Controller) revokePermission(ctx *gin.Context) {
 // @Produce json
 // @Param permission-id path string true "Permission ID"
 // @Success 200 {object} pbauth.GetPermissionResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/auth/permissions/{permission-id} [get]
 func (c *Controller) getPermission(ctx *gin.Context) {
 	var request pbauth.GetPermissionRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 
@@ -182,7 +198,11 @@ func (c *Controller) getPermission(ctx *gin.Context) {
 	}
 
 	// Add the permission ID to the request
-	request.PermissionId = ctx.Param(pbtypesrest.PermissionId.String())
+	request.PermissionId, err = appparams.PermissionID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Get the permission
 	response, err := c.client.GetPermission(grpcCtx, &request)
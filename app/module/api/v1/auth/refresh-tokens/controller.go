@@ -2,13 +2,14 @@ package refresh_tokens
 
 import (
 	"github.com/gin-gonic/gin"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
+	apppagination "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/pagination"
+	appparams "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/params"
+	_ "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbauth "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/auth"
 	pbconfigrestrefreshtokens "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/auth/refresh-tokens"
-	pbtypesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"net/http"
 )
@@ -24,7 +25,7 @@ type Controller struct {
 	route           *gin.RouterGroup
 	client          pbauth.AuthClient
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new refresh tokens controller
@@ -32,7 +33,7 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pbauth.AuthClient,
 	routeHandler commonhandler.Handler,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the refresh tokens controller
 	route := baseRoute.Group(pbconfigrestrefreshtokens.Base.String())
@@ -95,21 +96,25 @@ func (c *Controller) Initialize() {
 // @Produce json
 // @Param jwt-id path string true "JWT ID"
 // @Success 200 {object} pbauth.IsRefreshTokenValidResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Router /api/v1/auth/refresh-tokens/valid/{jwt-id} [get]
 func (c *Controller) isRefreshTokenValid(ctx *gin.Context) {
 	var request pbauth.IsRefreshTokenValidRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Add the JWT Identifier to the request
-	request.JwtId = ctx.Param(pbtypesrest.JwtId.String())
+	request.JwtId, err = appparams.JwtID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Check if the refresh token is valid
 	response, err := c.client.IsRefreshTokenValid(grpcCtx, &request)
@@ -122,14 +127,25 @@ func (c *Controller) isRefreshTokenValid(ctx *gin.Context) {
 // @Tags v1 auth refresh-tokens
 // @Accept json
 // @Produce json
+// @Param limit query int false "Maximum number of items of the page" minimum(1) maximum(100) default(20)
+// @Param cursor query string false "Cursor of the page, as returned in next_cursor"
+// @Param sort query string false "Field the items are sorted by, prefixed by - for descending order"
 // @Success 200 {object} pbauth.GetRefreshTokensInformationResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Header 200 {string} Link "Links to the first and the next pages"
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/auth/refresh-tokens [get]
 func (c *Controller) getRefreshTokensInformation(ctx *gin.Context) {
+	// Parse the requested page
+	page, err := apppagination.Parse(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
+
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, nil)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, nil)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -140,7 +156,7 @@ func (c *Controller) getRefreshTokensInformation(ctx *gin.Context) {
 		grpcCtx,
 		&emptypb.Empty{},
 	)
-	c.responseHandler.HandleResponse(ctx, http.StatusOK, response, err)
+	c.responseHandler.HandlePageResponse(ctx, http.StatusOK, response, page, false, err)
 }
 
 // refreshToken refreshes a user's token
@@ -150,13 +166,13 @@ func (c *Controller) getRefreshTokensInformation(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Success 200 {object} pbauth.RefreshTokenResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/auth/refresh-tokens [post]
 func (c *Controller) refreshToken(ctx *gin.Context) {
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, nil)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, nil)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -174,13 +190,13 @@ func (c *Controller) refreshToken(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Success 200 {object} pbauth.RevokeRefreshTokensResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/auth/refresh-tokens [delete]
 func (c *Controller) revokeRefreshTokens(ctx *gin.Context) {
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, nil)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, nil)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -199,22 +215,26 @@ func (c *Controller) revokeRefreshTokens(ctx *gin.Context) {
 // @Produce json
 // @Param jwt-id path string true "JWT ID"
 // @Success 200 {object} pbauth.GetRefreshTokenInformationResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/auth/refresh-tokens/{jwt-id} [get]
 func (c *Controller) getRefreshTokenInformation(ctx *gin.Context) {
 	var request pbauth.GetRefreshTokenInformationRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Add the JWT Identifier to the request
-	request.JwtId = ctx.Param(pbtypesrest.JwtId.String())
+	request.JwtId, err = appparams.JwtID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Get the refresh token information
 	response, err := c.client.GetRefreshTokenInformation(
@@ -232,22 +252,26 @@ func (c *Controller) getRefreshTokenInformation(ctx *gin.Context) {
 // @Produce json
 // @Param jwt-id path string true "JWT ID"
 // @Success 200 {object} pbauth.RevokeRefreshTokenResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/auth/refresh-tokens/{jwt-id} [delete]
 func (c *Controller) revokeRefreshToken(ctx *gin.Context) {
 	var request pbauth.RevokeRefreshTokenRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Add the JWT ID to the request
-	request.JwtId = ctx.Param(pbtypesrest.JwtId.String())
+	request.JwtId, err = appparams.JwtID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Revoke the given refresh token
 	response, err := c.client.RevokeRefreshToken(grpcCtx, &request)
@@ -2,13 +2,13 @@ package role_permissions
 
 import (
 	"github.com/gin-gonic/gin"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
+	appparams "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/params"
+	_ "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbauth "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/auth"
 	pbconfigrestrolepermissions "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/auth/role-permissions"
-	pbtypesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
 	"net/http"
 )
 
@@ -23,7 +23,7 @@ type Controller struct {
 	route           *gin.RouterGroup
 	client          pbauth.AuthClient
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new role-permissions controller
@@ -31,7 +31,7 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pbauth.AuthClient,
 	routeHandler commonhandler.Handler,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the role-permissions controller
 	route := baseRoute.Group(pbconfigrestrolepermissions.Base.String())
@@ -64,22 +64,26 @@ func (c *Controller) Initialize() {
 // @Produce json
 // @Param role-id path string true "Role ID"
 // @Success 200 {object} pbauth.RevokeRolePermissionResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/auth/role-permissions/{role-id} [delete]
 func (c *Controller) revokeRolePermission(ctx *gin.Context) {
 	var request pbauth.RevokeRolePermissionRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Add the role ID to the request
-	request.RoleId = ctx.Param(pbtypesrest.RoleId.String())
+	request.RoleId, err = appparams.RoleID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Revoke a permission from the role
 	response, err := c.client.RevokeRolePermission(grpcCtx, &request)
@@ -2,13 +2,14 @@ package roles
 
 import (
 	"github.com/gin-gonic/gin"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
+	apppagination "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/pagination"
+	appparams "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/params"
+	_ "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbauth "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/auth"
 	pbconfigrestroles "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/auth/roles"
-	pbtypesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"net/http"
 )
@@ -24,7 +25,7 @@ type Controller struct {
 	route           *gin.RouterGroup
 	client          pbauth.AuthClient
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new roles controller
@@ -32,7 +33,7 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pbauth.AuthClient,
 	routeHandler commonhandler.Handler,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the roles controller
 	route := baseRoute.Group(pbconfigrestroles.Base.String())
@@ -74,15 +75,15 @@ func (c *Controller) Initialize() {
 // @Produce json
 // @Param request body pbauth.AddRoleRequest true "Add Role Request"
 // @Success 201 {object} pbauth.AddRoleResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/auth/roles/ [post]
 func (c *Controller) addRole(ctx *gin.Context) {
 	var request pbauth.AddRoleRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -99,14 +100,25 @@ func (c *Controller) addRole(ctx *gin.Context) {
 // @Tags v1 auth roles
 // @Accept json
 // @Produce json
+// @Param limit query int false "Maximum number of items of the page" minimum(1) maximum(100) default(20)
+// @Param cursor query string false "Cursor of the page, as returned in next_cursor"
+// @Param sort query string false "Field the items are sorted by, prefixed by - for descending order"
 // @Success 200 {object} pbauth.GetRolesResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Header 200 {string} Link "Links to the first and the next pages"
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/auth/roles/ [get]
 func (c *Controller) getRoles(ctx *gin.Context) {
+	// Parse the requested page
+	page, err := apppagination.Parse(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
+
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, nil)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, nil)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -114,7 +126,7 @@ func (c *Controller) getRoles(ctx *gin.Context) {
 
 	// Get all roles
 	response, err := c.client.GetRoles(grpcCtx, &emptypb.Empty{})
-	c.responseHandler.HandleResponse(ctx, http.StatusOK, response, err)
+	c.responseHandler.HandlePageResponse(ctx, http.StatusOK, response, page, false, err)
 }
 
 // addRolePermission adds a permission to a role
@@ -126,22 +138,26 @@ func (c *Controller) getRoles(ctx *gin.Context) {
 // @Param role-id path string true "Role ID"
 // @Param request body pbauth.AddRolePermissionRequest true "Add Role Permission Request"
 // @Success 201 {object} pbauth.AddRolePermissionResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/auth/roles/{role-id} [post]
 func (c *Controller) addRolePermission(ctx *gin.Context) {
 	var request pbauth.AddRolePermissionRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Add the role ID to the request
-	request.RoleId = ctx.Param(pbtypesrest.RoleId.String())
+	request.RoleId, err = appparams.RoleID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Add a permission to the role
 	response, err := c.client.AddRolePermission(grpcCtx, &request)
@@ -156,22 +172,26 @@ func (c *Controller) addRolePermission(ctx *gin.Context) {
 // @Produce json
 // @Param role-id path string true "Role ID"
 // @Success 200 {object} pbauth.GetRolePermissionsResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/auth/roles/{role-id} [get]
 func (c *Controller) getRolePermissions(ctx *gin.Context) {
 	var request pbauth.GetRolePermissionsRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Add the role ID to the request
-	request.RoleId = ctx.Param(pbtypesrest.RoleId.String())
+	request.RoleId, err = appparams.RoleID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Get all permissions for the role
 	response, err := c.client.GetRolePermissions(grpcCtx, &request)
@@ -186,22 +206,26 @@ func (c *Controller) getRolePermissions(ctx *gin.Context) {
 // @Produce json
 // @Param role-id path string true "Role ID"
 // @Success 200 {object} pbauth.RevokeRoleResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/auth/roles/{role-id} [delete]
 func (c *Controller) revokeRole(ctx *gin.Context) {
 	var request pbauth.RevokeRoleRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Add the role ID to the request
-	request.RoleId = ctx.Param(pbtypesrest.RoleId.String())
+	request.RoleId, err = appparams.RoleID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Revoke a role
 	response, err := c.client.RevokeRole(grpcCtx, &request)
@@ -2,13 +2,14 @@ package user_roles
 
 import (
 	"github.com/gin-gonic/gin"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
+	appmapper "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/mapper"
+	appparams "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/params"
+	_ "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbauth "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/auth"
 	pbconfigrestuserroles "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/auth/user-roles"
-	pbtypesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
 	"net/http"
 )
 
@@ -23,7 +24,7 @@ type Controller struct {
 	route           *gin.RouterGroup
 	client          pbauth.AuthClient
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new user roles controller
@@ -31,7 +32,7 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pbauth.AuthClient,
 	routeHandler commonhandler.Handler,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the user roles controller
 	route := baseRoute.Group(pbconfigrestuserroles.Base.String())
@@ -48,10 +49,10 @@ func NewController(
 // Initialize initializes the routes for the controller
 func (c *Controller) Initialize() {
 	// Initialize the routes
-	c.route.POST(c.routeHandler.CreateAuthenticatedEndpoint(pbconfigrestuserroles.AddUserRoleMapper, c.addUserRole))
+	c.route.POST(c.routeHandler.CreateAuthenticatedEndpoint(appmapper.AddUserRoleMapper, c.addUserRole))
 	c.route.DELETE(
 		c.routeHandler.CreateAuthenticatedEndpoint(
-			pbconfigrestuserroles.RevokeUserRoleMapper,
+			appmapper.RevokeUserRoleMapper,
 			c.revokeUserRole,
 		),
 	)
@@ -67,22 +68,26 @@ func (c *Controller) Initialize() {
 // @Param user-id path string true "User ID"
 // @Param request body pbauth.AddUserRoleRequest true "Add User Role Request"
 // @Success 201 {object} pbauth.AddUserRoleResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/auth/user-roles/{user-id} [post]
 func (c *Controller) addUserRole(ctx *gin.Context) {
 	var request pbauth.AddUserRoleRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Add the user ID to the request
-	request.UserId = ctx.Param(pbtypesrest.UserId.String())
+	request.UserId, err = appparams.UserID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Add a role to a user
 	response, err := c.client.AddUserRole(grpcCtx, &request)
@@ -97,22 +102,26 @@ func (c *Controller) addUserRole(ctx *gin.Context) {
 // @Produce json
 // @Param user-id path string true "User ID"
 // @Success 200 {object} pbauth.RevokeUserRoleResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/auth/user-roles/{user-id} [delete]
 func (c *Controller) revokeUserRole(ctx *gin.Context) {
 	var request pbauth.RevokeUserRoleRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Add the user ID to the request
-	request.UserId = ctx.Param(pbtypesrest.UserId.String())
+	request.UserId, err = appparams.UserID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Revoke a role from the user
 	response, err := c.client.RevokeUserRole(grpcCtx, &request)
@@ -127,22 +136,26 @@ func (c *Controller) revokeUserRole(ctx *gin.Context) {
 // @Produce json
 // @Param user-id path string true "User ID"
 // @Success 200 {object} pbauth.GetUserRolesResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/auth/user-roles/{user-id} [get]
 func (c *Controller) getUserRoles(ctx *gin.Context) {
 	var request pbauth.GetUserRolesRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Add the user ID to the request
-	request.UserId = ctx.Param(pbtypesrest.UserId.String())
+	request.UserId, err = appparams.UserID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Get all user's roles
 	response, err := c.client.GetUserRoles(grpcCtx, &request)
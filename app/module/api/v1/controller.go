@@ -2,14 +2,15 @@ package v1
 
 import (
 	"github.com/gin-gonic/gin"
+	appbruteforce "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/bruteforce"
 	moduleauth "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/auth"
 	moduleorders "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/orders"
 	modulepayments "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/payments"
 	moduleshops "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/shops"
 	moduleusers "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/users"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	authmiddleware "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/middleware/auth"
 	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbauth "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/auth"
 	pborder "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/order"
 	pbpayment "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/payment"
@@ -28,7 +29,7 @@ import (
 type Controller struct {
 	route              *gin.RouterGroup
 	authentication     authmiddleware.Authentication
-	responseHandler    commonclientresponse.Handler
+	responseHandler    *appresponse.Handler
 	usersController    *moduleusers.Controller
 	authController     *moduleauth.Controller
 	paymentsController *modulepayments.Controller
@@ -40,7 +41,7 @@ type Controller struct {
 func NewController(
 	baseRoute *gin.RouterGroup,
 	authentication authmiddleware.Authentication,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the API version 1 controller
 	route := baseRoute.Group(pbconfigrestv1.Base.String())
@@ -56,15 +57,27 @@ func NewController(
 // Initialize initializes the routes for the controller
 func (c *Controller) Initialize() {}
 
-// InitializeAuth initializes the routes for the API version 1 auth controller
-func (c *Controller) InitializeAuth(authClient pbauth.AuthClient) *moduleauth.Controller {
+// InitializeAuth initializes the routes for the API version 1 auth controller, whose log-in attempts are checked by the
+// log-in guard, if any, whose lockout state is exposed to the users with the given admin role
+func (c *Controller) InitializeAuth(
+	authClient pbauth.AuthClient,
+	logInGuard *appbruteforce.Guard,
+	adminRole string,
+) *moduleauth.Controller {
 	// Check if the API version 1 auth controller has already been initialized
 	if c.authController != nil {
 		return c.authController
 	}
 
 	// Initialize the API version 1 auth controller
-	authController := moduleauth.NewController(c.route, authClient, c.authentication, c.responseHandler)
+	authController := moduleauth.NewController(
+		c.route,
+		authClient,
+		c.authentication,
+		c.responseHandler,
+		logInGuard,
+		adminRole,
+	)
 	authController.Initialize()
 
 	// Store the API version 1 auth controller
@@ -1,17 +1,18 @@
 package carts
 
 import (
-	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
+	_ "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
 
 	"github.com/gin-gonic/gin"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
 	moduleorderscurrent "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/orders/carts/current"
+	apppagination "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/pagination"
+	appparams "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/params"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	apptypes "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/types"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pborder "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/order"
 	pbconfigrestcarts "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/orders/carts"
-	typesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
 	"net/http"
 )
 
@@ -26,7 +27,7 @@ type Controller struct {
 	route           *gin.RouterGroup
 	client          pborder.OrderClient
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new carts controller
@@ -34,7 +35,7 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pborder.OrderClient,
 	routeHandler commonhandler.Handler,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the carts controller
 	route := baseRoute.Group(pbconfigrestcarts.Base.String())
@@ -80,22 +81,26 @@ func (c *Controller) initializeChildren() {
 // @Produce json
 // @Param cartId path string true "Cart ID"
 // @Success 200 {object} pborder.GetCartResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/orders/carts/{cartId} [get]
 func (c *Controller) getCart(ctx *gin.Context) {
 	var request pborder.GetCartRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Get the cart ID from the path
-	request.CartId = ctx.Param(typesrest.CartId.String())
+	request.CartId, err = appparams.CartID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Get the cart by ID
 	response, err := c.client.GetCart(grpcCtx, &request)
@@ -108,24 +113,38 @@ func (c *Controller) getCart(ctx *gin.Context) {
 // @Tags v1 orders carts
 // @Accept json
 // @Produce json
+// @Param limit query int false "Maximum number of items of the page" minimum(1) maximum(100) default(20)
+// @Param cursor query string false "Cursor of the page, as returned in next_cursor"
+// @Param sort query string false "Field the items are sorted by, prefixed by - for descending order"
 // @Success 200 {object} pborder.GetCartsResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Header 200 {string} Link "Links to the first and the next pages"
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/orders/carts [get]
 func (c *Controller) getCarts(ctx *gin.Context) {
+	// Parse the requested page
+	page, err := apppagination.Parse(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
+
 	var request pborder.GetCartsRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
+	// Map the page onto the request, if the RPC supports paging
+	paged := page.MapRequest(&request)
+
 	// Get all carts
 	response, err := c.client.GetCarts(grpcCtx, &request)
-	c.responseHandler.HandleResponse(ctx, http.StatusOK, response, err)
+	c.responseHandler.HandlePageResponse(ctx, http.StatusOK, response, page, paged, err)
 }
 
 // getCartTotal gets the total of a cart by ID
@@ -136,22 +155,26 @@ func (c *Controller) getCarts(ctx *gin.Context) {
 // @Produce json
 // @Param cartId path string true "Cart ID"
 // @Success 200 {object} pborder.GetCartTotalResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/orders/carts/{cartId}/total [get]
 func (c *Controller) getCartTotal(ctx *gin.Context) {
 	var request pborder.GetCartTotalRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Get the cart ID from the path
-	request.CartId = ctx.Param(typesrest.CartId.String())
+	request.CartId, err = appparams.CartID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Get the total of the cart by ID
 	response, err := c.client.GetCartTotal(grpcCtx, &request)
@@ -1,15 +1,16 @@
 package carts
 
 import (
-	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
+	_ "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
 
 	"github.com/gin-gonic/gin"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
+	appmapper "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/mapper"
+	appparams "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/params"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pborder "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/order"
 	pbconfigrestcurrentcart "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/orders/carts/current"
-	typesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"net/http"
 )
@@ -25,7 +26,7 @@ type Controller struct {
 	route           *gin.RouterGroup
 	client          pborder.OrderClient
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new current cart controller
@@ -33,7 +34,7 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pborder.OrderClient,
 	routeHandler commonhandler.Handler,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the current cart controller
 	route := baseRoute.Group(pbconfigrestcurrentcart.Base.String())
@@ -59,7 +60,7 @@ func (c *Controller) Initialize() {
 	)
 	c.route.DELETE(
 		c.routeHandler.CreateAuthenticatedEndpoint(
-			pbconfigrestcurrentcart.RemoveProductFromCartMapper,
+			appmapper.RemoveProductFromCartMapper,
 			c.removeProductFromCart,
 		),
 	)
@@ -73,13 +74,13 @@ func (c *Controller) Initialize() {
 // @Accept json
 // @Produce json
 // @Success 200 {object} pborder.GetCurrentCartResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/orders/carts/current [get]
 func (c *Controller) getCurrentCart(ctx *gin.Context) {
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, nil)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, nil)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -98,15 +99,15 @@ func (c *Controller) getCurrentCart(ctx *gin.Context) {
 // @Produce json
 // @Param request body pborder.AddProductToCartRequest true "Add Product To Cart Request"
 // @Success 200 {object} pborder.AddProductToCartResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/orders/carts/current [post]
 func (c *Controller) addProductToCart(ctx *gin.Context) {
 	var request pborder.AddProductToCartRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -123,25 +124,28 @@ func (c *Controller) addProductToCart(ctx *gin.Context) {
 // @Tags v1 orders carts current-cart
 // @Accept json
 // @Produce json
-// @Param cartId path string true "Cart ID"
-// @Param productId path string true "Product ID"
+// @Param branchProductId path string true "Branch product ID"
 // @Success 200 {object} pborder.RemoveProductFromCartResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/orders/carts/current/{branchProductId} [delete]
 func (c *Controller) removeProductFromCart(ctx *gin.Context) {
 	var request pborder.RemoveProductFromCartRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
-	// Get the product ID from the path
-	request.BranchProductId = ctx.Param(typesrest.ProductId.String())
+	// Get the branch product ID from the path
+	request.BranchProductId, err = appparams.BranchProductID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Remove product from the current cart
 	response, err := c.client.RemoveProductFromCart(grpcCtx, &request)
@@ -154,14 +158,17 @@ func (c *Controller) removeProductFromCart(ctx *gin.Context) {
 // @Tags v1 orders carts current-cart
 // @Accept json
 // @Produce json
+// @Param Idempotency-Key header string false "Key that makes the request safe to repeat, whose response is replayed"
 // @Success 200 {object} pborder.PlaceOrderResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 409 {object} _.Problem
+// @Failure 422 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/orders/carts/current/checkout [post]
 func (c *Controller) placeOrder(ctx *gin.Context) {
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, nil)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, nil)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -2,17 +2,18 @@ package orders
 
 import (
 	"github.com/gin-gonic/gin"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
 	moduleorderscarts "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/orders/carts"
+	apppagination "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/pagination"
+	appparams "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/params"
+	_ "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	apptypes "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/types"
 	authmiddleware "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/middleware/auth"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pborder "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/order"
 	pbconfiggrpcorder "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/grpc/order"
 	pbconfigrestorders "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/orders"
-	typesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"net/http"
 )
@@ -29,7 +30,7 @@ type Controller struct {
 	client          pborder.OrderClient
 	authentication  authmiddleware.Authentication
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new orders controller
@@ -37,13 +38,16 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pborder.OrderClient,
 	authentication authmiddleware.Authentication,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the orders controller
 	route := baseRoute.Group(pbconfigrestorders.Base.String())
 
-	// Create the route handler
-	routeHandler := commonhandler.NewDefaultHandler(authentication, &pbconfiggrpcorder.Interceptions)
+	// Create the route handler, which replays the responses of the repeated non-idempotent requests once they are
+	// authenticated
+	routeHandler := responseHandler.RouteHandler(
+		commonhandler.NewDefaultHandler(authentication, &pbconfiggrpcorder.Interceptions),
+	)
 
 	// Create a new orders controller
 	return &Controller{
@@ -86,22 +90,26 @@ func (c *Controller) initializeChildren() {
 // @Produce json
 // @Param orderId path string true "Order ID"
 // @Success 200 {object} pborder.GetOrderResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/orders/{orderId} [get]
 func (c *Controller) getOrder(ctx *gin.Context) {
 	var request pborder.GetOrderRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Get the order ID from the path
-	request.OrderId = ctx.Param(typesrest.OrderId.String())
+	request.OrderId, err = appparams.OrderID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Get the order by ID
 	response, err := c.client.GetOrder(grpcCtx, &request)
@@ -114,14 +122,25 @@ func (c *Controller) getOrder(ctx *gin.Context) {
 // @Tags v1 orders
 // @Accept json
 // @Produce json
+// @Param limit query int false "Maximum number of items of the page" minimum(1) maximum(100) default(20)
+// @Param cursor query string false "Cursor of the page, as returned in next_cursor"
+// @Param sort query string false "Field the items are sorted by, prefixed by - for descending order"
 // @Success 200 {object} pborder.GetOrdersResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Header 200 {string} Link "Links to the first and the next pages"
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/orders [get]
 func (c *Controller) getOrders(ctx *gin.Context) {
+	// Parse the requested page
+	page, err := apppagination.Parse(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
+
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, nil)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, nil)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -129,5 +148,5 @@ func (c *Controller) getOrders(ctx *gin.Context) {
 
 	// Get all orders
 	response, err := c.client.GetOrders(grpcCtx, &emptypb.Empty{})
-	c.responseHandler.HandleResponse(ctx, http.StatusOK, response, err)
+	c.responseHandler.HandlePageResponse(ctx, http.StatusOK, response, page, false, err)
 }
@@ -2,10 +2,12 @@ package accounts
 
 import (
 	"github.com/gin-gonic/gin"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
+	apppagination "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/pagination"
+	appparams "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/params"
+	_ "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbpayment "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/payment"
 	pbconfigrestaccounts "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/payments/accounts"
 	"net/http"
@@ -22,7 +24,7 @@ type Controller struct {
 	route           *gin.RouterGroup
 	client          pbpayment.PaymentClient
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new accounts controller
@@ -30,7 +32,7 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pbpayment.PaymentClient,
 	routeHandler commonhandler.Handler,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the accounts controller
 	route := baseRoute.Group(pbconfigrestaccounts.Base.String())
@@ -94,15 +96,15 @@ func (c *Controller) Initialize() {
 // @Produce json
 // @Param request body pbpayment.AddPaymentAccountRequest true "Add Payment Account Request"
 // @Success 201 {object} pbpayment.AddPaymentAccountResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/payments/accounts [post]
 func (c *Controller) addPaymentAccount(ctx *gin.Context) {
 	var request pbpayment.AddPaymentAccountRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -119,24 +121,38 @@ func (c *Controller) addPaymentAccount(ctx *gin.Context) {
 // @Tags v1 payments accounts
 // @Accept json
 // @Produce json
+// @Param limit query int false "Maximum number of items of the page" minimum(1) maximum(100) default(20)
+// @Param cursor query string false "Cursor of the page, as returned in next_cursor"
+// @Param sort query string false "Field the items are sorted by, prefixed by - for descending order"
 // @Success 200 {object} pbpayment.GetPaymentAccountsResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Header 200 {string} Link "Links to the first and the next pages"
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/payments/accounts [get]
 func (c *Controller) getPaymentAccounts(ctx *gin.Context) {
+	// Parse the requested page
+	page, err := apppagination.Parse(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
+
 	var request pbpayment.GetPaymentAccountsRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
+	// Map the page onto the request, if the RPC supports paging
+	paged := page.MapRequest(&request)
+
 	// Get payment accounts
 	response, err := c.client.GetPaymentAccounts(grpcCtx, &request)
-	c.responseHandler.HandleResponse(ctx, http.StatusOK, response, err)
+	c.responseHandler.HandlePageResponse(ctx, http.StatusOK, response, page, paged, err)
 }
 
 // getActivePaymentAccounts gets active payment accounts
@@ -146,15 +162,15 @@ func (c *Controller) getPaymentAccounts(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Success 200 {object} pbpayment.GetActivePaymentAccountsResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/payments/accounts/active [get]
 func (c *Controller) getActivePaymentAccounts(ctx *gin.Context) {
 	var request pbpayment.GetActivePaymentAccountsRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -171,22 +187,29 @@ func (c *Controller) getActivePaymentAccounts(ctx *gin.Context) {
 // @Tags v1 payments accounts
 // @Accept json
 // @Produce json
-// @Param request body pbpayment.ActivatePaymentAccountRequest true "Activate Payment Account Request"
+// @Param accountId path string true "Payment Account ID"
 // @Success 200 {object} pbpayment.ActivatePaymentAccountResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
-// @Router /api/v1/payments/accounts/activate [put]
+// @Router /api/v1/payments/accounts/activate/{accountId} [put]
 func (c *Controller) activatePaymentAccount(ctx *gin.Context) {
 	var request pbpayment.ActivatePaymentAccountRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
+	// Get the payment account ID from the path
+	request.PaymentAccountId, err = appparams.PaymentAccountID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
+
 	// Activate the payment account
 	response, err := c.client.ActivatePaymentAccount(grpcCtx, &request)
 	c.responseHandler.HandleResponse(ctx, http.StatusOK, response, err)
@@ -199,15 +222,15 @@ func (c *Controller) activatePaymentAccount(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Success 200 {object} pbpayment.GetSuspendedPaymentAccountsResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/payments/accounts/suspended [get]
 func (c *Controller) getSuspendedPaymentAccounts(ctx *gin.Context) {
 	var request pbpayment.GetSuspendedPaymentAccountsRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -224,22 +247,29 @@ func (c *Controller) getSuspendedPaymentAccounts(ctx *gin.Context) {
 // @Tags v1 payments accounts
 // @Accept json
 // @Produce json
-// @Param request body pbpayment.SuspendPaymentAccountRequest true "Suspend Payment Account Request"
+// @Param accountId path string true "Payment Account ID"
 // @Success 200 {object} pbpayment.SuspendPaymentAccountResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
-// @Router /api/v1/payments/accounts/suspend [put]
+// @Router /api/v1/payments/accounts/suspend/{accountId} [put]
 func (c *Controller) suspendPaymentAccount(ctx *gin.Context) {
 	var request pbpayment.SuspendPaymentAccountRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
+	// Get the payment account ID from the path
+	request.PaymentAccountId, err = appparams.PaymentAccountID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
+
 	// Suspend the payment account
 	response, err := c.client.SuspendPaymentAccount(grpcCtx, &request)
 	c.responseHandler.HandleResponse(ctx, http.StatusOK, response, err)
@@ -253,15 +283,15 @@ func (c *Controller) suspendPaymentAccount(ctx *gin.Context) {
 // @Produce json
 // @Param request body pbpayment.VerifyPaymentRequest true "Verify Payment Request"
 // @Success 200 {object} pbpayment.VerifyPaymentResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/payments/accounts/verify [post]
 func (c *Controller) verifyPayment(ctx *gin.Context) {
 	var request pbpayment.VerifyPaymentRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -2,13 +2,13 @@ package branch_rents
 
 import (
 	"github.com/gin-gonic/gin"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
+	appparams "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/params"
+	_ "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbpayment "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/payment"
 	pbconfigrestbranchrents "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/payments/branch-rents"
-	typesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
 	"net/http"
 )
 
@@ -23,7 +23,7 @@ type Controller struct {
 	route           *gin.RouterGroup
 	client          pbpayment.PaymentClient
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new branch rents controller
@@ -31,7 +31,7 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pbpayment.PaymentClient,
 	routeHandler commonhandler.Handler,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the branch rents controller
 	route := baseRoute.Group(pbconfigrestbranchrents.Base.String())
@@ -80,22 +80,30 @@ func (c *Controller) Initialize() {
 // @Tags v1 payments branch-rents
 // @Accept json
 // @Produce json
+// @Param branchRentId path string true "Branch Rent ID"
 // @Param request body pbpayment.AddBranchRentPaymentRequest true "Add Branch Rent Payment Request"
 // @Success 201 {object} pbpayment.AddBranchRentPaymentResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
-// @Router /api/v1/payments/branch-rents [post]
+// @Router /api/v1/payments/branch-rents/{branchRentId} [post]
 func (c *Controller) addBranchRentPayment(ctx *gin.Context) {
 	var request pbpayment.AddBranchRentPaymentRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
+	// Get the branch rent ID from the path
+	request.BranchRentId, err = appparams.BranchRentID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
+
 	// Add a new branch rent payment
 	response, err := c.client.AddBranchRentPayment(grpcCtx, &request)
 	c.responseHandler.HandleResponse(ctx, http.StatusCreated, response, err)
@@ -108,15 +116,15 @@ func (c *Controller) addBranchRentPayment(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Success 200 {object} pbpayment.GetBranchRentsPaymentsResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/payments/branch-rents [get]
 func (c *Controller) getBranchRentsPayments(ctx *gin.Context) {
 	var request pbpayment.GetBranchRentsPaymentsRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -135,22 +143,26 @@ func (c *Controller) getBranchRentsPayments(ctx *gin.Context) {
 // @Produce json
 // @Param branchRentId path string true "Branch Rent ID"
 // @Success 200 {object} pbpayment.GetBranchRentPaymentsResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/payments/branch-rents/{branchRentId} [get]
 func (c *Controller) getBranchRentPayments(ctx *gin.Context) {
 	var request pbpayment.GetBranchRentPaymentsRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Get the branch rent ID from the path
-	request.BranchRentId = ctx.Param(typesrest.BranchRentId.String())
+	request.BranchRentId, err = appparams.BranchRentID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Get branch rent payments by branch rent ID
 	response, err := c.client.GetBranchRentPayments(grpcCtx, &request)
@@ -163,22 +175,33 @@ func (c *Controller) getBranchRentPayments(ctx *gin.Context) {
 // @Tags v1 payments branch-rents
 // @Accept json
 // @Produce json
+// @Param branchRentId path string true "Branch Rent ID"
 // @Param request body pbpayment.PayForBranchRentRequest true "Pay For Branch Rent Request"
+// @Param Idempotency-Key header string false "Key that makes the request safe to repeat, whose response is replayed"
 // @Success 200 {object} pbpayment.PayForBranchRentResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 409 {object} _.Problem
+// @Failure 422 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
-// @Router /api/v1/payments/branch-rents/pay [post]
+// @Router /api/v1/payments/branch-rents/pay/{branchRentId} [post]
 func (c *Controller) payForBranchRent(ctx *gin.Context) {
 	var request pbpayment.PayForBranchRentRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
+	// Get the branch rent ID from the path
+	request.BranchRentId, err = appparams.BranchRentID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
+
 	// Process payment for the branch rent
 	response, err := c.client.PayForBranchRent(grpcCtx, &request)
 	c.responseHandler.HandleResponse(ctx, http.StatusOK, response, err)
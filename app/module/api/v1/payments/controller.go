@@ -5,10 +5,10 @@ import (
 	modulepaymentsaccounts "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/payments/accounts"
 	modulepaymentsbranchrents "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/payments/branch-rents"
 	modulepaymentsorders "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/payments/orders"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	apptypes "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/types"
 	authmiddleware "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/middleware/auth"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbpayment "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/payment"
 	pbconfiggrpcpayment "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/grpc/payment"
 	pbconfigrestpayments "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/payments"
@@ -26,7 +26,7 @@ type Controller struct {
 	client          pbpayment.PaymentClient
 	authentication  authmiddleware.Authentication
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new payments controller
@@ -34,13 +34,16 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pbpayment.PaymentClient,
 	authentication authmiddleware.Authentication,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the payments controller
 	route := baseRoute.Group(pbconfigrestpayments.Base.String())
 
-	// Create the route handler
-	routeHandler := commonhandler.NewDefaultHandler(authentication, &pbconfiggrpcpayment.Interceptions)
+	// Create the route handler, which replays the responses of the repeated non-idempotent requests once they are
+	// authenticated
+	routeHandler := responseHandler.RouteHandler(
+		commonhandler.NewDefaultHandler(authentication, &pbconfiggrpcpayment.Interceptions),
+	)
 
 	// Create a new payments controller
 	return &Controller{
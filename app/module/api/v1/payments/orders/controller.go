@@ -2,10 +2,11 @@ package orders
 
 import (
 	"github.com/gin-gonic/gin"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
+	appparams "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/params"
+	_ "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbpayment "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/payment"
 	pbconfigrestorders "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/payments/orders"
 	"net/http"
@@ -22,7 +23,7 @@ type Controller struct {
 	route           *gin.RouterGroup
 	client          pbpayment.PaymentClient
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new orders controller
@@ -30,7 +31,7 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pbpayment.PaymentClient,
 	routeHandler commonhandler.Handler,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the orders controller
 	route := baseRoute.Group(pbconfigrestorders.Base.String())
@@ -68,22 +69,30 @@ func (c *Controller) Initialize() {
 // @Tags v1 payments orders
 // @Accept json
 // @Produce json
+// @Param orderId path string true "Order ID"
 // @Param request body pbpayment.AddOrderPaymentRequest true "Add Order Payment Request"
 // @Success 201 {object} pbpayment.AddOrderPaymentResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
-// @Router /api/v1/payments/orders [post]
+// @Router /api/v1/payments/orders/{orderId} [post]
 func (c *Controller) addOrderPayment(ctx *gin.Context) {
 	var request pbpayment.AddOrderPaymentRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
+	// Get the order ID from the path
+	request.OrderId, err = appparams.OrderID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
+
 	// Add a new order payment
 	response, err := c.client.AddOrderPayment(grpcCtx, &request)
 	c.responseHandler.HandleResponse(ctx, http.StatusCreated, response, err)
@@ -95,21 +104,29 @@ func (c *Controller) addOrderPayment(ctx *gin.Context) {
 // @Tags v1 payments orders
 // @Accept json
 // @Produce json
+// @Param orderId path string true "Order ID"
 // @Success 200 {object} pbpayment.GetOrderPaymentsResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
-// @Router /api/v1/payments/orders [get]
+// @Router /api/v1/payments/orders/{orderId} [get]
 func (c *Controller) getOrderPayments(ctx *gin.Context) {
 	var request pbpayment.GetOrderPaymentsRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
+	// Get the order ID from the path
+	request.OrderId, err = appparams.OrderID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
+
 	// Get order payments
 	response, err := c.client.GetOrderPayments(grpcCtx, &request)
 	c.responseHandler.HandleResponse(ctx, http.StatusOK, response, err)
@@ -121,22 +138,33 @@ func (c *Controller) getOrderPayments(ctx *gin.Context) {
 // @Tags v1 payments orders
 // @Accept json
 // @Produce json
+// @Param orderId path string true "Order ID"
 // @Param request body pbpayment.PayForOrderRequest true "Pay For Order Request"
+// @Param Idempotency-Key header string false "Key that makes the request safe to repeat, whose response is replayed"
 // @Success 200 {object} pbpayment.PayForOrderResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 409 {object} _.Problem
+// @Failure 422 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
-// @Router /api/v1/payments/orders/pay [post]
+// @Router /api/v1/payments/orders/pay/{orderId} [post]
 func (c *Controller) payForOrder(ctx *gin.Context) {
 	var request pbpayment.PayForOrderRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
+	// Get the order ID from the path
+	request.OrderId, err = appparams.OrderID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
+
 	// Process payment for the order
 	response, err := c.client.PayForOrder(grpcCtx, &request)
 	c.responseHandler.HandleResponse(ctx, http.StatusOK, response, err)
@@ -2,15 +2,15 @@ package branches
 
 import (
 	"github.com/gin-gonic/gin"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
 	moduleshopsproducts "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/shops/businesses/branches/products"
+	appparams "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/params"
+	_ "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	apptypes "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/types"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbshop "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/shop"
 	pbconfigrestbranches "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/shops/businesses/branches"
-	typesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
 	"net/http"
 )
 
@@ -25,7 +25,7 @@ type Controller struct {
 	route           *gin.RouterGroup
 	client          pbshop.ShopClient
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new branches controller
@@ -33,7 +33,7 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pbshop.ShopClient,
 	routeHandler commonhandler.Handler,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the branches controller
 	route := baseRoute.Group(pbconfigrestbranches.Base.String())
@@ -98,15 +98,15 @@ func (c *Controller) initializeChildren() {
 // @Produce json
 // @Param request body pbshop.AddBranchRequest true "Add Branch Request"
 // @Success 201 {object} pbshop.AddBranchResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/shops/businesses/branches [post]
 func (c *Controller) addBranch(ctx *gin.Context) {
 	var request pbshop.AddBranchRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -125,21 +125,25 @@ func (c *Controller) addBranch(ctx *gin.Context) {
 // @Produce json
 // @Param branchId path string true "Branch ID"
 // @Success 200 {object} pbshop.GetBranchResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Router /api/v1/shops/businesses/branches/{branchId} [get]
 func (c *Controller) getBranch(ctx *gin.Context) {
 	var request pbshop.GetBranchRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Get the branch ID from the path
-	request.BranchId = ctx.Param(typesrest.BranchId.String())
+	request.BranchId, err = appparams.BranchID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Get the branch by ID
 	response, err := c.client.GetBranch(grpcCtx, &request)
@@ -154,21 +158,25 @@ func (c *Controller) getBranch(ctx *gin.Context) {
 // @Produce json
 // @Param businessId path string true "Business ID"
 // @Success 200 {object} pbshop.GetBusinessBranchesResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Router /api/v1/shops/businesses/branches/business-id/{businessId} [get]
 func (c *Controller) getBusinessBranches(ctx *gin.Context) {
 	var request pbshop.GetBusinessBranchesRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Get the business ID from the path
-	request.BusinessId = ctx.Param(typesrest.BusinessId.String())
+	request.BusinessId, err = appparams.BusinessID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Get all branches for the business
 	response, err := c.client.GetBusinessBranches(grpcCtx, &request)
@@ -181,22 +189,30 @@ func (c *Controller) getBusinessBranches(ctx *gin.Context) {
 // @Tags v1 shops businesses branches
 // @Accept json
 // @Produce json
+// @Param branchId path string true "Branch ID"
 // @Param request body pbshop.UpdateBranchRequest true "Update Branch Request"
 // @Success 200 {object} pbshop.UpdateBranchResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
-// @Router /api/v1/shops/businesses/branches [put]
+// @Router /api/v1/shops/businesses/branches/{branchId} [put]
 func (c *Controller) updateBranch(ctx *gin.Context) {
 	var request pbshop.UpdateBranchRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
+	// Get the branch ID from the path
+	request.BranchId, err = appparams.BranchID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
+
 	// Update the branch
 	response, err := c.client.UpdateBranch(grpcCtx, &request)
 	c.responseHandler.HandleResponse(ctx, http.StatusOK, response, err)
@@ -210,22 +226,26 @@ func (c *Controller) updateBranch(ctx *gin.Context) {
 // @Produce json
 // @Param branchId path string true "Branch ID"
 // @Success 200 {object} pbshop.CloseTemporarilyBranchResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/shops/businesses/branches/close-temporarily/{branchId} [post]
 func (c *Controller) closeTemporarilyBranch(ctx *gin.Context) {
 	var request pbshop.CloseTemporarilyBranchRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Get the branch ID from the path
-	request.BranchId = ctx.Param(typesrest.BranchId.String())
+	request.BranchId, err = appparams.BranchID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Suspend the branch
 	response, err := c.client.CloseTemporarilyBranch(grpcCtx, &request)
@@ -240,22 +260,26 @@ func (c *Controller) closeTemporarilyBranch(ctx *gin.Context) {
 // @Produce json
 // @Param branchId path string true "Branch ID"
 // @Success 200 {object} pbshop.OpenBranchResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/shops/businesses/branches/open/{branchId} [post]
 func (c *Controller) openBranch(ctx *gin.Context) {
 	var request pbshop.OpenBranchRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Get the branch ID from the path
-	request.BranchId = ctx.Param(typesrest.BranchId.String())
+	request.BranchId, err = appparams.BranchID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Open the branch
 	response, err := c.client.OpenBranch(grpcCtx, &request)
@@ -270,22 +294,26 @@ func (c *Controller) openBranch(ctx *gin.Context) {
 // @Produce json
 // @Param branchId path string true "Branch ID"
 // @Success 200 {object} pbshop.DeleteBranchResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/shops/businesses/branches/{branchId} [delete]
 func (c *Controller) deleteBranch(ctx *gin.Context) {
 	var request pbshop.DeleteBranchRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Get the branch ID from the path
-	request.BranchId = ctx.Param(typesrest.BranchId.String())
+	request.BranchId, err = appparams.BranchID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Delete the branch
 	response, err := c.client.DeleteBranch(grpcCtx, &request)
@@ -2,13 +2,16 @@ package products
 
 import (
 	"github.com/gin-gonic/gin"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
+	appmapper "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/mapper"
+	apppagination "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/pagination"
+	appparams "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/params"
+	_ "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
+	appsearch "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/search"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbshop "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/shop"
 	pbconfigrestproducts "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/shops/businesses/branches/products"
-	typesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
 	"net/http"
 )
 
@@ -23,7 +26,7 @@ type Controller struct {
 	route           *gin.RouterGroup
 	client          pbshop.ShopClient
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new products controller
@@ -31,7 +34,7 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pbshop.ShopClient,
 	routeHandler commonhandler.Handler,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the products controller
 	route := baseRoute.Group(pbconfigrestproducts.Base.String())
@@ -56,8 +59,8 @@ func (c *Controller) Initialize() {
 	)
 	c.route.GET(
 		c.routeHandler.CreateAuthenticatedEndpoint(
-			pbconfigrestproducts.GetBranchProductMapper,
-			c.getBranchProduct,
+			appmapper.GetBranchProductMapper,
+			c.responseHandler.Cached(c.getBranchProduct),
 		),
 	)
 	c.route.PUT(
@@ -80,22 +83,30 @@ func (c *Controller) Initialize() {
 // @Tags v1 shops businesses branches products
 // @Accept json
 // @Produce json
+// @Param branchId path string true "Branch ID"
 // @Param request body pbshop.AddBranchProductRequest true "Add Branch Product Request"
 // @Success 201 {object} pbshop.AddBranchProductResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
-// @Router /api/v1/shops/businesses/branches/products [post]
+// @Router /api/v1/shops/businesses/branches/products/{branchId} [post]
 func (c *Controller) addBranchProduct(ctx *gin.Context) {
 	var request pbshop.AddBranchProductRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
+	// Get the branch ID from the path
+	request.BranchId, err = appparams.BranchID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
+
 	// Add a new branch product
 	response, err := c.client.AddBranchProduct(grpcCtx, &request)
 	c.responseHandler.HandleResponse(ctx, http.StatusCreated, response, err)
@@ -107,23 +118,37 @@ func (c *Controller) addBranchProduct(ctx *gin.Context) {
 // @Tags v1 shops businesses branches products
 // @Accept json
 // @Produce json
+// @Param branchId path string true "Branch ID"
 // @Param productId path string true "Product ID"
+// @Param If-None-Match header string false "ETag of the copy of the response the client already has"
 // @Success 200 {object} pbshop.GetBranchProductResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
-// @Router /api/v1/shops/businesses/branches/products/{productId} [get]
+// @Header 200 {string} ETag "Validator of the response"
+// @Header 200 {string} Cache-Control "Caching directives of the response"
+// @Success 304 "Not Modified"
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
+// @Router /api/v1/shops/businesses/branches/products/{branchId}/{productId} [get]
 func (c *Controller) getBranchProduct(ctx *gin.Context) {
 	var request pbshop.GetBranchProductRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
-	// Get the product ID from the path
-	request.ProductId = ctx.Param(typesrest.ProductId.String())
+	// Get the branch and the product IDs from the path
+	request.BranchId, err = appparams.BranchID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
+	request.ProductId, err = appparams.ProductID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Get the branch product by ID
 	response, err := c.client.GetBranchProduct(grpcCtx, &request)
@@ -136,22 +161,30 @@ func (c *Controller) getBranchProduct(ctx *gin.Context) {
 // @Tags v1 shops businesses branches products
 // @Accept json
 // @Produce json
+// @Param branchId path string true "Branch ID"
 // @Param request body pbshop.UpdateBranchProductRequest true "Update Branch Product Request"
 // @Success 200 {object} pbshop.UpdateBranchProductResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
-// @Router /api/v1/shops/businesses/branches/products [put]
+// @Router /api/v1/shops/businesses/branches/products/{branchId} [put]
 func (c *Controller) updateBranchProduct(ctx *gin.Context) {
 	var request pbshop.UpdateBranchProductRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
+	// Get the branch ID from the path
+	request.BranchId, err = appparams.BranchID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
+
 	// Update the branch product
 	response, err := c.client.UpdateBranchProduct(grpcCtx, &request)
 	c.responseHandler.HandleResponse(ctx, http.StatusOK, response, err)
@@ -159,29 +192,50 @@ func (c *Controller) updateBranchProduct(ctx *gin.Context) {
 
 // searchBranchProducts searches for branch products
 // @Summary Search for branch products
-// @Description Search for branch products
+// @Description Search for branch products by text, product category, price range and stock
 // @Tags v1 shops businesses branches products
-// @Accept json
 // @Produce json
-// @Param request body pbshop.SearchBranchProductsRequest true "Search Branch Products Request"
+// @Param q query string false "Text the branch products are searched by" maxlength(200)
+// @Param category_id query string false "Product category ID"
+// @Param min_price query number false "Minimum price, included" minimum(0)
+// @Param max_price query number false "Maximum price, included" minimum(0)
+// @Param in_stock query bool false "Only return the products in stock"
+// @Param limit query int false "Maximum number of items of the page" minimum(1) maximum(100) default(20)
+// @Param cursor query string false "Cursor of the page, as returned in next_cursor"
+// @Param sort query string false "Field the items are sorted by, prefixed by - for descending order"
 // @Success 200 {object} pbshop.SearchBranchProductsResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Header 200 {string} Link "Links to the first and the next pages"
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
-// @Router /api/v1/shops/businesses/branches/products/search [post]
+// @Router /api/v1/shops/businesses/branches/products/search [get]
 func (c *Controller) searchBranchProducts(ctx *gin.Context) {
-	var request pbshop.SearchBranchProductsRequest
+	// Parse the search and the requested page
+	query, err := appsearch.Parse(ctx, appsearch.BranchProductFilters)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
+	page, err := apppagination.Parse(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, nil)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
-	// ADD PARAMETERS HERE
-
 	// Search for branch products
-	response, err := c.client.SearchBranchProducts(grpcCtx, &request)
-	c.responseHandler.HandleResponse(ctx, http.StatusOK, response, err)
+	response, err := c.client.SearchBranchProducts(
+		grpcCtx, &pbshop.SearchBranchProductsRequest{
+			Query:             query.Text,
+			ProductCategoryId: query.ProductCategoryID(),
+		},
+	)
+	query.Filter(response)
+	c.responseHandler.HandlePageResponse(ctx, http.StatusOK, response, page, false, err)
 }
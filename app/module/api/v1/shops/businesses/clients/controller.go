@@ -2,13 +2,13 @@ package clients
 
 import (
 	"github.com/gin-gonic/gin"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
+	appparams "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/params"
+	_ "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbshop "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/shop"
 	pbconfigrestclients "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/shops/businesses/clients"
-	typesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
 	"net/http"
 )
 
@@ -23,7 +23,7 @@ type Controller struct {
 	route           *gin.RouterGroup
 	client          pbshop.ShopClient
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new clients controller
@@ -31,7 +31,7 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pbshop.ShopClient,
 	routeHandler commonhandler.Handler,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the clients controller
 	route := baseRoute.Group(pbconfigrestclients.Base.String())
@@ -68,22 +68,30 @@ func (c *Controller) Initialize() {
 // @Tags v1 shops businesses clients
 // @Accept json
 // @Produce json
+// @Param businessId path string true "Business ID"
 // @Param request body pbshop.AddBusinessClientRequest true "Add Business Client Request"
 // @Success 201 {object} pbshop.AddBusinessClientResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
-// @Router /api/v1/shops/businesses/clients [post]
+// @Router /api/v1/shops/businesses/clients/{businessId} [post]
 func (c *Controller) addBusinessClient(ctx *gin.Context) {
 	var request pbshop.AddBusinessClientRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
+	// Get the business ID from the path
+	request.BusinessId, err = appparams.BusinessID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
+
 	// Add a new business client
 	response, err := c.client.AddBusinessClient(grpcCtx, &request)
 	c.responseHandler.HandleResponse(ctx, http.StatusCreated, response, err)
@@ -97,22 +105,26 @@ func (c *Controller) addBusinessClient(ctx *gin.Context) {
 // @Produce json
 // @Param businessId path string true "Business ID"
 // @Success 200 {object} pbshop.IsBusinessClientResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/shops/clients/{businessId} [get]
 func (c *Controller) isBusinessClient(ctx *gin.Context) {
 	var request pbshop.IsBusinessClientRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Get the business ID from the path
-	request.BusinessId = ctx.Param(typesrest.BusinessId.String())
+	request.BusinessId, err = appparams.BusinessID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Check if the business is a client
 	response, err := c.client.IsBusinessClient(grpcCtx, &request)
@@ -2,20 +2,20 @@ package businesses
 
 import (
 	"github.com/gin-gonic/gin"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
 	moduleshopsbranches "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/shops/businesses/branches"
 	moduleshopsclients "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/shops/businesses/clients"
 	moduleshopsmarkets "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/shops/businesses/markets"
 	moduleshopsowners "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/shops/businesses/owners"
 	moduleshopsproducts "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/shops/businesses/products"
+	appparams "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/params"
+	_ "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	apptypes "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/types"
 	authmiddleware "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/middleware/auth"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbshop "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/shop"
 	pbconfigrestbusinesses "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/shops/businesses"
-	typesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
 	"net/http"
 )
 
@@ -31,7 +31,7 @@ type Controller struct {
 	client          pbshop.ShopClient
 	authMiddleware  authmiddleware.Authentication
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new businesses controller
@@ -39,7 +39,7 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pbshop.ShopClient,
 	routeHandler commonhandler.Handler,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the businesses controller
 	route := baseRoute.Group(pbconfigrestbusinesses.Base.String())
@@ -57,7 +57,12 @@ func NewController(
 func (c *Controller) Initialize() {
 	// Initialize the routes
 	c.route.POST(c.routeHandler.CreateAuthenticatedEndpoint(pbconfigrestbusinesses.AddBusinessMapper, c.addBusiness))
-	c.route.GET(c.routeHandler.CreateAuthenticatedEndpoint(pbconfigrestbusinesses.GetBusinessMapper, c.getBusiness))
+	c.route.GET(
+		c.routeHandler.CreateAuthenticatedEndpoint(
+			pbconfigrestbusinesses.GetBusinessMapper,
+			c.responseHandler.Cached(c.getBusiness),
+		),
+	)
 	c.route.PUT(
 		c.routeHandler.CreateAuthenticatedEndpoint(
 			pbconfigrestbusinesses.UpdateBusinessMapper,
@@ -110,15 +115,15 @@ func (c *Controller) initializeChildren() {
 // @Produce json
 // @Param request body pbshop.AddBusinessRequest true "Add Business Request"
 // @Success 201 {object} pbshop.AddBusinessResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/shops/businesses [post]
 func (c *Controller) addBusiness(ctx *gin.Context) {
 	var request pbshop.AddBusinessRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -136,22 +141,30 @@ func (c *Controller) addBusiness(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param businessId path string true "Business ID"
+// @Param If-None-Match header string false "ETag of the copy of the response the client already has"
 // @Success 200 {object} pbshop.GetBusinessResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Header 200 {string} ETag "Validator of the response"
+// @Header 200 {string} Cache-Control "Caching directives of the response"
+// @Success 304 "Not Modified"
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Router /api/v1/shops/businesses/{businessId} [get]
 func (c *Controller) getBusiness(ctx *gin.Context) {
 	var request pbshop.GetBusinessRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Get the business ID from the path
-	request.BusinessId = ctx.Param(typesrest.BusinessId.String())
+	request.BusinessId, err = appparams.BusinessID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Get the business by ID
 	response, err := c.client.GetBusiness(grpcCtx, &request)
@@ -164,22 +177,29 @@ func (c *Controller) getBusiness(ctx *gin.Context) {
 // @Tags v1 shops businesses
 // @Accept json
 // @Produce json
+// @Param businessId path string true "Business ID"
 // @Param request body pbshop.UpdateBusinessRequest true "Update Business Request"
 // @Success 200 {object} pbshop.UpdateBusinessResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
-// @Router /api/v1/shops/businesses [put]
+// @Router /api/v1/shops/businesses/{businessId} [put]
 func (c *Controller) updateBusiness(ctx *gin.Context) {
 	var request pbshop.UpdateBusinessRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
+	// Check the business ID of the path, which is not forwarded since the request has no field for it
+	if _, err = appparams.BusinessID.Get(ctx); err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
+
 	// Update the business
 	response, err := c.client.UpdateBusiness(grpcCtx, &request)
 	c.responseHandler.HandleResponse(ctx, http.StatusOK, response, err)
@@ -191,22 +211,30 @@ func (c *Controller) updateBusiness(ctx *gin.Context) {
 // @Tags v1 shops businesses
 // @Accept json
 // @Produce json
+// @Param businessId path string true "Business ID"
 // @Param request body pbshop.SetBusinessProfilePictureRequest true "Set Business Profile Picture Request"
 // @Success 200 {object} pbshop.SetBusinessProfilePictureResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
-// @Router /api/v1/shops/businesses/profile-picture [post]
+// @Router /api/v1/shops/businesses/profile-picture/{businessId} [post]
 func (c *Controller) setBusinessProfilePicture(ctx *gin.Context) {
 	var request pbshop.SetBusinessProfilePictureRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
+	// Get the business ID from the path
+	request.BusinessId, err = appparams.BusinessID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
+
 	// Set the profile picture of the business
 	response, err := c.client.SetBusinessProfilePicture(grpcCtx, &request)
 	c.responseHandler.HandleResponse(ctx, http.StatusOK, response, err)
@@ -220,22 +248,26 @@ func (c *Controller) setBusinessProfilePicture(ctx *gin.Context) {
 // @Produce json
 // @Param businessId path string true "Business ID"
 // @Success 200 {object} pbshop.DeleteBusinessResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/shops/businesses/{businessId} [delete]
 func (c *Controller) deleteBusiness(ctx *gin.Context) {
 	var request pbshop.DeleteBusinessRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Get the business ID from the path
-	request.BusinessId = ctx.Param(typesrest.BusinessId.String())
+	request.BusinessId, err = appparams.BusinessID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Delete the business
 	response, err := c.client.DeleteBusiness(grpcCtx, &request)
@@ -2,13 +2,13 @@ package markets
 
 import (
 	"github.com/gin-gonic/gin"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
+	appparams "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/params"
+	_ "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbshop "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/shop"
 	pbconfigrestmarkets "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/shops/businesses/markets"
-	typesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
 	"net/http"
 )
 
@@ -23,7 +23,7 @@ type Controller struct {
 	route           *gin.RouterGroup
 	client          pbshop.ShopClient
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new markets controller
@@ -31,7 +31,7 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pbshop.ShopClient,
 	routeHandler commonhandler.Handler,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the markets controller
 	route := baseRoute.Group(pbconfigrestmarkets.Base.String())
@@ -70,15 +70,15 @@ func (c *Controller) Initialize() {
 // @Produce json
 // @Param request body pbshop.AddBusinessMarketCategoryRequest true "Add Business Market Category Request"
 // @Success 201 {object} pbshop.AddBusinessMarketCategoryResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/shops/businesses/markets/categories [post]
 func (c *Controller) addBusinessMarketCategory(ctx *gin.Context) {
 	var request pbshop.AddBusinessMarketCategoryRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -97,21 +97,25 @@ func (c *Controller) addBusinessMarketCategory(ctx *gin.Context) {
 // @Produce json
 // @Param businessId path string true "Business ID"
 // @Success 200 {object} pbshop.GetBusinessMarketCategoriesResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Router /api/v1/shops/businesses/markets/categories/{businessId} [get]
 func (c *Controller) getBusinessMarketCategories(ctx *gin.Context) {
 	var request pbshop.GetBusinessMarketCategoriesRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Get the business ID from the path
-	request.BusinessId = ctx.Param(typesrest.BusinessId.String())
+	request.BusinessId, err = appparams.BusinessID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Get all business market categories
 	response, err := c.client.GetBusinessMarketCategories(grpcCtx, &request)
@@ -2,13 +2,13 @@ package owners
 
 import (
 	"github.com/gin-gonic/gin"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
+	appparams "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/params"
+	_ "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbshop "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/shop"
 	pbconfigrestowners "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/shops/businesses/owners"
-	typesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
 	"net/http"
 )
 
@@ -23,7 +23,7 @@ type Controller struct {
 	route           *gin.RouterGroup
 	client          pbshop.ShopClient
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new owners controller
@@ -31,7 +31,7 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pbshop.ShopClient,
 	routeHandler commonhandler.Handler,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the owners controller
 	route := baseRoute.Group(pbconfigrestowners.Base.String())
@@ -74,22 +74,30 @@ func (c *Controller) Initialize() {
 // @Tags v1 shops businesses owners
 // @Accept json
 // @Produce json
+// @Param businessId path string true "Business ID"
 // @Param request body pbshop.AddBusinessOwnerRequest true "Add Business Owner Request"
 // @Success 201 {object} pbshop.AddBusinessOwnerResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
-// @Router /api/v1/shops/businesses/owners [post]
+// @Router /api/v1/shops/businesses/owners/{businessId} [post]
 func (c *Controller) addBusinessOwner(ctx *gin.Context) {
 	var request pbshop.AddBusinessOwnerRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
+	// Get the business ID from the path
+	request.BusinessId, err = appparams.BusinessID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
+
 	// Add a new business owner
 	response, err := c.client.AddBusinessOwner(grpcCtx, &request)
 	c.responseHandler.HandleResponse(ctx, http.StatusCreated, response, err)
@@ -101,22 +109,30 @@ func (c *Controller) addBusinessOwner(ctx *gin.Context) {
 // @Tags v1 shops businesses owners
 // @Accept json
 // @Produce json
+// @Param businessId path string true "Business ID"
 // @Param request body pbshop.RemoveBusinessOwnerRequest true "Remove Business Owner Request"
 // @Success 200 {object} pbshop.RemoveBusinessOwnerResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
-// @Router /api/v1/shops/businesses/owners [delete]
+// @Router /api/v1/shops/businesses/owners/{businessId} [delete]
 func (c *Controller) removeBusinessOwner(ctx *gin.Context) {
 	var request pbshop.RemoveBusinessOwnerRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
+	// Get the business ID from the path
+	request.BusinessId, err = appparams.BusinessID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
+
 	// Remove the business owner
 	response, err := c.client.RemoveBusinessOwner(grpcCtx, &request)
 	c.responseHandler.HandleResponse(ctx, http.StatusOK, response, err)
@@ -130,22 +146,26 @@ func (c *Controller) removeBusinessOwner(ctx *gin.Context) {
 // @Produce json
 // @Param businessId path string true "Business ID"
 // @Success 200 {object} pbshop.GetBusinessOwnersResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/shops/businesses/owners/{businessId} [get]
 func (c *Controller) getBusinessOwners(ctx *gin.Context) {
 	var request pbshop.GetBusinessOwnersRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Get the business ID from the path
-	request.BusinessId = ctx.Param(typesrest.BusinessId.String())
+	request.BusinessId, err = appparams.BusinessID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Get all business owners
 	response, err := c.client.GetBusinessOwners(grpcCtx, &request)
@@ -2,13 +2,15 @@ package products
 
 import (
 	"github.com/gin-gonic/gin"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
+	apppagination "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/pagination"
+	appparams "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/params"
+	_ "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
+	appsearch "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/search"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbshop "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/shop"
 	pbconfigrestproducts "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/shops/businesses/products"
-	typesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
 	"net/http"
 )
 
@@ -23,7 +25,7 @@ type Controller struct {
 	route           *gin.RouterGroup
 	client          pbshop.ShopClient
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new products controller
@@ -31,7 +33,7 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pbshop.ShopClient,
 	routeHandler commonhandler.Handler,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the products controller
 	route := baseRoute.Group(pbconfigrestproducts.Base.String())
@@ -57,7 +59,7 @@ func (c *Controller) Initialize() {
 	c.route.GET(
 		c.routeHandler.CreateAuthenticatedEndpoint(
 			pbconfigrestproducts.GetBusinessProductMapper,
-			c.getBusinessProduct,
+			c.responseHandler.Cached(c.getBusinessProduct),
 		),
 	)
 	c.route.PUT(
@@ -82,15 +84,15 @@ func (c *Controller) Initialize() {
 // @Produce json
 // @Param request body pbshop.AddBusinessProductRequest true "Add Business Product Request"
 // @Success 201 {object} pbshop.AddBusinessProductResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/shops/businesses/products [post]
 func (c *Controller) addBusinessProduct(ctx *gin.Context) {
 	var request pbshop.AddBusinessProductRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -108,22 +110,30 @@ func (c *Controller) addBusinessProduct(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param productId path string true "Product ID"
+// @Param If-None-Match header string false "ETag of the copy of the response the client already has"
 // @Success 200 {object} pbshop.GetBusinessProductResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Header 200 {string} ETag "Validator of the response"
+// @Header 200 {string} Cache-Control "Caching directives of the response"
+// @Success 304 "Not Modified"
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Router /api/v1/shops/businesses/products/{productId} [get]
 func (c *Controller) getBusinessProduct(ctx *gin.Context) {
 	var request pbshop.GetBusinessProductRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Get the product ID from the path
-	request.ProductId = ctx.Param(typesrest.ProductId.String())
+	request.ProductId, err = appparams.ProductID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Get the business product by ID
 	response, err := c.client.GetBusinessProduct(grpcCtx, &request)
@@ -136,22 +146,30 @@ func (c *Controller) getBusinessProduct(ctx *gin.Context) {
 // @Tags v1 shops businesses products
 // @Accept json
 // @Produce json
+// @Param productId path string true "Product ID"
 // @Param request body pbshop.UpdateBusinessProductRequest true "Update Business Product Request"
 // @Success 200 {object} pbshop.UpdateBusinessProductResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
-// @Router /api/v1/shops/businesses/products [put]
+// @Router /api/v1/shops/businesses/products/{productId} [put]
 func (c *Controller) updateBusinessProduct(ctx *gin.Context) {
 	var request pbshop.UpdateBusinessProductRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
+	// Get the product ID from the path
+	request.ProductId, err = appparams.ProductID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
+
 	// Update the business product
 	response, err := c.client.UpdateBusinessProduct(grpcCtx, &request)
 	c.responseHandler.HandleResponse(ctx, http.StatusOK, response, err)
@@ -159,28 +177,45 @@ func (c *Controller) updateBusinessProduct(ctx *gin.Context) {
 
 // searchBusinessProducts searches for business products
 // @Summary Search for business products
-// @Description Search for business products
+// @Description Search for business products by text and product category
 // @Tags v1 shops businesses products
-// @Accept json
 // @Produce json
-// @Param request body pbshop.SearchBusinessProductsRequest true "Search Business Products Request"
+// @Param q query string false "Text the business products are searched by" maxlength(200)
+// @Param category_id query string false "Product category ID"
+// @Param limit query int false "Maximum number of items of the page" minimum(1) maximum(100) default(20)
+// @Param cursor query string false "Cursor of the page, as returned in next_cursor"
+// @Param sort query string false "Field the items are sorted by, prefixed by - for descending order"
 // @Success 200 {object} pbshop.SearchBusinessProductsResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
-// @Router /api/v1/shops/businesses/products/search [post]
+// @Header 200 {string} Link "Links to the first and the next pages"
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
+// @Router /api/v1/shops/businesses/products/search [get]
 func (c *Controller) searchBusinessProducts(ctx *gin.Context) {
-	var request pbshop.SearchBusinessProductsRequest
+	// Parse the search and the requested page
+	query, err := appsearch.Parse(ctx, appsearch.ProductFilters)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
+	page, err := apppagination.Parse(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, nil)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
-	// ADD PARAMETERS HERE
-
 	// Search for business products
-	response, err := c.client.SearchBusinessProducts(grpcCtx, &request)
-	c.responseHandler.HandleResponse(ctx, http.StatusOK, response, err)
+	response, err := c.client.SearchBusinessProducts(
+		grpcCtx, &pbshop.SearchBusinessProductsRequest{
+			Query:             query.Text,
+			ProductCategoryId: query.ProductCategoryID(),
+		},
+	)
+	c.responseHandler.HandlePageResponse(ctx, http.StatusOK, response, page, false, err)
 }
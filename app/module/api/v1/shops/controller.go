@@ -6,11 +6,11 @@ import (
 	moduleshopsmarkets "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/shops/markets"
 	moduleshopsproducts "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/shops/products"
 	moduleshopsstores "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/shops/stores"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	apptypes "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/types"
 	authmiddleware "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/middleware/auth"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
 	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbshop "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/shop"
 	pbconfiggrpcshop "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/grpc/shop"
 	pbconfigrestshops "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/shops"
@@ -28,7 +28,7 @@ type Controller struct {
 	client          pbshop.ShopClient
 	authentication  authmiddleware.Authentication
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new shops controller
@@ -36,13 +36,16 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pbshop.ShopClient,
 	authentication authmiddleware.Authentication,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the shops controller
 	route := baseRoute.Group(pbconfigrestshops.Base.String())
 
-	// Create the route handler
-	routeHandler := commonhandler.NewDefaultHandler(authentication, &pbconfiggrpcshop.Interceptions)
+	// Create the route handler, which replays the responses of the repeated non-idempotent requests once they are
+	// authenticated
+	routeHandler := responseHandler.RouteHandler(
+		commonhandler.NewDefaultHandler(authentication, &pbconfiggrpcshop.Interceptions),
+	)
 
 	// Create a new shops controller
 	return &Controller{
@@ -2,13 +2,13 @@ package categories
 
 import (
 	"github.com/gin-gonic/gin"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
+	appparams "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/params"
+	_ "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbshop "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/shop"
 	pbconfigrestcategories "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/shops/markets/categories"
-	typesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
 	"net/http"
 )
 
@@ -23,7 +23,7 @@ type Controller struct {
 	route           *gin.RouterGroup
 	client          pbshop.ShopClient
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new markets categories controller
@@ -31,7 +31,7 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pbshop.ShopClient,
 	routeHandler commonhandler.Handler,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the markets categories controller
 	route := baseRoute.Group(pbconfigrestcategories.Base.String())
@@ -57,7 +57,7 @@ func (c *Controller) Initialize() {
 	c.route.GET(
 		c.routeHandler.CreateAuthenticatedEndpoint(
 			pbconfigrestcategories.GetMarketCategoryMapper,
-			c.getMarketCategory,
+			c.responseHandler.Cached(c.getMarketCategory),
 		),
 	)
 	c.route.PUT(
@@ -76,15 +76,15 @@ func (c *Controller) Initialize() {
 // @Produce json
 // @Param request body pbshop.AddMarketCategoryRequest true "Add Market Category Request"
 // @Success 201 {object} pbshop.AddMarketCategoryResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/shops/markets/categories [post]
 func (c *Controller) addMarketCategory(ctx *gin.Context) {
 	var request pbshop.AddMarketCategoryRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -102,22 +102,30 @@ func (c *Controller) addMarketCategory(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param categoryId path string true "Category ID"
+// @Param If-None-Match header string false "ETag of the copy of the response the client already has"
 // @Success 200 {object} pbshop.GetMarketCategoryResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Header 200 {string} ETag "Validator of the response"
+// @Header 200 {string} Cache-Control "Caching directives of the response"
+// @Success 304 "Not Modified"
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Router /api/v1/shops/markets/categories/{categoryId} [get]
 func (c *Controller) getMarketCategory(ctx *gin.Context) {
 	var request pbshop.GetMarketCategoryRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Get the category ID from the path
-	request.MarketCategoryId = ctx.Param(typesrest.CategoryId.String())
+	request.MarketCategoryId, err = appparams.CategoryID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Get the market category by ID
 	response, err := c.client.GetMarketCategory(grpcCtx, &request)
@@ -130,22 +138,30 @@ func (c *Controller) getMarketCategory(ctx *gin.Context) {
 // @Tags v1 shops markets categories
 // @Accept json
 // @Produce json
+// @Param categoryId path string true "Category ID"
 // @Param request body pbshop.UpdateMarketCategoryRequest true "Update Market Category Request"
 // @Success 200 {object} pbshop.UpdateMarketCategoryResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
-// @Router /api/v1/shops/markets/categories [put]
+// @Router /api/v1/shops/markets/categories/{categoryId} [put]
 func (c *Controller) updateMarketCategory(ctx *gin.Context) {
 	var request pbshop.UpdateMarketCategoryRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
+	// Get the market category ID from the path
+	request.MarketCategoryId, err = appparams.CategoryID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
+
 	// Update the market category
 	response, err := c.client.UpdateMarketCategory(grpcCtx, &request)
 	c.responseHandler.HandleResponse(ctx, http.StatusOK, response, err)
@@ -2,13 +2,13 @@ package categories
 
 import (
 	"github.com/gin-gonic/gin"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
+	appparams "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/params"
+	_ "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbshop "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/shop"
 	pbconfigrestcategories "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/shops/products/categories"
-	typesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
 	"net/http"
 )
 
@@ -23,7 +23,7 @@ type Controller struct {
 	route           *gin.RouterGroup
 	client          pbshop.ShopClient
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new products categories controller
@@ -31,7 +31,7 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pbshop.ShopClient,
 	routeHandler commonhandler.Handler,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the products categories controller
 	route := baseRoute.Group(pbconfigrestcategories.Base.String())
@@ -57,7 +57,7 @@ func (c *Controller) Initialize() {
 	c.route.GET(
 		c.routeHandler.CreateAuthenticatedEndpoint(
 			pbconfigrestcategories.GetProductCategoryMapper,
-			c.getProductCategory,
+			c.responseHandler.Cached(c.getProductCategory),
 		),
 	)
 	c.route.PUT(
@@ -76,15 +76,15 @@ func (c *Controller) Initialize() {
 // @Produce json
 // @Param request body pbshop.AddProductCategoryRequest true "Add Product Category Request"
 // @Success 201 {object} pbshop.AddProductCategoryResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/shops/products/categories [post]
 func (c *Controller) addProductCategory(ctx *gin.Context) {
 	var request pbshop.AddProductCategoryRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -102,22 +102,30 @@ func (c *Controller) addProductCategory(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param categoryId path string true "Category ID"
+// @Param If-None-Match header string false "ETag of the copy of the response the client already has"
 // @Success 200 {object} pbshop.GetProductCategoryResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Header 200 {string} ETag "Validator of the response"
+// @Header 200 {string} Cache-Control "Caching directives of the response"
+// @Success 304 "Not Modified"
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Router /api/v1/shops/products/categories/{categoryId} [get]
 func (c *Controller) getProductCategory(ctx *gin.Context) {
 	var request pbshop.GetProductCategoryRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Get the category ID from the path
-	request.ProductCategoryId = ctx.Param(typesrest.CategoryId.String())
+	request.ProductCategoryId, err = appparams.CategoryID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Get the product category by ID
 	response, err := c.client.GetProductCategory(grpcCtx, &request)
@@ -132,15 +140,15 @@ func (c *Controller) getProductCategory(ctx *gin.Context) {
 // @Produce json
 // @Param request body pbshop.UpdateProductCategoryRequest true "Update Product Category Request"
 // @Success 200 {object} pbshop.UpdateProductCategoryResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/shops/products/categories [put]
 func (c *Controller) updateProductCategory(ctx *gin.Context) {
 	var request pbshop.UpdateProductCategoryRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
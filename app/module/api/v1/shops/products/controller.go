@@ -2,15 +2,17 @@ package products
 
 import (
 	"github.com/gin-gonic/gin"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
 	moduleshopscategories "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/shops/markets/categories"
+	apppagination "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/pagination"
+	appparams "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/params"
+	_ "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
+	appsearch "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/search"
 	apptypes "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/types"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbshop "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/shop"
 	pbconfigrestproducts "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/shops/products"
-	typesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
 	"net/http"
 )
 
@@ -25,7 +27,7 @@ type Controller struct {
 	route           *gin.RouterGroup
 	client          pbshop.ShopClient
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new products controller
@@ -33,7 +35,7 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pbshop.ShopClient,
 	routeHandler commonhandler.Handler,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the products controller
 	route := baseRoute.Group(pbconfigrestproducts.Base.String())
@@ -51,7 +53,12 @@ func NewController(
 func (c *Controller) Initialize() {
 	// Initialize the routes
 	c.route.POST(c.routeHandler.CreateAuthenticatedEndpoint(pbconfigrestproducts.AddProductMapper, c.addProduct))
-	c.route.GET(c.routeHandler.CreateAuthenticatedEndpoint(pbconfigrestproducts.GetProductMapper, c.getProduct))
+	c.route.GET(
+		c.routeHandler.CreateAuthenticatedEndpoint(
+			pbconfigrestproducts.GetProductMapper,
+			c.responseHandler.Cached(c.getProduct),
+		),
+	)
 	c.route.PUT(c.routeHandler.CreateAuthenticatedEndpoint(pbconfigrestproducts.UpdateProductMapper, c.updateProduct))
 	c.route.GET(c.routeHandler.CreateAuthenticatedEndpoint(pbconfigrestproducts.SearchProductsMapper, c.searchProducts))
 
@@ -80,15 +87,15 @@ func (c *Controller) initializeChildren() {
 // @Produce json
 // @Param request body pbshop.AddProductRequest true "Add Product Request"
 // @Success 201 {object} pbshop.AddProductResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/shops/products [post]
 func (c *Controller) addProduct(ctx *gin.Context) {
 	var request pbshop.AddProductRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -106,22 +113,30 @@ func (c *Controller) addProduct(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param productId path string true "Product ID"
+// @Param If-None-Match header string false "ETag of the copy of the response the client already has"
 // @Success 200 {object} pbshop.GetProductResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Header 200 {string} ETag "Validator of the response"
+// @Header 200 {string} Cache-Control "Caching directives of the response"
+// @Success 304 "Not Modified"
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Router /api/v1/shops/products/{productId} [get]
 func (c *Controller) getProduct(ctx *gin.Context) {
 	var request pbshop.GetProductRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Get the product ID from the path
-	request.ProductId = ctx.Param(typesrest.ProductId.String())
+	request.ProductId, err = appparams.ProductID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Get the product by ID
 	response, err := c.client.GetProduct(grpcCtx, &request)
@@ -134,22 +149,30 @@ func (c *Controller) getProduct(ctx *gin.Context) {
 // @Tags v1 shops products
 // @Accept json
 // @Produce json
+// @Param productId path string true "Product ID"
 // @Param request body pbshop.UpdateProductRequest true "Update Product Request"
 // @Success 200 {object} pbshop.UpdateProductResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
-// @Router /api/v1/shops/products [put]
+// @Router /api/v1/shops/products/{productId} [put]
 func (c *Controller) updateProduct(ctx *gin.Context) {
 	var request pbshop.UpdateProductRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
+	// Get the product ID from the path
+	request.ProductId, err = appparams.ProductID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
+
 	// Update the product
 	response, err := c.client.UpdateProduct(grpcCtx, &request)
 	c.responseHandler.HandleResponse(ctx, http.StatusOK, response, err)
@@ -157,28 +180,45 @@ func (c *Controller) updateProduct(ctx *gin.Context) {
 
 // searchProducts searches for products
 // @Summary Search for products
-// @Description Search for products
+// @Description Search for products by text and product category
 // @Tags v1 shops products
-// @Accept json
 // @Produce json
-// @Param request body pbshop.SearchProductsRequest true "Search Products Request"
+// @Param q query string false "Text the products are searched by" maxlength(200)
+// @Param category_id query string false "Product category ID"
+// @Param limit query int false "Maximum number of items of the page" minimum(1) maximum(100) default(20)
+// @Param cursor query string false "Cursor of the page, as returned in next_cursor"
+// @Param sort query string false "Field the items are sorted by, prefixed by - for descending order"
 // @Success 200 {object} pbshop.SearchProductsResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
-// @Router /api/v1/shops/products/search [post]
+// @Header 200 {string} Link "Links to the first and the next pages"
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
+// @Router /api/v1/shops/products/search [get]
 func (c *Controller) searchProducts(ctx *gin.Context) {
-	var request pbshop.SearchProductsRequest
+	// Parse the search and the requested page
+	query, err := appsearch.Parse(ctx, appsearch.ProductFilters)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
+	page, err := apppagination.Parse(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, nil)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
-	// ADD PARAMETERS HERE
-
 	// Search for products
-	response, err := c.client.SearchProducts(grpcCtx, &request)
-	c.responseHandler.HandleResponse(ctx, http.StatusOK, response, err)
+	response, err := c.client.SearchProducts(
+		grpcCtx, &pbshop.SearchProductsRequest{
+			Query:             query.Text,
+			ProductCategoryId: query.ProductCategoryID(),
+		},
+	)
+	c.responseHandler.HandlePageResponse(ctx, http.StatusOK, response, page, false, err)
 }
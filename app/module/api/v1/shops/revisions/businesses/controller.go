@@ -2,10 +2,10 @@ package businesses
 
 import (
 	"github.com/gin-gonic/gin"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
+	_ "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbshop "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/shop"
 	pbconfigrestbusinesses "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/shops/revisions/businesses"
 	"net/http"
@@ -22,7 +22,7 @@ type Controller struct {
 	route           *gin.RouterGroup
 	client          pbshop.ShopClient
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new businesses revisions controller
@@ -30,7 +30,7 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pbshop.ShopClient,
 	routeHandler commonhandler.Handler,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the businesses revisions controller
 	route := baseRoute.Group(pbconfigrestbusinesses.Base.String())
@@ -69,15 +69,15 @@ func (c *Controller) Initialize() {
 // @Produce json
 // @Param request body pbshop.OpenAdminRevisionToBusinessRequest true "Open Admin Revision To Business Request"
 // @Success 200 {object} pbshop.OpenAdminRevisionToBusinessResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/shops/revisions/businesses [post]
 func (c *Controller) openAdminRevisionToBusiness(ctx *gin.Context) {
 	var request pbshop.OpenAdminRevisionToBusinessRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -96,15 +96,15 @@ func (c *Controller) openAdminRevisionToBusiness(ctx *gin.Context) {
 // @Produce json
 // @Param request body pbshop.OpenAdminRevisionToBusinessProductRequest true "Open Admin Revision To Business Product Request"
 // @Success 200 {object} pbshop.OpenAdminRevisionToBusinessProductResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/shops/revisions/businesses/products [get]
 func (c *Controller) openAdminRevisionToBusinessProduct(ctx *gin.Context) {
 	var request pbshop.OpenAdminRevisionToBusinessProductRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
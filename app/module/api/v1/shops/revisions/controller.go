@@ -2,13 +2,13 @@ package revisions
 
 import (
 	"github.com/gin-gonic/gin"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
 	moduleshopsbusinesses "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/shops/revisions/businesses"
+	_ "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	apptypes "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/types"
 	authmiddleware "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/middleware/auth"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbshop "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/shop"
 	pbconfiggrpcshop "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/grpc/shop"
 	pbconfigrestrevisions "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/shops/revisions"
@@ -27,7 +27,7 @@ type Controller struct {
 	client          pbshop.ShopClient
 	authentication  authmiddleware.Authentication
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new revisions controller
@@ -35,13 +35,16 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pbshop.ShopClient,
 	authentication authmiddleware.Authentication,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the revisions controller
 	route := baseRoute.Group(pbconfigrestrevisions.Base.String())
 
-	// Create the route handler
-	routeHandler := commonhandler.NewDefaultHandler(authentication, &pbconfiggrpcshop.Interceptions)
+	// Create the route handler, which replays the responses of the repeated non-idempotent requests once they are
+	// authenticated
+	routeHandler := responseHandler.RouteHandler(
+		commonhandler.NewDefaultHandler(authentication, &pbconfiggrpcshop.Interceptions),
+	)
 
 	// Create a new revisions controller
 	return &Controller{
@@ -106,15 +109,15 @@ func (c *Controller) initializeChildren() {
 // @Produce json
 // @Param request body pbshop.UpdateAdminRevisionRequest true "Update Admin Revision Request"
 // @Success 200 {object} pbshop.UpdateAdminRevisionResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/shops/revisions [post]
 func (c *Controller) updateAdminRevision(ctx *gin.Context) {
 	var request pbshop.UpdateAdminRevisionRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -133,15 +136,15 @@ func (c *Controller) updateAdminRevision(ctx *gin.Context) {
 // @Produce json
 // @Param request body pbshop.CloseAdminRevisionRequest true "Close Admin Revision Request"
 // @Success 200 {object} pbshop.CloseAdminRevisionResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/shops/revisions [delete]
 func (c *Controller) closeAdminRevision(ctx *gin.Context) {
 	var request pbshop.CloseAdminRevisionRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -160,15 +163,15 @@ func (c *Controller) closeAdminRevision(ctx *gin.Context) {
 // @Produce json
 // @Param request body pbshop.OpenAdminRevisionToBranchRequest true "Open Admin Revision To Branch Request"
 // @Success 200 {object} pbshop.OpenAdminRevisionToBranchResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/shops/revisions/branches [post]
 func (c *Controller) openAdminRevisionToBranch(ctx *gin.Context) {
 	var request pbshop.OpenAdminRevisionToBranchRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -187,15 +190,15 @@ func (c *Controller) openAdminRevisionToBranch(ctx *gin.Context) {
 // @Produce json
 // @Param request body pbshop.OpenAdminRevisionToProductRequest true "Open Admin Revision To Product Request"
 // @Success 200 {object} pbshop.OpenAdminRevisionToProductResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/shops/revisions/products [post]
 func (c *Controller) openAdminRevisionToProduct(ctx *gin.Context) {
 	var request pbshop.OpenAdminRevisionToProductRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
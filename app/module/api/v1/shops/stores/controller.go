@@ -2,13 +2,13 @@ package stores
 
 import (
 	"github.com/gin-gonic/gin"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
+	appparams "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/params"
+	_ "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbshop "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/shop"
 	pbconfigreststores "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/shops/stores"
-	typesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"net/http"
 )
@@ -24,7 +24,7 @@ type Controller struct {
 	route           *gin.RouterGroup
 	client          pbshop.ShopClient
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new stores controller
@@ -32,7 +32,7 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pbshop.ShopClient,
 	routeHandler commonhandler.Handler,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the stores controller
 	route := baseRoute.Group(pbconfigreststores.Base.String())
@@ -68,15 +68,15 @@ func (c *Controller) Initialize() {
 // @Produce json
 // @Param request body pbshop.AddStoreRequest true "Add Store Request"
 // @Success 201 {object} pbshop.AddStoreResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/shops/stores [post]
 func (c *Controller) addStore(ctx *gin.Context) {
 	var request pbshop.AddStoreRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -95,22 +95,26 @@ func (c *Controller) addStore(ctx *gin.Context) {
 // @Produce json
 // @Param storeId path string true "Store ID"
 // @Success 200 {object} pbshop.GetStoreResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/shops/stores/{storeId} [get]
 func (c *Controller) getStore(ctx *gin.Context) {
 	var request pbshop.GetStoreRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Get the store ID from the path
-	request.StoreId = ctx.Param(typesrest.StoreId.String())
+	request.StoreId, err = appparams.StoreID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Get the store by ID
 	response, err := c.client.GetStore(grpcCtx, &request)
@@ -125,22 +129,26 @@ func (c *Controller) getStore(ctx *gin.Context) {
 // @Produce json
 // @Param storeId path string true "Store ID"
 // @Success 200 {object} pbshop.DeleteStoreResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/shops/stores/{storeId} [delete]
 func (c *Controller) deleteStore(ctx *gin.Context) {
 	var request pbshop.DeleteStoreRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Get the store ID from the path
-	request.StoreId = ctx.Param(typesrest.StoreId.String())
+	request.StoreId, err = appparams.StoreID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Delete the store
 	response, err := c.client.DeleteStore(grpcCtx, &request)
@@ -154,13 +162,13 @@ func (c *Controller) deleteStore(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Success 200 {object} pbshop.GetUnoccupiedStoresResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/shops/stores/unoccupied [get]
 func (c *Controller) getUnoccupiedStores(ctx *gin.Context) {
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, nil)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, nil)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
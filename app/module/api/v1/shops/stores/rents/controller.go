@@ -2,13 +2,13 @@ package rents
 
 import (
 	"github.com/gin-gonic/gin"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
+	appparams "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/params"
+	_ "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbshop "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/shop"
 	pbconfigrestrents "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/shops/stores/rents"
-	typesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
 	"net/http"
 )
 
@@ -23,7 +23,7 @@ type Controller struct {
 	route           *gin.RouterGroup
 	client          pbshop.ShopClient
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new stores rents controller
@@ -31,7 +31,7 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pbshop.ShopClient,
 	routeHandler commonhandler.Handler,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the stores rents controller
 	route := baseRoute.Group(pbconfigrestrents.Base.String())
@@ -78,15 +78,15 @@ func (c *Controller) Initialize() {
 // @Produce json
 // @Param request body pbshop.AddBranchRentRequest true "Add Branch Rent Request"
 // @Success 201 {object} pbshop.AddBranchRentResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/shops/stores/rents [post]
 func (c *Controller) addBranchRent(ctx *gin.Context) {
 	var request pbshop.AddBranchRentRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -105,22 +105,26 @@ func (c *Controller) addBranchRent(ctx *gin.Context) {
 // @Produce json
 // @Param branchId path string true "Branch ID"
 // @Success 200 {object} pbshop.GetBranchRentsResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/shops/stores/rents/{branchId} [get]
 func (c *Controller) getBranchRents(ctx *gin.Context) {
 	var request pbshop.GetBranchRentsRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Get the branch ID from the path
-	request.BranchId = ctx.Param(typesrest.BranchId.String())
+	request.BranchId, err = appparams.BranchID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Get branch rents by branch ID
 	response, err := c.client.GetBranchRents(grpcCtx, &request)
@@ -135,15 +139,15 @@ func (c *Controller) getBranchRents(ctx *gin.Context) {
 // @Produce json
 // @Param request body pbshop.UpdateBranchRentRequest true "Update Branch Rent Request"
 // @Success 200 {object} pbshop.UpdateBranchRentResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/shops/stores/rents [put]
 func (c *Controller) updateBranchRent(ctx *gin.Context) {
 	var request pbshop.UpdateBranchRentRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -162,22 +166,26 @@ func (c *Controller) updateBranchRent(ctx *gin.Context) {
 // @Produce json
 // @Param branchId path string true "Branch ID"
 // @Success 200 {object} pbshop.GetUnpaidBranchRentsResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/shops/stores/rents/branch-unpaid/{branchId} [get]
 func (c *Controller) getUnpaidBranchRents(ctx *gin.Context) {
 	var request pbshop.GetUnpaidBranchRentsRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Get the branch ID from the path
-	request.BranchId = ctx.Param(typesrest.BranchId.String())
+	request.BranchId, err = appparams.BranchID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Get unpaid branch rents by branch ID
 	response, err := c.client.GetUnpaidBranchRents(grpcCtx, &request)
@@ -192,22 +200,26 @@ func (c *Controller) getUnpaidBranchRents(ctx *gin.Context) {
 // @Produce json
 // @Param businessId path string true "Business ID"
 // @Success 200 {object} pbshop.GetBusinessUnpaidBranchRentsResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/shops/stores/rents/business-unpaid/{businessId} [get]
 func (c *Controller) getBusinessUnpaidBranchRents(ctx *gin.Context) {
 	var request pbshop.GetBusinessUnpaidBranchRentsRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Get the business ID from the path
-	request.BusinessId = ctx.Param(typesrest.BusinessId.String())
+	request.BusinessId, err = appparams.BusinessID.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Get unpaid branch rents by business ID
 	response, err := c.client.GetBusinessUnpaidBranchRents(grpcCtx, &request)
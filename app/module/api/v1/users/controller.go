@@ -2,20 +2,20 @@ package users
 
 import (
 	"github.com/gin-gonic/gin"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
 	moduleusersemails "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/users/emails"
 	moduleusersphonenumbers "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/users/phone-numbers"
 	moduleusersprofiles "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/users/profiles"
 	moduleusersusernames "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api/v1/users/usernames"
+	appparams "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/params"
+	_ "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	apptypes "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/types"
 	authmiddleware "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/middleware/auth"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbuser "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/user"
 	pbconfiggrpcuser "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/grpc/user"
 	pbconfigrestusers "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/users"
-	pbtypesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
 	"net/http"
 )
 
@@ -31,7 +31,7 @@ type Controller struct {
 	client          pbuser.UserClient
 	authentication  authmiddleware.Authentication
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new users controller
@@ -39,13 +39,16 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pbuser.UserClient,
 	authentication authmiddleware.Authentication,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the users controller
 	route := baseRoute.Group(pbconfigrestusers.Base.String())
 
-	// Create the route handler
-	routeHandler := commonhandler.NewDefaultHandler(authentication, &pbconfiggrpcuser.Interceptions)
+	// Create the route handler, which replays the responses of the repeated non-idempotent requests once they are
+	// authenticated
+	routeHandler := responseHandler.RouteHandler(
+		commonhandler.NewDefaultHandler(authentication, &pbconfiggrpcuser.Interceptions),
+	)
 
 	// Create a new users controller
 	return &Controller{
@@ -110,14 +113,14 @@ func (c *Controller) initializeChildren() {
 // @Produce json
 // @Param request body pbuser.SignUpRequest true "Sign Up Request"
 // @Success 201 {object} pbuser.SignUpResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Router /api/v1/users/sign-up [post]
 func (c *Controller) signUp(ctx *gin.Context) {
 	var request pbuser.SignUpRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -136,15 +139,15 @@ func (c *Controller) signUp(ctx *gin.Context) {
 // @Produce json
 // @Param request body pbuser.UpdateUserRequest true "Update User Request"
 // @Success 200 {object} pbuser.UpdateUserResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/users [patch]
 func (c *Controller) updateUser(ctx *gin.Context) {
 	var request pbuser.UpdateUserRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -163,21 +166,25 @@ func (c *Controller) updateUser(ctx *gin.Context) {
 // @Produce json
 // @Param username path string true "Username"
 // @Success 200 {object} pbuser.GetUserIdByUsernameResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Router /api/v1/users/user-id/{username} [get]
 func (c *Controller) getUserIdByUsername(ctx *gin.Context) {
 	var request pbuser.GetUserIdByUsernameRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Add the username to the request
-	request.Username = ctx.Param(pbtypesrest.Username.String())
+	request.Username, err = appparams.Username.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Get the user's ID by username
 	response, err := c.client.GetUserIdByUsername(grpcCtx, &request)
@@ -192,15 +199,15 @@ func (c *Controller) getUserIdByUsername(ctx *gin.Context) {
 // @Produce json
 // @Param request body pbuser.ChangePasswordRequest true "Change Password Request"
 // @Success 200 {object} pbuser.ChangePasswordResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/users/password [put]
 func (c *Controller) changePassword(ctx *gin.Context) {
 	var request pbuser.ChangePasswordRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -219,15 +226,15 @@ func (c *Controller) changePassword(ctx *gin.Context) {
 // @Produce json
 // @Param request body pbuser.ChangeUsernameRequest true "Change Username Request"
 // @Success 200 {object} pbuser.ChangeUsernameResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/users/username [patch]
 func (c *Controller) changeUsername(ctx *gin.Context) {
 	var request pbuser.ChangeUsernameRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -246,14 +253,14 @@ func (c *Controller) changeUsername(ctx *gin.Context) {
 // @Produce json
 // @Param request body pbuser.ForgotPasswordRequest true "Forgot Password Request"
 // @Success 200 {object} pbuser.ForgotPasswordResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Router /api/v1/users/forgot-password [post]
 func (c *Controller) forgotPassword(ctx *gin.Context) {
 	var request pbuser.ForgotPasswordRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -273,21 +280,25 @@ func (c *Controller) forgotPassword(ctx *gin.Context) {
 // @Param token path string true "Verification Token"
 // @Param request body pbuser.ResetPasswordRequest true "Reset Password Request"
 // @Success 200 {object} pbuser.ResetPasswordResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Router /api/v1/users/reset-password/{token} [post]
 func (c *Controller) resetPassword(ctx *gin.Context) {
 	var request pbuser.ResetPasswordRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Add the token to the request
-	request.Token = ctx.Param(pbtypesrest.Token.String())
+	request.Token, err = appparams.Token.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Reset the user's password
 	response, err := c.client.ResetPassword(grpcCtx, &request)
@@ -302,15 +313,15 @@ func (c *Controller) resetPassword(ctx *gin.Context) {
 // @Produce json
 // @Param request body pbuser.DeleteUserRequest true "Delete User Request"
 // @Success 200 {object} pbuser.DeleteUserResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/users/delete-account [delete]
 func (c *Controller) deleteUser(ctx *gin.Context) {
 	var request pbuser.DeleteUserRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
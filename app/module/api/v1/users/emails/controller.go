@@ -2,13 +2,13 @@ package emails
 
 import (
 	"github.com/gin-gonic/gin"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
+	appparams "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/params"
+	_ "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbuser "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/user"
 	pbconfigrestemails "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/users/emails"
-	pbtypesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"net/http"
 )
@@ -24,7 +24,7 @@ type Controller struct {
 	route           *gin.RouterGroup
 	client          pbuser.UserClient
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new emails controller
@@ -32,7 +32,7 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pbuser.UserClient,
 	routeHandler commonhandler.Handler,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the emails controller
 	route := baseRoute.Group(pbconfigrestemails.Base.String())
@@ -75,13 +75,13 @@ func (c *Controller) Initialize() {
 // @Accept json
 // @Produce json
 // @Success 200 {object} pbuser.GetActiveEmailsResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/users/emails [get]
 func (c *Controller) getActiveEmails(ctx *gin.Context) {
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, nil)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, nil)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -100,14 +100,14 @@ func (c *Controller) getActiveEmails(ctx *gin.Context) {
 // @Produce json
 // @Param request body pbuser.AddEmailRequest true "Add Email Request"
 // @Success 201 {object} pbuser.AddEmailResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Router /api/v1/users/emails [post]
 func (c *Controller) addEmail(ctx *gin.Context) {
 	var request pbuser.AddEmailRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -125,13 +125,13 @@ func (c *Controller) addEmail(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Success 200 {object} pbuser.GetPrimaryEmailResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/users/emails/primary [get]
 func (c *Controller) getPrimaryEmail(ctx *gin.Context) {
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, nil)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, nil)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -150,15 +150,15 @@ func (c *Controller) getPrimaryEmail(ctx *gin.Context) {
 // @Produce json
 // @Param request body pbuser.ChangePrimaryEmailRequest true "Change Primary Email Request"
 // @Success 200 {object} pbuser.ChangePrimaryEmailResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/users/emails/primary [put]
 func (c *Controller) changePrimaryEmail(ctx *gin.Context) {
 	var request pbuser.ChangePrimaryEmailRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -177,22 +177,26 @@ func (c *Controller) changePrimaryEmail(ctx *gin.Context) {
 // @Produce json
 // @Param email path string true "Email"
 // @Success 200 {object} pbuser.DeleteEmailResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/users/emails/{email} [delete]
 func (c *Controller) deleteEmail(ctx *gin.Context) {
 	var request pbuser.DeleteEmailRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Add the email to the request
-	request.Email = ctx.Param(pbtypesrest.Email.String())
+	request.Email, err = appparams.Email.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Delete an email from the user's account
 	response, err := c.client.DeleteEmail(grpcCtx, &request)
@@ -207,15 +211,15 @@ func (c *Controller) deleteEmail(ctx *gin.Context) {
 // @Produce json
 // @Param request body pbuser.SendVerificationEmailRequest true "Send Verification Email Request"
 // @Success 200 {object} pbuser.SendVerificationEmailResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/users/emails/send-verification [post]
 func (c *Controller) sendVerificationEmail(ctx *gin.Context) {
 	var request pbuser.SendVerificationEmailRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -237,21 +241,25 @@ func (c *Controller) sendVerificationEmail(ctx *gin.Context) {
 // @Produce json
 // @Param token path string true "Verification Token"
 // @Success 200 {object} pbuser.VerifyEmailResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Router /api/v1/users/emails/verify/{token} [post]
 func (c *Controller) verifyEmail(ctx *gin.Context) {
 	var request pbuser.VerifyEmailRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Add the token to the request
-	request.Token = ctx.Param(pbtypesrest.Token.String())
+	request.Token, err = appparams.Token.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Verify the user's email
 	response, err := c.client.VerifyEmail(grpcCtx, &request)
@@ -2,13 +2,13 @@ package phone_numbers
 
 import (
 	"github.com/gin-gonic/gin"
+	appgrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client/context"
+	appparams "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/params"
+	_ "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
 	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
-	_ "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/types"
-	commongrpcclientctx "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/context"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
 	pbuser "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/user"
 	pbconfigrestphonenumbers "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/users/phone-numbers"
-	pbtypesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"net/http"
 )
@@ -24,7 +24,7 @@ type Controller struct {
 	route           *gin.RouterGroup
 	client          pbuser.UserClient
 	routeHandler    commonhandler.Handler
-	responseHandler commonclientresponse.Handler
+	responseHandler *appresponse.Handler
 }
 
 // NewController creates a new phone numbers controller
@@ -32,7 +32,7 @@ func NewController(
 	baseRoute *gin.RouterGroup,
 	client pbuser.UserClient,
 	routeHandler commonhandler.Handler,
-	responseHandler commonclientresponse.Handler,
+	responseHandler *appresponse.Handler,
 ) *Controller {
 	// Create a new route for the phone numbers controller
 	route := baseRoute.Group(pbconfigrestphonenumbers.Base.String())
@@ -82,13 +82,13 @@ func (c *Controller) Initialize() {
 // @Accept json
 // @Produce json
 // @Success 200 {object} pbuser.GetPhoneNumberResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/users/phone-numbers [get]
 func (c *Controller) getPhoneNumber(ctx *gin.Context) {
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, nil)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, nil)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -107,15 +107,15 @@ func (c *Controller) getPhoneNumber(ctx *gin.Context) {
 // @Produce json
 // @Param request body pbuser.ChangePhoneNumberRequest true "Change Phone Number Request"
 // @Success 200 {object} pbuser.ChangePhoneNumberResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/users/phone-numbers [put]
 func (c *Controller) changePhoneNumber(ctx *gin.Context) {
 	var request pbuser.ChangePhoneNumberRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -134,15 +134,15 @@ func (c *Controller) changePhoneNumber(ctx *gin.Context) {
 // @Produce json
 // @Param request body pbuser.SendVerificationSMSRequest true "Send Verification SMS Request"
 // @Success 200 {object} pbuser.SendVerificationSMSResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Security BearerAuth
 // @Router /api/v1/users/phone-numbers/send-verification [post]
 func (c *Controller) sendVerificationSMS(ctx *gin.Context) {
 	var request pbuser.SendVerificationSMSRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
@@ -164,21 +164,25 @@ func (c *Controller) sendVerificationSMS(ctx *gin.Context) {
 // @Produce json
 // @Param token path string true "Verification Token"
 // @Success 200 {object} pbuser.VerifyPhoneNumberResponse
-// @Failure 400 {object} _.ErrorResponse
-// @Failure 500 {object} _.ErrorResponse
+// @Failure 400 {object} _.Problem
+// @Failure 500 {object} _.Problem
 // @Router /api/v1/users/phone-numbers/verify/{token} [post]
 func (c *Controller) verifyPhoneNumber(ctx *gin.Context) {
 	var request pbuser.VerifyPhoneNumberRequest
 
 	// Prepare the gRPC context
-	grpcCtx, err := commongrpcclientctx.PrepareCtx(ctx, &request)
+	grpcCtx, err := appgrpcclientctx.PrepareCtx(ctx, &request)
 	if err != nil {
 		c.responseHandler.HandlePrepareCtxError(ctx, err)
 		return
 	}
 
 	// Add the token to the request
-	request.Token = ctx.Param(pbtypesrest.Token.String())
+	request.Token, err = appparams.Token.Get(ctx)
+	if err != nil {
+		c.responseHandler.HandleBadRequest(ctx, err)
+		return
+	}
 
 	// Verify the user's phone number
 	response, err := c.client.VerifyPhoneNumber(grpcCtx, &request)
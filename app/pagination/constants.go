@@ -0,0 +1,45 @@
+package pagination
+
+const (
+	// LimitQuery is the query parameter with the maximum number of items of a page
+	LimitQuery = "limit"
+
+	// CursorQuery is the query parameter with the cursor of a page, as returned in the previous one
+	CursorQuery = "cursor"
+
+	// SortQuery is the query parameter with the field the items are sorted by, prefixed by a minus sign to sort them
+	// in descending order
+	SortQuery = "sort"
+
+	// NextCursorKey is the key of the cursor of the next page in the response body, and the name of the field with
+	// the cursor of the next page in the responses of the RPCs that support paging
+	NextCursorKey = "next_cursor"
+
+	// LinkHeader is the header with the links to the first and the next pages
+	LinkHeader = "Link"
+
+	// DefaultLimit is the number of items of a page without a limit
+	DefaultLimit = 20
+
+	// MaxLimit is the maximum number of items of a page
+	MaxLimit = 100
+)
+
+const (
+	// offsetCursorPrefix is the prefix of the cursors of the pages sliced by the gateway
+	offsetCursorPrefix = "o:"
+
+	// backendCursorPrefix is the prefix of the cursors returned by the RPCs that support paging
+	backendCursorPrefix = "b:"
+
+	// timestampName is the full name of the timestamp message, whose fields can be sorted by
+	timestampName = "google.protobuf.Timestamp"
+)
+
+var (
+	// limitFields are the names of the request fields the limit of a page is mapped onto
+	limitFields = []string{"limit", "page_size"}
+
+	// cursorFields are the names of the request fields the cursor of a page is mapped onto
+	cursorFields = []string{"cursor", "page_token"}
+)
@@ -0,0 +1,11 @@
+package pagination
+
+import (
+	"errors"
+)
+
+var (
+	InvalidLimitError  = errors.New("invalid limit, expected a number between 1 and 100")
+	InvalidCursorError = errors.New("invalid cursor")
+	InvalidSortError   = errors.New("invalid sort field")
+)
@@ -0,0 +1,70 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"github.com/gin-gonic/gin"
+	"strconv"
+	"strings"
+)
+
+// Page is a page of a list requested through the limit, cursor and sort query parameters. The cursors are opaque to
+// the clients: they either hold the offset of a page sliced by the gateway or the cursor returned by an RPC that
+// supports paging
+type Page struct {
+	Limit         int
+	Offset        int
+	BackendCursor string
+	Sort          string
+	Descending    bool
+}
+
+// Parse parses the page requested by the query parameters of the request
+func Parse(ctx *gin.Context) (*Page, error) {
+	page := &Page{Limit: DefaultLimit}
+
+	// Parse the limit
+	if limit := ctx.Query(LimitQuery); limit != "" {
+		parsedLimit, err := strconv.Atoi(limit)
+		if err != nil || parsedLimit < 1 || parsedLimit > MaxLimit {
+			return nil, InvalidLimitError
+		}
+		page.Limit = parsedLimit
+	}
+
+	// Parse the cursor
+	if cursor := ctx.Query(CursorQuery); cursor != "" {
+		decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+		if err != nil {
+			return nil, InvalidCursorError
+		}
+		if offset, ok := strings.CutPrefix(string(decoded), offsetCursorPrefix); ok {
+			page.Offset, err = strconv.Atoi(offset)
+			if err != nil || page.Offset < 0 {
+				return nil, InvalidCursorError
+			}
+		} else if backendCursor, ok := strings.CutPrefix(string(decoded), backendCursorPrefix); ok {
+			page.BackendCursor = backendCursor
+		} else {
+			return nil, InvalidCursorError
+		}
+	}
+
+	// Parse the sort field
+	if sort := ctx.Query(SortQuery); sort != "" {
+		page.Sort, page.Descending = strings.CutPrefix(sort, "-")
+		if page.Sort == "" {
+			return nil, InvalidSortError
+		}
+	}
+	return page, nil
+}
+
+// encodeOffsetCursor encodes the cursor of the page sliced by the gateway at the given offset
+func encodeOffsetCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(offsetCursorPrefix + strconv.Itoa(offset)))
+}
+
+// encodeBackendCursor encodes the cursor returned by an RPC that supports paging
+func encodeBackendCursor(cursor string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(backendCursorPrefix + cursor))
+}
@@ -0,0 +1,222 @@
+package pagination
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"sort"
+	"strings"
+)
+
+// MapRequest maps the page onto the limit and cursor fields of the request of an RPC, and returns whether the RPC
+// supports paging. The RPCs that do not have both fields return every item, which are sliced by the gateway
+func (p *Page) MapRequest(request proto.Message) bool {
+	if request == nil {
+		return false
+	}
+	message := request.ProtoReflect()
+	limitField := findField(message.Descriptor(), limitFields, isIntegerKind)
+	cursorField := findField(message.Descriptor(), cursorFields, isStringKind)
+	if limitField == nil || cursorField == nil {
+		return false
+	}
+
+	message.Set(limitField, integerValue(limitField.Kind(), int64(p.Limit)))
+	if p.BackendCursor != "" {
+		message.Set(cursorField, protoreflect.ValueOfString(p.BackendCursor))
+	}
+	return true
+}
+
+// apply slices and sorts the items of the response, unless the RPC already paged them, and returns the cursor of the
+// next page, if any
+func (p *Page) apply(response proto.Message, paged bool) (string, error) {
+	message := response.ProtoReflect()
+
+	// Forward the cursor of the RPCs that support paging
+	if paged {
+		field := message.Descriptor().Fields().ByName(NextCursorKey)
+		if field == nil || !isStringKind(field.Kind()) {
+			return "", nil
+		}
+		if cursor := message.Get(field).String(); cursor != "" {
+			return encodeBackendCursor(cursor), nil
+		}
+		return "", nil
+	}
+
+	// The cursors returned by the RPCs that support paging cannot be used to slice the items
+	if p.BackendCursor != "" {
+		return "", InvalidCursorError
+	}
+
+	field := ItemsField(message.Descriptor())
+	if field == nil {
+		return "", nil
+	}
+	list := message.Get(field).List()
+	items := make([]protoreflect.Value, list.Len())
+	for i := range items {
+		items[i] = list.Get(i)
+	}
+
+	// Sort the items by the requested field
+	if p.Sort != "" {
+		sortField := findField(field.Message(), []string{p.Sort}, isSortableKind)
+		if sortField == nil || (sortField.Kind() == protoreflect.MessageKind && sortField.Message().FullName() != timestampName) {
+			return "", InvalidSortError
+		}
+		sort.SliceStable(
+			items, func(i, j int) bool {
+				comparison := compare(sortField, items[i].Message().Get(sortField), items[j].Message().Get(sortField))
+				if p.Descending {
+					return comparison > 0
+				}
+				return comparison < 0
+			},
+		)
+	}
+
+	// Slice the items of the page
+	start := min(p.Offset, len(items))
+	end := min(start+p.Limit, len(items))
+	message.Clear(field)
+	if end > start {
+		sliced := message.Mutable(field).List()
+		for _, item := range items[start:end] {
+			sliced.Append(item)
+		}
+	}
+
+	if end < len(items) {
+		return encodeOffsetCursor(end), nil
+	}
+	return "", nil
+}
+
+// ItemsField returns the only repeated message field of a response, which holds the items of the list, or nil if
+// there is not exactly one
+func ItemsField(descriptor protoreflect.MessageDescriptor) protoreflect.FieldDescriptor {
+	var found protoreflect.FieldDescriptor
+	fields := descriptor.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if !field.IsList() || field.Kind() != protoreflect.MessageKind {
+			continue
+		}
+		if found != nil {
+			return nil
+		}
+		found = field
+	}
+	return found
+}
+
+// findField returns the first singular field of the message with one of the given names and a matching kind, or nil
+// if there is none
+func findField(
+	descriptor protoreflect.MessageDescriptor,
+	names []string,
+	matchesKind func(protoreflect.Kind) bool,
+) protoreflect.FieldDescriptor {
+	for _, name := range names {
+		field := descriptor.Fields().ByName(protoreflect.Name(name))
+		if field == nil {
+			field = descriptor.Fields().ByJSONName(name)
+		}
+		if field != nil && !field.IsList() && !field.IsMap() && matchesKind(field.Kind()) {
+			return field
+		}
+	}
+	return nil
+}
+
+// isIntegerKind checks if the kind is an integer one
+func isIntegerKind(kind protoreflect.Kind) bool {
+	switch kind {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return true
+	}
+	return false
+}
+
+// isStringKind checks if the kind is the string one
+func isStringKind(kind protoreflect.Kind) bool {
+	return kind == protoreflect.StringKind
+}
+
+// isSortableKind checks if the fields of the kind can be sorted by
+func isSortableKind(kind protoreflect.Kind) bool {
+	switch kind {
+	case protoreflect.StringKind, protoreflect.BoolKind, protoreflect.EnumKind, protoreflect.FloatKind,
+		protoreflect.DoubleKind, protoreflect.MessageKind:
+		return true
+	}
+	return isIntegerKind(kind)
+}
+
+// integerValue returns the value of an integer field of the given kind
+func integerValue(kind protoreflect.Kind, value int64) protoreflect.Value {
+	switch kind {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(int32(value))
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(uint32(value))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return protoreflect.ValueOfUint64(uint64(value))
+	default:
+		return protoreflect.ValueOfInt64(value)
+	}
+}
+
+// compare compares two values of the given field, returning a negative number if the first one goes first, a positive
+// one if it goes last or zero if they are equal
+func compare(field protoreflect.FieldDescriptor, a, b protoreflect.Value) int {
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		return strings.Compare(a.String(), b.String())
+	case protoreflect.BoolKind:
+		return compareOrdered(boolToInt(a.Bool()), boolToInt(b.Bool()))
+	case protoreflect.EnumKind:
+		return compareOrdered(a.Enum(), b.Enum())
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return compareOrdered(a.Float(), b.Float())
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return compareOrdered(a.Uint(), b.Uint())
+	case protoreflect.MessageKind:
+		return compareTimestamps(a.Message(), b.Message())
+	default:
+		return compareOrdered(a.Int(), b.Int())
+	}
+}
+
+// compareTimestamps compares two timestamps by their seconds and nanoseconds
+func compareTimestamps(a, b protoreflect.Message) int {
+	fields := a.Descriptor().Fields()
+	seconds, nanos := fields.ByName("seconds"), fields.ByName("nanos")
+	if comparison := compareOrdered(a.Get(seconds).Int(), b.Get(seconds).Int()); comparison != 0 {
+		return comparison
+	}
+	return compareOrdered(a.Get(nanos).Int(), b.Get(nanos).Int())
+}
+
+// compareOrdered compares two ordered values
+func compareOrdered[T int | int64 | uint64 | float64 | protoreflect.EnumNumber](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// boolToInt converts false to 0 and true to 1
+func boolToInt(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,70 @@
+package pagination
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/proto"
+	"net/url"
+	"strings"
+)
+
+// Encoder encodes the responses as JSON, naming their fields like the JSON encoding does
+type Encoder interface {
+	EncodeJSON(message proto.Message) ([]byte, error)
+	JSONName(name string) string
+}
+
+// Apply leaves the items of the page in the response and sets the links to the first and the next pages, returning
+// the cursor of the next page, if any. The paged flag tells whether the RPC already paged the items, as returned by
+// MapRequest
+func (p *Page) Apply(ctx *gin.Context, response proto.Message, paged bool) (string, error) {
+	nextCursor, err := p.apply(response, paged)
+	if err != nil {
+		return "", err
+	}
+
+	// Set the links to the first and the next pages
+	links := []string{link(ctx, "", "first")}
+	if nextCursor != "" {
+		links = append(links, link(ctx, nextCursor, "next"))
+	}
+	ctx.Header(LinkHeader, strings.Join(links, ", "))
+	return nextCursor, nil
+}
+
+// Body returns the JSON body of the page of the response with the cursor of the next page, encoded by the given
+// encoder, and sets the links to the first and the next pages
+func (p *Page) Body(ctx *gin.Context, response proto.Message, paged bool, encoder Encoder) (map[string]any, error) {
+	nextCursor, err := p.Apply(ctx, response, paged)
+	if err != nil {
+		return nil, err
+	}
+
+	// Encode the response like the other ones, adding the cursor of the next page
+	encoded, err := encoder.EncodeJSON(response)
+	if err != nil {
+		return nil, err
+	}
+	body := make(map[string]any)
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	if err = decoder.Decode(&body); err != nil {
+		return nil, err
+	}
+	if nextCursor != "" {
+		body[encoder.JSONName(NextCursorKey)] = nextCursor
+	}
+	return body, nil
+}
+
+// link returns the link to the page of the request with the given cursor and relation type
+func link(ctx *gin.Context, cursor, relation string) string {
+	query := ctx.Request.URL.Query()
+	query.Del(CursorQuery)
+	if cursor != "" {
+		query.Set(CursorQuery, cursor)
+	}
+	target := url.URL{Path: ctx.Request.URL.Path, RawQuery: query.Encode()}
+	return "<" + target.String() + `>; rel="` + relation + `"`
+}
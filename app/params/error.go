@@ -0,0 +1,21 @@
+package params
+
+import (
+	"fmt"
+)
+
+// Error is the error of a path parameter without the format it was declared with
+type Error struct {
+	Param       string
+	Description string
+}
+
+// Error returns the message of the error, naming the parameter
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s %q: %s", InvalidParamError, e.Param, e.Description)
+}
+
+// Unwrap returns the generic error of the invalid path parameters
+func (e *Error) Unwrap() error {
+	return InvalidParamError
+}
@@ -0,0 +1,11 @@
+package params
+
+import (
+	"errors"
+)
+
+var (
+	InvalidParamError    = errors.New("invalid path parameter")
+	MissingParamError    = errors.New("declared path parameter missing in the endpoint of its route")
+	UndeclaredParamError = errors.New("path parameter of the endpoint of a registered route not declared")
+)
@@ -0,0 +1,37 @@
+package params
+
+import (
+	appvalidation "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/validation"
+	"strings"
+)
+
+// Format is the format of the values of a path parameter
+type Format struct {
+	description string
+	matches     func(value string) bool
+}
+
+var (
+	// UUIDFormat is the format of the IDs of the auth and user services, which are UUIDs in their canonical form
+	UUIDFormat = Format{description: "must be a valid UUID", matches: appvalidation.IsUUID}
+
+	// ObjectIDFormat is the format of the IDs of the shop, order and payment services, which are MongoDB ObjectIDs
+	ObjectIDFormat = Format{description: "must be a valid ObjectID", matches: appvalidation.IsObjectID}
+
+	// UsernameFormat is the format of the usernames
+	UsernameFormat = Format{
+		description: "must have between 3 and 32 letters, digits, dots, dashes or underscores",
+		matches:     appvalidation.IsUsername,
+	}
+
+	// EmailFormat is the format of the email addresses
+	EmailFormat = Format{description: "must be a valid email address", matches: appvalidation.IsEmail}
+
+	// TokenFormat is the format of the opaque tokens, like the ones sent to verify an email, which must not be blank
+	TokenFormat = Format{
+		description: "must not be empty",
+		matches: func(value string) bool {
+			return strings.TrimSpace(value) != ""
+		},
+	}
+)
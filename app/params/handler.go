@@ -0,0 +1,66 @@
+package params
+
+import (
+	"github.com/gin-gonic/gin"
+	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
+	typesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
+	"sync"
+)
+
+var (
+	// registeredMutex guards the registered mappers
+	registeredMutex sync.Mutex
+
+	// registered are the mappers the routes created by the route handlers are registered with
+	registered = make(map[*typesrest.Mapper]bool)
+)
+
+// RouteHandler creates the endpoints like the wrapped route handler does, recording the mappers they are registered
+// with, so their path parameters can be checked against the declared ones
+type RouteHandler struct {
+	commonhandler.Handler
+}
+
+// NewRouteHandler creates a new route handler that records the mappers of the endpoints created by the given one
+func NewRouteHandler(handler commonhandler.Handler) *RouteHandler {
+	return &RouteHandler{Handler: handler}
+}
+
+// CreateAuthenticatedEndpoint creates the authenticated endpoint, recording its mapper
+func (r *RouteHandler) CreateAuthenticatedEndpoint(mapper *typesrest.Mapper, handler gin.HandlerFunc) (
+	string,
+	gin.HandlerFunc,
+	gin.HandlerFunc,
+) {
+	register(mapper)
+	return r.Handler.CreateAuthenticatedEndpoint(mapper, handler)
+}
+
+// CreateUnauthenticatedEndpoint creates the unauthenticated endpoint, recording its mapper
+func (r *RouteHandler) CreateUnauthenticatedEndpoint(mapper *typesrest.Mapper, handler gin.HandlerFunc) (
+	string,
+	gin.HandlerFunc,
+) {
+	register(mapper)
+	return r.Handler.CreateUnauthenticatedEndpoint(mapper, handler)
+}
+
+// Registered returns the mappers the routes created by the route handlers are registered with
+func Registered() []*typesrest.Mapper {
+	registeredMutex.Lock()
+	defer registeredMutex.Unlock()
+
+	mappers := make([]*typesrest.Mapper, 0, len(registered))
+	for mapper := range registered {
+		mappers = append(mappers, mapper)
+	}
+	return mappers
+}
+
+// register records the mapper a route is registered with
+func register(mapper *typesrest.Mapper) {
+	registeredMutex.Lock()
+	defer registeredMutex.Unlock()
+
+	registered[mapper] = true
+}
@@ -0,0 +1,42 @@
+package params
+
+import (
+	"github.com/gin-gonic/gin"
+	typesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
+)
+
+// Param is a path parameter declared with the format of its values
+type Param struct {
+	name   string
+	format Format
+}
+
+// NewParam declares the path parameter with the given name, as written in the route templates, and format
+func NewParam(name string, format Format) Param {
+	return Param{name: name, format: format}
+}
+
+// Name returns the name of the path parameter
+func (p Param) Name() string {
+	return p.name
+}
+
+// Get returns the value of the path parameter in the request, or an *Error naming the parameter if the value does
+// not have its format
+func (p Param) Get(ctx *gin.Context) (string, error) {
+	value := ctx.Param(p.name)
+	if !p.format.matches(value) {
+		return "", &Error{Param: p.name, Description: p.format.description}
+	}
+	return value, nil
+}
+
+// in checks if the path parameter is one of the parameters of the given endpoint
+func (p Param) in(endpoint *typesrest.Endpoint) bool {
+	for _, param := range endpoint.Params {
+		if param.String() == p.name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package params
+
+import (
+	typesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
+)
+
+var (
+	// UserID is the ID of a user
+	UserID = NewParam(typesrest.UserId.String(), UUIDFormat)
+
+	// RoleID is the ID of a role
+	RoleID = NewParam(typesrest.RoleId.String(), UUIDFormat)
+
+	// PermissionID is the ID of a permission
+	PermissionID = NewParam(typesrest.PermissionId.String(), UUIDFormat)
+
+	// JwtID is the ID of an access or a refresh token
+	JwtID = NewParam(typesrest.JwtId.String(), UUIDFormat)
+
+	// Username is the username of a user
+	Username = NewParam(typesrest.Username.String(), UsernameFormat)
+
+	// Email is an email address of a user
+	Email = NewParam(typesrest.Email.String(), EmailFormat)
+
+	// Token is a token sent to a user to verify an email or a phone number, or to reset the password
+	Token = NewParam(typesrest.Token.String(), TokenFormat)
+
+	// BusinessID is the ID of a business
+	BusinessID = NewParam(typesrest.BusinessId.String(), ObjectIDFormat)
+
+	// BranchID is the ID of a branch
+	BranchID = NewParam(typesrest.BranchId.String(), ObjectIDFormat)
+
+	// BranchRentID is the ID of the rent of a branch
+	BranchRentID = NewParam(typesrest.BranchRentId.String(), ObjectIDFormat)
+
+	// StoreID is the ID of a store
+	StoreID = NewParam(typesrest.StoreId.String(), ObjectIDFormat)
+
+	// ProductID is the ID of a product
+	ProductID = NewParam(typesrest.ProductId.String(), ObjectIDFormat)
+
+	// BranchProductID is the ID of the product of a branch
+	BranchProductID = NewParam(typesrest.BranchProductId.String(), ObjectIDFormat)
+
+	// CategoryID is the ID of a product or market category
+	CategoryID = NewParam(typesrest.CategoryId.String(), ObjectIDFormat)
+
+	// CartID is the ID of a cart
+	CartID = NewParam(typesrest.CartId.String(), ObjectIDFormat)
+
+	// OrderID is the ID of an order
+	OrderID = NewParam(typesrest.OrderId.String(), ObjectIDFormat)
+
+	// PaymentAccountID is the ID of a payment account
+	PaymentAccountID = NewParam(typesrest.AccountId.String(), ObjectIDFormat)
+)
@@ -0,0 +1,154 @@
+package params
+
+import (
+	"errors"
+	"fmt"
+	appmapper "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/mapper"
+	pbconfigrestauthaccesstokens "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/auth/access-tokens"
+	pbconfigrestauthpermissions "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/auth/permissions"
+	pbconfigrestauthrefreshtokens "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/auth/refresh-tokens"
+	pbconfigrestauthrolepermissions "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/auth/role-permissions"
+	pbconfigrestauthroles "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/auth/roles"
+	pbconfigrestauthuserroles "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/auth/user-roles"
+	pbconfigrestorders "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/orders"
+	pbconfigrestorderscarts "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/orders/carts"
+	pbconfigrestpaymentsaccounts "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/payments/accounts"
+	pbconfigrestpaymentsbranchrents "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/payments/branch-rents"
+	pbconfigrestpaymentsorders "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/payments/orders"
+	pbconfigrestshopsbusinesses "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/shops/businesses"
+	pbconfigrestshopsbusinessesbranches "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/shops/businesses/branches"
+	pbconfigrestshopsbusinessesbranchesproducts "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/shops/businesses/branches/products"
+	pbconfigrestshopsbusinessesclients "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/shops/businesses/clients"
+	pbconfigrestshopsbusinessesmarkets "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/shops/businesses/markets"
+	pbconfigrestshopsbusinessesowners "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/shops/businesses/owners"
+	pbconfigrestshopsbusinessesproducts "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/shops/businesses/products"
+	pbconfigrestshopsmarketscategories "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/shops/markets/categories"
+	pbconfigrestshopsproducts "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/shops/products"
+	pbconfigrestshopsproductscategories "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/shops/products/categories"
+	pbconfigrestshopsstores "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/shops/stores"
+	pbconfigrestshopsstoresrents "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/shops/stores/rents"
+	pbconfigrestusers "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/users"
+	pbconfigrestusersemails "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/users/emails"
+	pbconfigrestusersphonenumbers "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/users/phone-numbers"
+	pbconfigrestusersprofiles "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/users/profiles"
+	pbconfigrestusersusernames "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/rest/api/v1/users/usernames"
+	typesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
+)
+
+// Routes are the path parameters read by the handler of each route, keyed by the mapper the route is registered with
+var Routes = map[*typesrest.Mapper][]Param{
+	// Auth
+	pbconfigrestauthaccesstokens.IsAccessTokenValidMapper:          {JwtID},
+	pbconfigrestauthpermissions.RevokePermissionMapper:             {PermissionID},
+	pbconfigrestauthpermissions.GetPermissionMapper:                {PermissionID},
+	pbconfigrestauthrefreshtokens.IsRefreshTokenValidMapper:        {JwtID},
+	pbconfigrestauthrefreshtokens.GetRefreshTokenInformationMapper: {JwtID},
+	pbconfigrestauthrefreshtokens.RevokeRefreshTokenMapper:         {JwtID},
+	pbconfigrestauthrolepermissions.RevokeRolePermissionMapper:     {RoleID},
+	pbconfigrestauthroles.AddRolePermissionMapper:                  {RoleID},
+	pbconfigrestauthroles.GetRolePermissionsMapper:                 {RoleID},
+	pbconfigrestauthroles.RevokeRoleMapper:                         {RoleID},
+	appmapper.AddUserRoleMapper:                                    {UserID},
+	appmapper.RevokeUserRoleMapper:                                 {UserID},
+	pbconfigrestauthuserroles.GetUserRolesMapper:                   {UserID},
+
+	// Users
+	pbconfigrestusers.GetUserIdByUsernameMapper:           {Username},
+	pbconfigrestusers.ResetPasswordMapper:                 {Token},
+	pbconfigrestusersemails.DeleteEmailMapper:             {Email},
+	pbconfigrestusersemails.VerifyEmailMapper:             {Token},
+	pbconfigrestusersphonenumbers.VerifyPhoneNumberMapper: {Token},
+	pbconfigrestusersprofiles.GetProfileMapper:            {Username},
+	pbconfigrestusersusernames.UsernameExistsMapper:       {Username},
+	pbconfigrestusersusernames.GetUsernameByUserIdMapper:  {UserID},
+
+	// Shops
+	pbconfigrestshopsbusinessesbranches.GetBranchMapper:                   {BranchID},
+	pbconfigrestshopsbusinessesbranches.GetBusinessBranchesMapper:         {BusinessID},
+	pbconfigrestshopsbusinessesbranches.UpdateBranchMapper:                {BranchID},
+	pbconfigrestshopsbusinessesbranches.CloseTemporarilyBranchMapper:      {BranchID},
+	pbconfigrestshopsbusinessesbranches.OpenBranchMapper:                  {BranchID},
+	pbconfigrestshopsbusinessesbranches.DeleteBranchMapper:                {BranchID},
+	appmapper.GetBranchProductMapper:                                      {BranchID, ProductID},
+	pbconfigrestshopsbusinessesbranchesproducts.AddBranchProductMapper:    {BranchID},
+	pbconfigrestshopsbusinessesbranchesproducts.UpdateBranchProductMapper: {BranchID},
+	pbconfigrestshopsbusinessesclients.AddBusinessClientMapper:            {BusinessID},
+	pbconfigrestshopsbusinessesclients.IsBusinessClientMapper:             {BusinessID},
+	pbconfigrestshopsbusinesses.GetBusinessMapper:                         {BusinessID},
+	pbconfigrestshopsbusinesses.UpdateBusinessMapper:                      {BusinessID},
+	pbconfigrestshopsbusinesses.SetBusinessProfilePictureMapper:           {BusinessID},
+	pbconfigrestshopsbusinesses.DeleteBusinessMapper:                      {BusinessID},
+	pbconfigrestshopsbusinessesmarkets.GetBusinessMarketCategoriesMapper:  {BusinessID},
+	pbconfigrestshopsbusinessesowners.AddBusinessOwnerMapper:              {BusinessID},
+	pbconfigrestshopsbusinessesowners.RemoveBusinessOwnerMapper:           {BusinessID},
+	pbconfigrestshopsbusinessesowners.GetBusinessOwnersMapper:             {BusinessID},
+	pbconfigrestshopsbusinessesproducts.GetBusinessProductMapper:          {ProductID},
+	pbconfigrestshopsbusinessesproducts.UpdateBusinessProductMapper:       {ProductID},
+	pbconfigrestshopsmarketscategories.GetMarketCategoryMapper:            {CategoryID},
+	pbconfigrestshopsmarketscategories.UpdateMarketCategoryMapper:         {CategoryID},
+	pbconfigrestshopsproductscategories.GetProductCategoryMapper:          {CategoryID},
+	pbconfigrestshopsproducts.GetProductMapper:                            {ProductID},
+	pbconfigrestshopsproducts.UpdateProductMapper:                         {ProductID},
+	pbconfigrestshopsstores.GetStoreMapper:                                {StoreID},
+	pbconfigrestshopsstores.DeleteStoreMapper:                             {StoreID},
+	pbconfigrestshopsstoresrents.GetBranchRentsMapper:                     {BranchID},
+	pbconfigrestshopsstoresrents.GetUnpaidBranchRentsMapper:               {BranchID},
+	pbconfigrestshopsstoresrents.GetBusinessUnpaidBranchRentsMapper:       {BusinessID},
+
+	// Orders
+	pbconfigrestorderscarts.GetCartMapper:      {CartID},
+	pbconfigrestorderscarts.GetCartTotalMapper: {CartID},
+	appmapper.RemoveProductFromCartMapper:      {BranchProductID},
+	pbconfigrestorders.GetOrderMapper:          {OrderID},
+
+	// Payments
+	pbconfigrestpaymentsaccounts.ActivatePaymentAccountMapper:   {PaymentAccountID},
+	pbconfigrestpaymentsaccounts.SuspendPaymentAccountMapper:    {PaymentAccountID},
+	pbconfigrestpaymentsbranchrents.AddBranchRentPaymentMapper:  {BranchRentID},
+	pbconfigrestpaymentsbranchrents.GetBranchRentPaymentsMapper: {BranchRentID},
+	pbconfigrestpaymentsbranchrents.PayForBranchRentMapper:      {BranchRentID},
+	pbconfigrestpaymentsorders.AddOrderPaymentMapper:            {OrderID},
+	pbconfigrestpaymentsorders.GetOrderPaymentsMapper:           {OrderID},
+	pbconfigrestpaymentsorders.PayForOrderMapper:                {OrderID},
+}
+
+// Check checks that the endpoint of every mapper with declared path parameters has each of them, so the handlers
+// only read the parameters of their route template, and that every path parameter of the endpoint of the registered
+// mappers is declared, so none of them is ignored by its handler. Every mismatch is reported at once
+func Check(declared map[*typesrest.Mapper][]Param, registered []*typesrest.Mapper) error {
+	var errs []error
+	for mapper, params := range declared {
+		for _, param := range params {
+			if !param.in(mapper.Endpoint) {
+				errs = append(errs, fmt.Errorf("%w: %q of %q", MissingParamError, param.name, mapper.Path()))
+			}
+		}
+	}
+	for _, mapper := range registered {
+		for _, endpointParam := range mapper.Endpoint.Params {
+			if !isDeclared(declared[mapper], endpointParam.String()) {
+				errs = append(
+					errs,
+					fmt.Errorf(
+						"%w: %q of %q mapped to %q",
+						UndeclaredParamError,
+						endpointParam.String(),
+						mapper.Path(),
+						mapper.GRPCMethod.String(),
+					),
+				)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// isDeclared checks if the path parameter with the given name is one of the declared ones
+func isDeclared(params []Param, name string) bool {
+	for _, param := range params {
+		if param.name == name {
+			return true
+		}
+	}
+	return false
+}
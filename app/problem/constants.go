@@ -0,0 +1,70 @@
+package problem
+
+const (
+	// ContentType is the content type of the problem details, as defined by RFC 7807
+	ContentType = "application/problem+json"
+
+	// TypePrefix is the prefix of the type URIs of the problems, followed by their error code in kebab case
+	TypePrefix = "urn:pixel-plaza:problem:"
+
+	// RetryAfterHeader is the header with the seconds to wait before retrying a rejected request
+	RetryAfterHeader = "Retry-After"
+)
+
+const (
+	// CanceledCode is the error code of the canceled requests
+	CanceledCode = "CANCELED"
+
+	// UnknownCode is the error code of the unknown errors
+	UnknownCode = "UNKNOWN"
+
+	// InvalidArgumentCode is the error code of the requests with invalid arguments
+	InvalidArgumentCode = "INVALID_ARGUMENT"
+
+	// DeadlineExceededCode is the error code of the requests that were not answered in time
+	DeadlineExceededCode = "DEADLINE_EXCEEDED"
+
+	// NotFoundCode is the error code of the requests for resources that do not exist
+	NotFoundCode = "NOT_FOUND"
+
+	// AlreadyExistsCode is the error code of the requests that create a resource that already exists
+	AlreadyExistsCode = "ALREADY_EXISTS"
+
+	// PermissionDeniedCode is the error code of the requests the caller is not allowed to make
+	PermissionDeniedCode = "PERMISSION_DENIED"
+
+	// ResourceExhaustedCode is the error code of the requests rejected because a quota was exhausted
+	ResourceExhaustedCode = "RESOURCE_EXHAUSTED"
+
+	// FailedPreconditionCode is the error code of the requests rejected by the state of the system
+	FailedPreconditionCode = "FAILED_PRECONDITION"
+
+	// AbortedCode is the error code of the requests aborted by a concurrent one
+	AbortedCode = "ABORTED"
+
+	// OutOfRangeCode is the error code of the requests with arguments past the valid range
+	OutOfRangeCode = "OUT_OF_RANGE"
+
+	// UnimplementedCode is the error code of the requests for operations that are not implemented
+	UnimplementedCode = "UNIMPLEMENTED"
+
+	// InternalCode is the error code of the internal errors
+	InternalCode = "INTERNAL"
+
+	// UnavailableCode is the error code of the requests rejected because a service is unavailable
+	UnavailableCode = "UNAVAILABLE"
+
+	// DataLossCode is the error code of the unrecoverable data losses
+	DataLossCode = "DATA_LOSS"
+
+	// UnauthenticatedCode is the error code of the requests without valid credentials
+	UnauthenticatedCode = "UNAUTHENTICATED"
+
+	// MethodNotAllowedCode is the error code of the requests whose route does not accept their method
+	MethodNotAllowedCode = "METHOD_NOT_ALLOWED"
+)
+
+const (
+	// StatusClientClosedRequest is the non-standard HTTP status of the requests canceled by the client
+	StatusClientClosedRequest = 499
+)
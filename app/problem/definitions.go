@@ -0,0 +1,111 @@
+package problem
+
+import (
+	"google.golang.org/grpc/codes"
+	"net/http"
+)
+
+// definition is the HTTP status, the error code and the title of the problems of a gRPC status code. The messages of
+// the internal errors are not exposed, since they may leak the details of the backend services
+type definition struct {
+	status   int
+	code     string
+	title    string
+	internal bool
+}
+
+// definitions are the problem definitions of every gRPC status code but OK
+var definitions = map[codes.Code]definition{
+	codes.Canceled: {
+		status: StatusClientClosedRequest,
+		code:   CanceledCode,
+		title:  "Request canceled",
+	},
+	codes.Unknown: {
+		status:   http.StatusInternalServerError,
+		code:     UnknownCode,
+		title:    "Unknown error",
+		internal: true,
+	},
+	codes.InvalidArgument: {
+		status: http.StatusBadRequest,
+		code:   InvalidArgumentCode,
+		title:  "Invalid argument",
+	},
+	codes.DeadlineExceeded: {
+		status: http.StatusGatewayTimeout,
+		code:   DeadlineExceededCode,
+		title:  "Deadline exceeded",
+	},
+	codes.NotFound: {
+		status: http.StatusNotFound,
+		code:   NotFoundCode,
+		title:  "Not found",
+	},
+	codes.AlreadyExists: {
+		status: http.StatusConflict,
+		code:   AlreadyExistsCode,
+		title:  "Already exists",
+	},
+	codes.PermissionDenied: {
+		status: http.StatusForbidden,
+		code:   PermissionDeniedCode,
+		title:  "Permission denied",
+	},
+	codes.ResourceExhausted: {
+		status: http.StatusTooManyRequests,
+		code:   ResourceExhaustedCode,
+		title:  "Resource exhausted",
+	},
+	codes.FailedPrecondition: {
+		status: http.StatusBadRequest,
+		code:   FailedPreconditionCode,
+		title:  "Failed precondition",
+	},
+	codes.Aborted: {
+		status: http.StatusConflict,
+		code:   AbortedCode,
+		title:  "Aborted",
+	},
+	codes.OutOfRange: {
+		status: http.StatusBadRequest,
+		code:   OutOfRangeCode,
+		title:  "Out of range",
+	},
+	codes.Unimplemented: {
+		status: http.StatusNotImplemented,
+		code:   UnimplementedCode,
+		title:  "Not implemented",
+	},
+	codes.Internal: {
+		status:   http.StatusInternalServerError,
+		code:     InternalCode,
+		title:    "Internal error",
+		internal: true,
+	},
+	codes.Unavailable: {
+		status: http.StatusServiceUnavailable,
+		code:   UnavailableCode,
+		title:  "Service unavailable",
+	},
+	codes.DataLoss: {
+		status:   http.StatusInternalServerError,
+		code:     DataLossCode,
+		title:    "Data loss",
+		internal: true,
+	},
+	codes.Unauthenticated: {
+		status: http.StatusUnauthorized,
+		code:   UnauthenticatedCode,
+		title:  "Unauthenticated",
+	},
+}
+
+// titles are the titles of the problems indexed by their error code
+var titles = func() map[string]string {
+	titles := make(map[string]string, len(definitions))
+	for _, d := range definitions {
+		titles[d.code] = d.title
+	}
+	return titles
+}()
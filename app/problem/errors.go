@@ -0,0 +1,11 @@
+package problem
+
+import (
+	"errors"
+)
+
+var (
+	InternalError         = errors.New("the request could not be handled due to an internal error")
+	RouteNotFoundError    = errors.New("no route matches the path of the request")
+	MethodNotAllowedError = errors.New("the route does not accept the method of the request")
+)
@@ -0,0 +1,49 @@
+package problem
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// ResponseHandler answers the errors of the common middlewares, like the authentication one, with problem details
+// instead of the error responses of the common response handler
+type ResponseHandler struct{}
+
+// NewResponseHandler creates a new problem details response handler
+func NewResponseHandler() *ResponseHandler {
+	return &ResponseHandler{}
+}
+
+// HandlePrepareCtxError writes the error returned while preparing the context of a backend call
+func (h *ResponseHandler) HandlePrepareCtxError(ctx *gin.Context, err error) {
+	New(http.StatusBadRequest, InvalidArgumentCode, err).Write(ctx)
+}
+
+// HandleResponse writes the response with the given status code, or its error
+func (h *ResponseHandler) HandleResponse(ctx *gin.Context, code int, response interface{}, err error) {
+	if err != nil {
+		h.HandleErrorResponse(ctx, err)
+		return
+	}
+	ctx.JSON(code, response)
+}
+
+// HandleErrorResponse writes the error, mapping its gRPC status to the problem
+func (h *ResponseHandler) HandleErrorResponse(ctx *gin.Context, err error) {
+	FromError(err).Write(ctx)
+}
+
+// Recovery answers the requests whose handlers panicked with an internal error, once the panic was recovered
+func Recovery(ctx *gin.Context, _ any) {
+	New(http.StatusInternalServerError, InternalCode, InternalError).Abort(ctx)
+}
+
+// NoRoute answers the requests whose path does not match any route
+func NoRoute(ctx *gin.Context) {
+	New(http.StatusNotFound, NotFoundCode, RouteNotFoundError).Abort(ctx)
+}
+
+// NoMethod answers the requests whose path matches a route of another method
+func NoMethod(ctx *gin.Context) {
+	New(http.StatusMethodNotAllowed, MethodNotAllowedCode, MethodNotAllowedError).Abort(ctx)
+}
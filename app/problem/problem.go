@@ -0,0 +1,144 @@
+package problem
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	apprequestid "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/requestid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// Problem is the body of every error response, as defined by RFC 7807, extended with the stable error code the
+	// clients are expected to handle, the errors of the invalid fields and the ID of the request
+	Problem struct {
+		Type      string       `json:"type" example:"urn:pixel-plaza:problem:invalid-argument"`
+		Title     string       `json:"title" example:"Invalid argument"`
+		Status    int          `json:"status" example:"400"`
+		Detail    string       `json:"detail,omitempty" example:"the request has invalid fields"`
+		Instance  string       `json:"instance,omitempty" example:"/api/v1/users/sign-up"`
+		Code      string       `json:"code" example:"INVALID_ARGUMENT"`
+		Errors    []FieldError `json:"errors,omitempty"`
+		RequestID string       `json:"request_id,omitempty" example:"3f2c9a4e8b7d4c1a9e6f0b2d5a8c7e1f"`
+
+		// retryAfter is the time the client must wait before retrying the request, if any
+		retryAfter time.Duration
+	}
+
+	// FieldError is the error of an invalid field of the request
+	FieldError struct {
+		Field  string `json:"field" example:"email"`
+		Detail string `json:"detail" example:"invalid email address"`
+	}
+)
+
+// New creates the problem of an error answered by the gateway itself, with the given HTTP status and error code
+func New(status int, code string, err error) *Problem {
+	title, ok := titles[code]
+	if !ok {
+		title = http.StatusText(status)
+	}
+
+	problem := &Problem{
+		Type:   TypeURI(code),
+		Title:  title,
+		Status: status,
+		Code:   code,
+	}
+	if err != nil {
+		problem.Detail = err.Error()
+	}
+	return problem
+}
+
+// FromError creates the problem of an error returned by a backend service, mapping its gRPC status to the HTTP status
+// and the error code. Its error details are mapped too: the field violations of a BadRequest and the violations of a
+// PreconditionFailure to the field errors, the reason of an ErrorInfo to the error code, the message of a
+// LocalizedMessage to the detail and the delay of a RetryInfo to the Retry-After header
+func FromError(err error) *Problem {
+	s := status.Convert(err)
+	d, ok := definitions[s.Code()]
+	if !ok {
+		d = definitions[codes.Unknown]
+	}
+
+	problem := New(d.status, d.code, nil)
+	if d.internal {
+		problem.Detail = InternalError.Error()
+		return problem
+	}
+	problem.Detail = s.Message()
+
+	for _, detail := range s.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.BadRequest:
+			for _, violation := range detail.GetFieldViolations() {
+				problem.Errors = append(
+					problem.Errors,
+					FieldError{Field: violation.GetField(), Detail: violation.GetDescription()},
+				)
+			}
+		case *errdetails.PreconditionFailure:
+			for _, violation := range detail.GetViolations() {
+				problem.Errors = append(
+					problem.Errors,
+					FieldError{Field: violation.GetSubject(), Detail: violation.GetDescription()},
+				)
+			}
+		case *errdetails.ErrorInfo:
+			if reason := detail.GetReason(); reason != "" {
+				problem.Code = reason
+				problem.Type = TypeURI(reason)
+			}
+		case *errdetails.LocalizedMessage:
+			if message := detail.GetMessage(); message != "" {
+				problem.Detail = message
+			}
+		case *errdetails.RetryInfo:
+			problem.retryAfter = detail.GetRetryDelay().AsDuration()
+		}
+	}
+	return problem
+}
+
+// TypeURI returns the type URI of the problems with the given error code
+func TypeURI(code string) string {
+	return TypePrefix + strings.ToLower(strings.ReplaceAll(code, "_", "-"))
+}
+
+// WithErrors adds the errors of the invalid fields to the problem
+func (p *Problem) WithErrors(errors ...FieldError) *Problem {
+	p.Errors = append(p.Errors, errors...)
+	return p
+}
+
+// WithRetryAfter sets the time the client must wait before retrying the request
+func (p *Problem) WithRetryAfter(retryAfter time.Duration) *Problem {
+	p.retryAfter = retryAfter
+	return p
+}
+
+// Write writes the problem as the response of the request, with the path of the request as its instance and the ID
+// of the request
+func (p *Problem) Write(ctx *gin.Context) {
+	p.Instance = ctx.Request.URL.Path
+	p.RequestID = apprequestid.FromGinContext(ctx)
+	if p.retryAfter > 0 {
+		ctx.Header(RetryAfterHeader, strconv.Itoa(int(math.Ceil(p.retryAfter.Seconds()))))
+	}
+
+	body, _ := json.Marshal(p)
+	ctx.Data(p.Status, ContentType, body)
+}
+
+// Abort writes the problem as the response of the request and stops the handlers chain
+func (p *Problem) Abort(ctx *gin.Context) {
+	ctx.Abort()
+	p.Write(ctx)
+}
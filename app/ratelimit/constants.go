@@ -0,0 +1,52 @@
+package ratelimit
+
+import (
+	"time"
+)
+
+const (
+	// EnabledKey is the key of the flag that enables the rate limits
+	EnabledKey = "RATE_LIMIT_ENABLED"
+
+	// BackendKey is the key of the backend the token buckets are stored in
+	BackendKey = "RATE_LIMIT_BACKEND"
+
+	// MemoryBackend stores the token buckets in the memory of each gateway instance
+	MemoryBackend = "memory"
+
+	// RedisBackend stores the token buckets in Redis, sharing them between the gateway instances
+	RedisBackend = "redis"
+
+	// DefaultRouteKey is the key of the token bucket shared by the routes without their own limit
+	DefaultRouteKey = "*"
+)
+
+const (
+	// LimitHeader is the header with the capacity of the token bucket
+	LimitHeader = "RateLimit-Limit"
+
+	// RemainingHeader is the header with the number of requests left in the token bucket
+	RemainingHeader = "RateLimit-Remaining"
+
+	// ResetHeader is the header with the number of seconds until the token bucket is full again
+	ResetHeader = "RateLimit-Reset"
+
+	// PolicyHeader is the header with the limit applied to the route
+	PolicyHeader = "RateLimit-Policy"
+
+	// RetryAfterHeader is the header with the number of seconds until the next request is allowed
+	RetryAfterHeader = "Retry-After"
+)
+
+const (
+	// ExceededErrorCode is the error code of the requests rejected by the rate limits
+	ExceededErrorCode = "RATE_LIMIT_EXCEEDED"
+)
+
+const (
+	// keyPrefix is the prefix of the keys of the token buckets
+	keyPrefix = "ratelimit:"
+
+	// cleanupInterval is the interval the full token buckets are removed from the memory store at
+	cleanupInterval = time.Minute
+)
@@ -0,0 +1,15 @@
+package ratelimit
+
+import (
+	"errors"
+)
+
+var (
+	NilLoggerError            = errors.New("nil logger")
+	NilStoreError             = errors.New("nil rate limit store")
+	NilIdentifierError        = errors.New("nil JWT identifier")
+	NilRedisClientError       = errors.New("nil Redis client")
+	InvalidLimitError         = errors.New("invalid rate limit, the requests, period and burst must be positive")
+	TooManyRequestsError      = errors.New("too many requests, try again later")
+	UnexpectedRedisReplyError = errors.New("unexpected Redis reply")
+)
@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+type (
+	// Limit is a token bucket limit, which allows Requests requests every Period with bursts of up to Burst requests
+	Limit struct {
+		Requests int
+		Period   time.Duration
+		Burst    int
+	}
+
+	// Result is the result of taking a token from a bucket
+	Result struct {
+		Allowed    bool
+		Remaining  int
+		RetryAfter time.Duration
+		ResetAfter time.Duration
+	}
+
+	// Store stores the token buckets
+	Store interface {
+		Take(ctx context.Context, key string, limit Limit) (Result, error)
+	}
+)
+
+// Validate checks that the limit can be used
+func (l Limit) Validate() error {
+	if l.Requests <= 0 || l.Period <= 0 || l.Burst <= 0 {
+		return InvalidLimitError
+	}
+	return nil
+}
+
+// Rate returns the number of tokens added to the bucket per second
+func (l Limit) Rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// Policy returns the limit formatted as the value of the RateLimit-Policy header
+func (l Limit) Policy() string {
+	return fmt.Sprintf("%d;w=%d;burst=%d", l.Requests, int(math.Ceil(l.Period.Seconds())), l.Burst)
+}
+
+// newResult creates the result of taking a token from a bucket with the given limit, which has the given number of
+// tokens left after the take
+func newResult(limit Limit, allowed bool, tokens float64) Result {
+	rate := limit.Rate()
+	result := Result{
+		Allowed:    allowed,
+		Remaining:  int(math.Floor(tokens)),
+		ResetAfter: secondsToDuration((float64(limit.Burst) - tokens) / rate),
+	}
+	if !allowed {
+		result.RetryAfter = secondsToDuration((1 - tokens) / rate)
+	}
+	return result
+}
+
+// secondsToDuration converts a number of seconds to a duration
+func secondsToDuration(seconds float64) time.Duration {
+	if seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
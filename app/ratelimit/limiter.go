@@ -0,0 +1,142 @@
+package ratelimit
+
+import (
+	"github.com/gin-gonic/gin"
+	appjwt "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/jwt"
+	appproblem "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	approute "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/route"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Limiter limits the rate of the requests of every client to every route with token buckets
+type Limiter struct {
+	store        Store
+	identifier   *appjwt.Identifier
+	defaultLimit *Limit
+	routeLimits  map[string]Limit
+	exemptRoutes map[string]bool
+	logger       *Logger
+}
+
+// NewLimiter creates a new rate limiter with the limits of the routes indexed by their route key, like
+// "POST /api/v1/auth/log-in". The routes without their own limit share a bucket with the default limit, if any, while
+// the exempt routes, like the health probes, are never limited
+func NewLimiter(
+	store Store,
+	identifier *appjwt.Identifier,
+	defaultLimit *Limit,
+	routeLimits map[string]Limit,
+	exemptRoutes []string,
+	logger *Logger,
+) (*Limiter, error) {
+	// Check if the store, the identifier or the logger are nil
+	if store == nil {
+		return nil, NilStoreError
+	}
+	if identifier == nil {
+		return nil, NilIdentifierError
+	}
+	if logger == nil {
+		return nil, NilLoggerError
+	}
+
+	// Check the limits and normalize the route keys
+	if defaultLimit != nil {
+		if err := defaultLimit.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	normalizedLimits := make(map[string]Limit, len(routeLimits))
+	for key, limit := range routeLimits {
+		if err := limit.Validate(); err != nil {
+			return nil, err
+		}
+		normalizedKey, err := approute.ParseKey(key)
+		if err != nil {
+			return nil, err
+		}
+		normalizedLimits[normalizedKey] = limit
+	}
+	normalizedExemptRoutes := make(map[string]bool, len(exemptRoutes))
+	for _, key := range exemptRoutes {
+		normalizedKey, err := approute.ParseKey(key)
+		if err != nil {
+			return nil, err
+		}
+		normalizedExemptRoutes[normalizedKey] = true
+	}
+
+	return &Limiter{
+		store:        store,
+		identifier:   identifier,
+		defaultLimit: defaultLimit,
+		routeLimits:  normalizedLimits,
+		exemptRoutes: normalizedExemptRoutes,
+		logger:       logger,
+	}, nil
+}
+
+// Middleware rejects the requests whose token bucket is empty with 429 Too Many Requests. The buckets are keyed by
+// the JWT user ID of the authenticated requests and by the client IP of the anonymous ones. If the store fails, the
+// request is allowed
+func (l *Limiter) Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		// The exempt routes are never limited
+		routeKey := approute.KeyFromGinContext(ctx)
+		if l.exemptRoutes[routeKey] {
+			ctx.Next()
+			return
+		}
+
+		// Get the limit of the route
+		limit, ok := l.routeLimits[routeKey]
+		if !ok {
+			if l.defaultLimit == nil {
+				ctx.Next()
+				return
+			}
+			routeKey = DefaultRouteKey
+			limit = *l.defaultLimit
+		}
+
+		// Take a token from the bucket of the client
+		identity := l.identity(ctx)
+		result, err := l.store.Take(ctx.Request.Context(), keyPrefix+routeKey+":"+identity, limit)
+		if err != nil {
+			l.logger.StoreFailed(routeKey, err)
+			ctx.Next()
+			return
+		}
+
+		// Set the rate limit headers
+		header := ctx.Writer.Header()
+		header.Set(LimitHeader, strconv.Itoa(limit.Burst))
+		header.Set(RemainingHeader, strconv.Itoa(result.Remaining))
+		header.Set(ResetHeader, formatSeconds(result.ResetAfter))
+		header.Set(PolicyHeader, limit.Policy())
+
+		if !result.Allowed {
+			l.logger.RequestLimited(routeKey, identity)
+			header.Set(RetryAfterHeader, formatSeconds(result.RetryAfter))
+			appproblem.New(http.StatusTooManyRequests, ExceededErrorCode, TooManyRequestsError).Abort(ctx)
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// identity returns the identity the token bucket of the request is keyed by
+func (l *Limiter) identity(ctx *gin.Context) string {
+	if userID := l.identifier.UserID(ctx); userID != "" {
+		return "user:" + userID
+	}
+	return "ip:" + ctx.ClientIP()
+}
+
+// formatSeconds formats a duration as a whole number of seconds, rounded up
+func formatSeconds(duration time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(duration.Seconds())))
+}
@@ -0,0 +1,30 @@
+package ratelimit
+
+import (
+	"log/slog"
+)
+
+// Logger is the logger for the rate limiter
+type Logger struct {
+	logger *slog.Logger
+}
+
+// NewLogger creates a new rate limiter logger
+func NewLogger(logger *slog.Logger) (*Logger, error) {
+	// Check if the logger is nil
+	if logger == nil {
+		return nil, NilLoggerError
+	}
+
+	return &Logger{logger: logger}, nil
+}
+
+// RequestLimited logs that a request was rejected because its token bucket was empty
+func (l *Logger) RequestLimited(route, identity string) {
+	l.logger.Info("request rate limited", slog.String("route", route), slog.String("identity", identity))
+}
+
+// StoreFailed logs that the token bucket of a request could not be taken from the store, so the request was allowed
+func (l *Logger) StoreFailed(route string, err error) {
+	l.logger.Error("failed to take from the rate limit store", slog.String("route", route), slog.String("error", err.Error()))
+}
@@ -0,0 +1,92 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+type (
+	// MemoryStore stores the token buckets in memory, so they are not shared between the gateway instances
+	MemoryStore struct {
+		mutex   sync.Mutex
+		buckets map[string]*bucket
+		stop    chan struct{}
+		done    chan struct{}
+	}
+
+	// bucket is a token bucket stored in memory
+	bucket struct {
+		tokens  float64
+		updated time.Time
+		fullAt  time.Time
+	}
+)
+
+// NewMemoryStore creates a new memory store, which removes the full token buckets in the background until it is
+// closed
+func NewMemoryStore() *MemoryStore {
+	store := &MemoryStore{
+		buckets: make(map[string]*bucket),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go store.cleanup()
+	return store
+}
+
+// Take takes a token from the bucket with the given key
+func (m *MemoryStore) Take(_ context.Context, key string, limit Limit) (Result, error) {
+	now := time.Now()
+	rate := limit.Rate()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	// Refill the bucket with the tokens added since its last update
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updated: now}
+		m.buckets[key] = b
+	}
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+now.Sub(b.updated).Seconds()*rate)
+	b.updated = now
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+	b.fullAt = now.Add(secondsToDuration((float64(limit.Burst) - b.tokens) / rate))
+	return newResult(limit, allowed, b.tokens), nil
+}
+
+// Close stops removing the full token buckets
+func (m *MemoryStore) Close() error {
+	close(m.stop)
+	<-m.done
+	return nil
+}
+
+// cleanup removes the full token buckets periodically, since they are equivalent to missing ones
+func (m *MemoryStore) cleanup() {
+	defer close(m.done)
+
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stop:
+			return
+		case now := <-ticker.C:
+			m.mutex.Lock()
+			for key, b := range m.buckets {
+				if !now.Before(b.fullAt) {
+					delete(m.buckets, key)
+				}
+			}
+			m.mutex.Unlock()
+		}
+	}
+}
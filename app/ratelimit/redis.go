@@ -0,0 +1,85 @@
+package ratelimit
+
+import (
+	"context"
+	"github.com/go-redis/redis/v8"
+	"strconv"
+)
+
+// takeScript takes a token from a bucket stored as a hash with its tokens and last update time, using the clock of
+// the Redis server so every gateway instance agrees on it. It returns whether the token was taken and the tokens left
+var takeScript = redis.NewScript(
+	`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) + tonumber(time[2]) / 1000000
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'updated')
+local tokens = tonumber(state[1])
+local updated = tonumber(state[2])
+if tokens == nil or updated == nil then
+	tokens = burst
+	updated = now
+end
+tokens = math.min(burst, tokens + math.max(0, now - updated) * rate)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil((burst - tokens) / rate * 1000) + 1000)
+return {allowed, tostring(tokens)}
+`,
+)
+
+// RedisStore stores the token buckets in Redis, so they are shared between the gateway instances. It requires Redis
+// 5 or newer
+type RedisStore struct {
+	client redis.UniversalClient
+}
+
+// NewRedisStore creates a new Redis store
+func NewRedisStore(client redis.UniversalClient) (*RedisStore, error) {
+	// Check if the client is nil
+	if client == nil {
+		return nil, NilRedisClientError
+	}
+
+	return &RedisStore{client: client}, nil
+}
+
+// Take takes a token from the bucket with the given key
+func (r *RedisStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	reply, err := takeScript.Run(
+		ctx,
+		r.client,
+		[]string{key},
+		strconv.FormatFloat(limit.Rate(), 'f', -1, 64),
+		limit.Burst,
+	).Slice()
+	if err != nil {
+		return Result{}, err
+	}
+
+	// Parse the reply
+	if len(reply) != 2 {
+		return Result{}, UnexpectedRedisReplyError
+	}
+	allowed, ok := reply[0].(int64)
+	if !ok {
+		return Result{}, UnexpectedRedisReplyError
+	}
+	tokensReply, ok := reply[1].(string)
+	if !ok {
+		return Result{}, UnexpectedRedisReplyError
+	}
+	tokens, err := strconv.ParseFloat(tokensReply, 64)
+	if err != nil {
+		return Result{}, UnexpectedRedisReplyError
+	}
+	return newResult(limit, allowed == 1, tokens), nil
+}
@@ -0,0 +1,166 @@
+package ratelimit
+
+import (
+	"context"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"testing"
+	"time"
+)
+
+type (
+	// testStore is a store and the function that moves the clock of its token buckets forward
+	testStore struct {
+		name    string
+		store   Store
+		advance func(key string, duration time.Duration)
+	}
+
+	// takeStep is the time waited before taking a token from the bucket, and the result of the take
+	takeStep struct {
+		wait           time.Duration
+		wantAllowed    bool
+		wantRemaining  int
+		wantRetryAfter time.Duration
+	}
+
+	// takeCase is a sequence of takes from the same token bucket
+	takeCase struct {
+		name  string
+		steps []takeStep
+	}
+)
+
+var (
+	// testLimit allows a request per second with bursts of up to two requests
+	testLimit = Limit{Requests: 1, Period: time.Second, Burst: 2}
+)
+
+// newTestStores creates a memory store and a Redis store backed by an in-memory Redis server
+func newTestStores(t *testing.T) []testStore {
+	t.Helper()
+
+	memoryStore := NewMemoryStore()
+	t.Cleanup(func() { _ = memoryStore.Close() })
+
+	// The time of the Redis server is a whole second, since the script stores it with a limited precision
+	server := miniredis.RunT(t)
+	now := time.Now().Truncate(time.Second)
+	server.SetTime(now)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	redisStore, err := NewRedisStore(client)
+	if err != nil {
+		t.Fatalf("NewRedisStore() error = %v", err)
+	}
+
+	return []testStore{
+		{
+			name:  "memory",
+			store: memoryStore,
+			advance: func(key string, duration time.Duration) {
+				// The memory store uses the clock of the gateway, so the bucket is updated earlier instead
+				memoryStore.mutex.Lock()
+				defer memoryStore.mutex.Unlock()
+				if b, ok := memoryStore.buckets[key]; ok {
+					b.updated = b.updated.Add(-duration)
+				}
+			},
+		},
+		{
+			name:  "redis",
+			store: redisStore,
+			advance: func(_ string, duration time.Duration) {
+				// The Redis store uses the clock of the Redis server, which is only moved by the tests
+				now = now.Add(duration)
+				server.SetTime(now)
+			},
+		},
+	}
+}
+
+// TestStoreTake checks the tokens are taken from the bucket until it is empty, and the bucket is refilled at the rate
+// of the limit up to its burst
+func TestStoreTake(t *testing.T) {
+	cases := []takeCase{
+		{
+			name: "burst",
+			steps: []takeStep{
+				{wantAllowed: true, wantRemaining: 1},
+				{wantAllowed: true, wantRemaining: 0},
+				{wantAllowed: false, wantRemaining: 0, wantRetryAfter: time.Second},
+			},
+		},
+		{
+			name: "partial refill",
+			steps: []takeStep{
+				{wantAllowed: true, wantRemaining: 1},
+				{wantAllowed: true, wantRemaining: 0},
+				{
+					wait:           500 * time.Millisecond,
+					wantAllowed:    false,
+					wantRemaining:  0,
+					wantRetryAfter: 500 * time.Millisecond,
+				},
+				{wait: 500 * time.Millisecond, wantAllowed: true, wantRemaining: 0},
+			},
+		},
+		{
+			name: "refill",
+			steps: []takeStep{
+				{wantAllowed: true, wantRemaining: 1},
+				{wantAllowed: true, wantRemaining: 0},
+				{wantAllowed: false, wantRemaining: 0, wantRetryAfter: time.Second},
+				{wait: time.Second, wantAllowed: true, wantRemaining: 0},
+				{wantAllowed: false, wantRemaining: 0, wantRetryAfter: time.Second},
+			},
+		},
+		{
+			name: "refill up to burst",
+			steps: []takeStep{
+				{wantAllowed: true, wantRemaining: 1},
+				{wantAllowed: true, wantRemaining: 0},
+				{wait: 10 * time.Second, wantAllowed: true, wantRemaining: 1},
+				{wantAllowed: true, wantRemaining: 0},
+				{wantAllowed: false, wantRemaining: 0, wantRetryAfter: time.Second},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	for _, s := range newTestStores(t) {
+		for _, c := range cases {
+			t.Run(
+				s.name+"/"+c.name, func(t *testing.T) {
+					key := keyPrefix + c.name
+					for index, step := range c.steps {
+						if step.wait > 0 {
+							s.advance(key, step.wait)
+						}
+
+						result, err := s.store.Take(ctx, key, testLimit)
+						if err != nil {
+							t.Fatalf("step %d: Take() error = %v", index, err)
+						}
+						if result.Allowed != step.wantAllowed || result.Remaining != step.wantRemaining {
+							t.Errorf(
+								"step %d: Take() allowed = %v and remaining = %d, want %v and %d",
+								index,
+								result.Allowed,
+								result.Remaining,
+								step.wantAllowed,
+								step.wantRemaining,
+							)
+						}
+
+						// The memory store refills the bucket with the time elapsed between the steps too
+						if result.RetryAfter > step.wantRetryAfter ||
+							result.RetryAfter < step.wantRetryAfter-100*time.Millisecond {
+							t.Errorf("step %d: Take() retry after = %v, want %v", index, result.RetryAfter, step.wantRetryAfter)
+						}
+					}
+				},
+			)
+		}
+	}
+}
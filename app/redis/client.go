@@ -0,0 +1,23 @@
+package redis
+
+import (
+	goredis "github.com/go-redis/redis/v8"
+)
+
+// Config is the configuration of the Redis server shared by the subsystems with a Redis backend
+type Config struct {
+	Address  string `yaml:"address" json:"address"`
+	Password string `yaml:"password" json:"password"`
+	Database int    `yaml:"db" json:"db"`
+}
+
+// NewClient creates a new Redis client, which connects lazily to the server
+func NewClient(config *Config) goredis.UniversalClient {
+	return goredis.NewClient(
+		&goredis.Options{
+			Addr:     config.Address,
+			Password: config.Password,
+			DB:       config.Database,
+		},
+	)
+}
@@ -0,0 +1,12 @@
+package redis
+
+const (
+	// AddressKey is the key of the address of the Redis server
+	AddressKey = "REDIS_ADDRESS"
+
+	// PasswordKey is the key of the password of the Redis server
+	PasswordKey = "REDIS_PASSWORD"
+
+	// DatabaseKey is the key of the Redis database number
+	DatabaseKey = "REDIS_DB"
+)
@@ -0,0 +1,23 @@
+package requestid
+
+const (
+	// Header is the header the request ID is read from and echoed in
+	Header = "X-Request-ID"
+
+	// MetadataKey is the key of the request ID in the outgoing gRPC metadata
+	MetadataKey = "x-request-id"
+
+	// BodyKey is the key of the request ID added to the error response bodies
+	BodyKey = "request_id"
+
+	// MaxLength is the maximum length of a request ID sent by the client, the longer ones are replaced
+	MaxLength = 128
+
+	// ginCtxKey is the key of the request ID in the Gin context
+	ginCtxKey = "request_id"
+)
+
+var (
+	// jsonContentTypes are the content types of the JSON error bodies the request ID is added to
+	jsonContentTypes = []string{"application/json", "application/problem+json"}
+)
@@ -0,0 +1,104 @@
+package requestid
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// errorBodyWriter buffers the JSON error bodies so the request ID can be added to them once the handlers are done
+type errorBodyWriter struct {
+	gin.ResponseWriter
+	buffer *bytes.Buffer
+}
+
+// Middleware accepts the request ID sent by the client in the X-Request-ID header, or generates a new one, stores it
+// in the Gin context, echoes it in the response header and adds it to every JSON error body. It must be the first
+// global middleware, so every response goes through it
+func Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.GetHeader(Header)
+		if !IsValid(id) {
+			id = Generate()
+		}
+		ctx.Set(ginCtxKey, id)
+		ctx.Request = ctx.Request.WithContext(NewContext(ctx.Request.Context(), id))
+		ctx.Header(Header, id)
+
+		writer := &errorBodyWriter{ResponseWriter: ctx.Writer}
+		ctx.Writer = writer
+
+		ctx.Next()
+
+		ctx.Writer = writer.ResponseWriter
+		writer.flush(id)
+	}
+}
+
+// isError checks if the response is a JSON error whose body must be buffered, including the replayed problem details
+// that carry the ID of the original request
+func (w *errorBodyWriter) isError() bool {
+	if w.ResponseWriter.Status() < http.StatusBadRequest {
+		return false
+	}
+	contentType := w.Header().Get("Content-Type")
+	for _, jsonContentType := range jsonContentTypes {
+		if strings.HasPrefix(contentType, jsonContentType) {
+			return true
+		}
+	}
+	return false
+}
+
+// Write buffers the body of the JSON errors and writes any other body as is
+func (w *errorBodyWriter) Write(data []byte) (int, error) {
+	if w.buffer == nil && !w.isError() {
+		return w.ResponseWriter.Write(data)
+	}
+	if w.buffer == nil {
+		w.buffer = new(bytes.Buffer)
+	}
+	return w.buffer.Write(data)
+}
+
+// WriteString buffers the body of the JSON errors and writes any other body as is
+func (w *errorBodyWriter) WriteString(data string) (int, error) {
+	return w.Write([]byte(data))
+}
+
+// Written checks if the body has been written, including the buffered one
+func (w *errorBodyWriter) Written() bool {
+	return w.buffer != nil || w.ResponseWriter.Written()
+}
+
+// Size returns the size of the body, including the buffered one
+func (w *errorBodyWriter) Size() int {
+	if w.buffer != nil {
+		return w.buffer.Len()
+	}
+	return w.ResponseWriter.Size()
+}
+
+// flush writes the buffered error body with the request ID added, leaving it untouched if it is not a JSON object
+func (w *errorBodyWriter) flush(id string) {
+	if w.buffer == nil {
+		return
+	}
+
+	body := w.buffer.Bytes()
+	var fields map[string]any
+	if err := json.Unmarshal(body, &fields); err == nil && fields != nil {
+		fields[BodyKey] = id
+		if encoded, err := json.Marshal(fields); err == nil {
+			body = encoded
+		}
+	}
+
+	if w.Header().Get("Content-Length") != "" {
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	}
+	_, _ = w.ResponseWriter.Write(body)
+}
@@ -0,0 +1,57 @@
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"github.com/gin-gonic/gin"
+)
+
+// ctxKey is the type of the key of the request ID in a context
+type ctxKey struct{}
+
+// Generate generates a new random request ID with the format of a UUID version 4
+func Generate() string {
+	var id [16]byte
+	_, _ = rand.Read(id[:])
+
+	// Set the version and variant bits
+	id[6] = (id[6] & 0x0f) | 0x40
+	id[8] = (id[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:16])
+}
+
+// IsValid checks if a request ID sent by the client can be used as is, which requires it to be non-empty, not longer
+// than MaxLength and made of letters, digits and the characters '-', '_', '.' and ':'
+func IsValid(id string) bool {
+	if id == "" || len(id) > MaxLength {
+		return false
+	}
+	for _, char := range id {
+		switch {
+		case char >= 'a' && char <= 'z',
+			char >= 'A' && char <= 'Z',
+			char >= '0' && char <= '9',
+			char == '-', char == '_', char == '.', char == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// FromGinContext returns the request ID stored in the Gin context, or an empty string if there is none
+func FromGinContext(ctx *gin.Context) string {
+	return ctx.GetString(ginCtxKey)
+}
+
+// NewContext returns a copy of the context that carries the request ID
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext returns the request ID carried by the context, or an empty string if there is none
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(ctxKey{}).(string)
+	return id
+}
@@ -0,0 +1,12 @@
+package response
+
+import (
+	"errors"
+)
+
+var (
+	NilCodecError               = errors.New("nil response codec")
+	NilCacheError               = errors.New("nil response cache")
+	GatewayTimeoutError         = errors.New("the backend service did not answer in time")
+	FailedToEncodeResponseError = errors.New("failed to encode the response")
+)
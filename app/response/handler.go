@@ -0,0 +1,192 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	appbreaker "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/breaker"
+	appcache "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/cache"
+	appcodec "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/codec"
+	appidempotency "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/idempotency"
+	apppagination "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/pagination"
+	appparams "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/params"
+	appproblem "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	apptracing "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/tracing"
+	appvalidation "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/validation"
+	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"net/http"
+)
+
+// Handler handles the responses of the backend services and the errors preparing their calls. Every error is answered
+// with the problem details of RFC 7807, mapping the gRPC status of the backend errors to the HTTP status, while the
+// successful responses are encoded by the codec, as JSON or as binary protobuf depending on the Accept header. The
+// successful responses of the cached routes are validated by their ETag, and the ones of the non-idempotent routes are
+// replayed by their idempotency key
+type Handler struct {
+	codec       *appcodec.Codec
+	cache       *appcache.Cache
+	idempotency *appidempotency.Middleware
+}
+
+// NewHandler creates a new response handler. The idempotency middleware is nil if the idempotency keys are disabled
+func NewHandler(
+	codec *appcodec.Codec,
+	cache *appcache.Cache,
+	idempotency *appidempotency.Middleware,
+) (*Handler, error) {
+	// Check if the codec or the cache are nil
+	if codec == nil {
+		return nil, NilCodecError
+	}
+	if cache == nil {
+		return nil, NilCacheError
+	}
+
+	return &Handler{codec: codec, cache: cache, idempotency: idempotency}, nil
+}
+
+// RouteHandler wraps the route handler of a controller, recording the mappers of its endpoints so their path
+// parameters are checked at startup, replaying the responses of its authenticated endpoints by their idempotency key
+// once the caller was authenticated, if the idempotency keys are enabled, and tracing their authentication
+func (h *Handler) RouteHandler(routeHandler commonhandler.Handler) commonhandler.Handler {
+	routeHandler = appparams.NewRouteHandler(routeHandler)
+	if h.idempotency != nil {
+		routeHandler = h.idempotency.RouteHandler(routeHandler)
+	}
+	return apptracing.NewRouteHandler(routeHandler)
+}
+
+// Cached wraps the handler of a cached route, so its responses are served from the in-memory cache while they are
+// stored
+func (h *Handler) Cached(handler gin.HandlerFunc) gin.HandlerFunc {
+	return h.cache.Handler(handler)
+}
+
+// HandleResponse writes the response of a backend service with the given status code, or its error. The successful
+// responses of the cached routes the client already has are answered with 304 Not Modified
+func (h *Handler) HandleResponse(ctx *gin.Context, code int, response proto.Message, err error) {
+	if err != nil {
+		h.handleError(ctx, err)
+		return
+	}
+	if h.cache.Revalidate(ctx, code, response) {
+		return
+	}
+	h.write(ctx, code, response)
+}
+
+// HandlePageResponse writes the requested page of the list returned by a backend service, or its error. The paged
+// flag tells whether the RPC already paged the items, otherwise they are sliced by the gateway
+func (h *Handler) HandlePageResponse(
+	ctx *gin.Context,
+	code int,
+	response proto.Message,
+	page *apppagination.Page,
+	paged bool,
+	err error,
+) {
+	if err != nil {
+		h.HandleResponse(ctx, code, response, err)
+		return
+	}
+
+	// The binary protobuf pages are the sliced response, whose next cursor is only given by the Link header
+	if appcodec.FormatFromGinContext(ctx) == appcodec.Protobuf {
+		if _, err = page.Apply(ctx, response, paged); err != nil {
+			h.handlePageError(ctx, err)
+			return
+		}
+		h.write(ctx, code, response)
+		return
+	}
+
+	body, err := page.Body(ctx, response, paged, h.codec)
+	if err != nil {
+		h.handlePageError(ctx, err)
+		return
+	}
+	appcodec.AddVary(ctx.Writer.Header(), appcodec.AcceptHeader)
+	ctx.JSON(code, body)
+}
+
+// HandleBadRequest writes the error of a request rejected by the gateway before calling the backend service, naming
+// the invalid path parameter, if any
+func (h *Handler) HandleBadRequest(ctx *gin.Context, err error) {
+	problem := appproblem.New(http.StatusBadRequest, appproblem.InvalidArgumentCode, err)
+
+	var paramErr *appparams.Error
+	if errors.As(err, &paramErr) {
+		problem.WithErrors(appproblem.FieldError{Field: paramErr.Param, Detail: paramErr.Description})
+	}
+	problem.Write(ctx)
+}
+
+// HandlePrepareCtxError writes the error returned while preparing the context of a backend call. The routes are
+// authenticated before their handlers run, so the error is the one of a request body that could not be decoded or
+// that has invalid fields
+func (h *Handler) HandlePrepareCtxError(ctx *gin.Context, err error) {
+	var validationErr *appvalidation.Error
+	if errors.As(err, &validationErr) {
+		h.handleValidationError(ctx, validationErr)
+		return
+	}
+	if _, ok := status.FromError(err); ok {
+		h.handleError(ctx, err)
+		return
+	}
+	h.HandleBadRequest(ctx, err)
+}
+
+// write writes a successful response in the format negotiated with the client
+func (h *Handler) write(ctx *gin.Context, code int, response proto.Message) {
+	if err := h.codec.Write(ctx, code, response); err != nil {
+		appproblem.New(http.StatusInternalServerError, appproblem.InternalCode, FailedToEncodeResponseError).Write(ctx)
+	}
+}
+
+// handlePageError writes the error of a page that could not be built, either because of its cursor or its sort field
+// or because the response could not be encoded
+func (h *Handler) handlePageError(ctx *gin.Context, err error) {
+	if errors.Is(err, apppagination.InvalidCursorError) || errors.Is(err, apppagination.InvalidSortError) {
+		h.HandleBadRequest(ctx, err)
+		return
+	}
+	appproblem.New(http.StatusInternalServerError, appproblem.InternalCode, FailedToEncodeResponseError).Write(ctx)
+}
+
+// handleValidationError writes the error of a request body with invalid fields, naming each one of them
+func (h *Handler) handleValidationError(ctx *gin.Context, err *appvalidation.Error) {
+	fieldErrors := make([]appproblem.FieldError, 0, len(err.Violations))
+	for _, violation := range err.Violations {
+		fieldErrors = append(fieldErrors, appproblem.FieldError{Field: violation.Field, Detail: violation.Description})
+	}
+	appproblem.New(http.StatusBadRequest, appproblem.InvalidArgumentCode, err).WithErrors(fieldErrors...).Write(ctx)
+}
+
+// handleError writes the error of a backend call, answering the failures the gateway is responsible for, like the
+// exceeded deadlines and the open circuits, before mapping the gRPC status of the rest
+func (h *Handler) handleError(ctx *gin.Context, err error) {
+	// The client disconnected, so nobody will read the response
+	if ctx.Request.Context().Err() == context.Canceled {
+		ctx.Abort()
+		return
+	}
+
+	// The circuit of the backend service is open, so the call was rejected right away
+	if retryAfter, ok := appbreaker.RetryAfter(err); ok {
+		appproblem.New(http.StatusServiceUnavailable, appbreaker.OpenErrorCode, appbreaker.OpenCircuitError).
+			WithRetryAfter(retryAfter).
+			Write(ctx)
+		return
+	}
+
+	// The deadline of the route was exceeded
+	if status.Code(err) == codes.DeadlineExceeded || errors.Is(err, context.DeadlineExceeded) {
+		appproblem.New(http.StatusGatewayTimeout, appproblem.DeadlineExceededCode, GatewayTimeoutError).Write(ctx)
+		return
+	}
+	appproblem.FromError(err).Write(ctx)
+}
@@ -0,0 +1,44 @@
+package retry
+
+import (
+	"google.golang.org/grpc/codes"
+	"time"
+)
+
+const (
+	// EnabledKey is the key of the flag that enables the retries of the backend calls
+	EnabledKey = "RETRY_ENABLED"
+
+	// IdempotencyKeyMetadataKey is the key of the idempotency key in the outgoing gRPC metadata, so the backend
+	// services can deduplicate the retried calls
+	IdempotencyKeyMetadataKey = "idempotency-key"
+)
+
+const (
+	// DefaultMaxAttempts is the default maximum number of attempts of a call, including the first one
+	DefaultMaxAttempts = 3
+
+	// DefaultInitialBackoff is the default wait before the first retry
+	DefaultInitialBackoff = 100 * time.Millisecond
+
+	// DefaultMaxBackoff is the default maximum wait between two attempts
+	DefaultMaxBackoff = 2 * time.Second
+
+	// DefaultMultiplier is the default factor the wait is multiplied by after every retry
+	DefaultMultiplier = 2.0
+
+	// DefaultJitter is the default fraction of the wait that is randomized
+	DefaultJitter = 0.2
+)
+
+var (
+	// ReadOnlyPrefixes are the prefixes of the names of the RPCs that do not modify anything, which are retried by
+	// default
+	ReadOnlyPrefixes = []string{"Get", "Search", "Is"}
+
+	// ReadOnlyMethods are the names of the read-only RPCs that do not match any of the read-only prefixes
+	ReadOnlyMethods = []string{"UsernameExists"}
+
+	// RetryableCodes are the status codes of the transient failures, like the cold starts of the backend services
+	RetryableCodes = []codes.Code{codes.Unavailable}
+)
@@ -0,0 +1,19 @@
+package retry
+
+import (
+	"context"
+)
+
+// idempotencyKeyContextKey is the key of the idempotency key in the context of a backend call
+type idempotencyKeyContextKey struct{}
+
+// ContextWithIdempotencyKey returns a copy of the context carrying the idempotency key of the request
+func ContextWithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// IdempotencyKeyFromContext returns the idempotency key carried by the context, or an empty string if there is none
+func IdempotencyKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key
+}
@@ -0,0 +1,11 @@
+package retry
+
+import (
+	"errors"
+)
+
+var (
+	NilLoggerError     = errors.New("nil logger")
+	InvalidPolicyError = errors.New("invalid retry policy")
+	InvalidMethodError = errors.New("invalid RPC name")
+)
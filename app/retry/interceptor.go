@@ -0,0 +1,26 @@
+package retry
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// IdempotencyKeyUnaryClientInterceptor appends the idempotency key carried by the context of the call to the outgoing
+// gRPC metadata, so the backend services can deduplicate the retried calls. It must run after the authentication
+// interceptor, which replaces the outgoing metadata of every attempt
+func IdempotencyKeyUnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if key := IdempotencyKeyFromContext(ctx); key != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, IdempotencyKeyMetadataKey, key)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
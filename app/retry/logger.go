@@ -0,0 +1,33 @@
+package retry
+
+import (
+	"log/slog"
+	"time"
+)
+
+// Logger is the logger for the retries of the backend calls
+type Logger struct {
+	logger *slog.Logger
+}
+
+// NewLogger creates a new retry logger
+func NewLogger(logger *slog.Logger) (*Logger, error) {
+	// Check if the logger is nil
+	if logger == nil {
+		return nil, NilLoggerError
+	}
+
+	return &Logger{logger: logger}, nil
+}
+
+// Retrying logs that a failed backend call is going to be retried
+func (l *Logger) Retrying(service, method string, attempt int, backoff time.Duration, err error) {
+	l.logger.Warn(
+		"retrying backend call",
+		slog.String("service", service),
+		slog.String("method", method),
+		slog.Int("attempt", attempt),
+		slog.Duration("backoff", backoff),
+		slog.String("error", err.Error()),
+	)
+}
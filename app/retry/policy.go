@@ -0,0 +1,39 @@
+package retry
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Policy is the retry policy of an RPC. A call is attempted up to MaxAttempts times, waiting InitialBackoff before the
+// first retry and multiplying the wait by Multiplier after every retry up to MaxBackoff. A fraction Jitter of every
+// wait is randomized, so the retries of concurrent calls are spread out
+type Policy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	Jitter         float64
+}
+
+// Validate checks that the policy can be used
+func (p Policy) Validate() error {
+	if p.MaxAttempts <= 0 || p.InitialBackoff <= 0 || p.MaxBackoff < p.InitialBackoff {
+		return InvalidPolicyError
+	}
+	if p.Multiplier < 1 || p.Jitter < 0 || p.Jitter > 1 {
+		return InvalidPolicyError
+	}
+	return nil
+}
+
+// backoff returns the wait before the given retry, starting at 1
+func (p Policy) backoff(retry int) time.Duration {
+	backoff := float64(p.InitialBackoff) * math.Pow(p.Multiplier, float64(retry-1))
+	backoff = math.Min(backoff, float64(p.MaxBackoff))
+
+	// Randomize the wait within [backoff * (1 - jitter), backoff * (1 + jitter)]
+	backoff *= 1 + p.Jitter*(2*rand.Float64()-1)
+	return time.Duration(backoff)
+}
@@ -0,0 +1,139 @@
+package retry
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Retrier retries the backend calls that failed with a transient error. The read-only RPCs and the idempotent ones
+// are retried by default, while the rest are only retried when the request carries an idempotency key
+type Retrier struct {
+	defaultPolicy     Policy
+	methodPolicies    map[string]Policy
+	idempotentMethods map[string]bool
+	logger            *Logger
+}
+
+// NewRetrier creates a new retrier with the policies of the RPCs indexed by their name, like GetBusiness, the default
+// policy of the rest and the names of the non read-only RPCs that are safe to repeat
+func NewRetrier(
+	defaultPolicy Policy,
+	methodPolicies map[string]Policy,
+	idempotentMethods []string,
+	logger *Logger,
+) (*Retrier, error) {
+	// Check if the logger is nil
+	if logger == nil {
+		return nil, NilLoggerError
+	}
+
+	// Check the policies
+	if err := defaultPolicy.Validate(); err != nil {
+		return nil, err
+	}
+	for method, policy := range methodPolicies {
+		if err := ValidateMethod(method); err != nil {
+			return nil, err
+		}
+		if err := policy.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	idempotent := make(map[string]bool, len(idempotentMethods))
+	for _, method := range idempotentMethods {
+		if err := ValidateMethod(method); err != nil {
+			return nil, err
+		}
+		idempotent[method] = true
+	}
+
+	return &Retrier{
+		defaultPolicy:     defaultPolicy,
+		methodPolicies:    methodPolicies,
+		idempotentMethods: idempotent,
+		logger:            logger,
+	}, nil
+}
+
+// ValidateMethod checks that the value is the name of an RPC, without its service
+func ValidateMethod(method string) error {
+	if method == "" || strings.ContainsAny(method, "/. ") {
+		return InvalidMethodError
+	}
+	return nil
+}
+
+// IsReadOnly checks if the RPC with the given name does not modify anything
+func IsReadOnly(method string) bool {
+	if slices.Contains(ReadOnlyMethods, method) {
+		return true
+	}
+	for _, prefix := range ReadOnlyPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRetryable checks if the error is a transient failure worth retrying
+func IsRetryable(err error) bool {
+	return slices.Contains(RetryableCodes, status.Code(err))
+}
+
+// methodName returns the name of the RPC of the given full method, like /shop.Shop/GetBusiness
+func methodName(fullMethod string) string {
+	return fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+}
+
+// UnaryClientInterceptor retries the calls to the given backend service
+func (r *Retrier) UnaryClientInterceptor(service string) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		name := methodName(method)
+
+		// Never repeat a call that is not safe to repeat, unless its idempotency key, which is forwarded to the backend
+		// service by the interceptor that runs after the authentication, lets the backend service deduplicate it
+		if !IsReadOnly(name) && !r.idempotentMethods[name] && IdempotencyKeyFromContext(ctx) == "" {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		policy, ok := r.methodPolicies[name]
+		if !ok {
+			policy = r.defaultPolicy
+		}
+
+		for attempt := 1; ; attempt++ {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil || attempt >= policy.MaxAttempts || !IsRetryable(err) {
+				return err
+			}
+
+			// Give up if the deadline of the request would be exceeded while waiting
+			backoff := policy.backoff(attempt)
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= backoff {
+				return err
+			}
+			r.logger.Retrying(service, name, attempt, backoff, err)
+
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package route
+
+import (
+	"errors"
+)
+
+var (
+	InvalidKeyError   = errors.New("invalid route key, expected an HTTP method and a path separated by a space")
+	UnknownRouteError = errors.New("unknown route key, it does not match any registered route")
+)
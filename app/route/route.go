@@ -0,0 +1,72 @@
+package route
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+)
+
+// methods are the HTTP methods accepted in the route keys
+var methods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodDelete:  true,
+	http.MethodOptions: true,
+}
+
+// Normalize converts a route template to the form used in the route keys, replacing its parameters, written either
+// with the Gin syntax, like /api/v1/users/reset-password/:token, or with the Swagger one, like
+// /api/v1/users/reset-password/{token}, by a bare ':'. Since Gin does not allow two parameters with different names
+// in the same position, the keys do not depend on the parameter names
+func Normalize(path string) string {
+	segments := strings.Split(path, "/")
+	for index, segment := range segments {
+		switch {
+		case strings.HasPrefix(segment, ":"),
+			strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}"):
+			segments[index] = ":"
+		case strings.HasPrefix(segment, "*"):
+			segments[index] = "*"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// Key returns the key of the route with the given HTTP method and template, which is used to configure the
+// per-route policies
+func Key(method, path string) string {
+	return strings.ToUpper(method) + " " + Normalize(path)
+}
+
+// KeyFromGinContext returns the key of the route matched by the request, or an empty string if it did not match any
+func KeyFromGinContext(ctx *gin.Context) string {
+	path := ctx.FullPath()
+	if path == "" {
+		return ""
+	}
+	return Key(ctx.Request.Method, path)
+}
+
+// ParseKey parses a route key written as an HTTP method and a route template separated by a space, like
+// "POST /api/v1/auth/log-in", and returns it normalized
+func ParseKey(key string) (string, error) {
+	method, path, ok := strings.Cut(strings.TrimSpace(key), " ")
+	path = strings.TrimSpace(path)
+	if !ok || !methods[strings.ToUpper(method)] || !strings.HasPrefix(path, "/") {
+		return "", fmt.Errorf("%w: %q", InvalidKeyError, key)
+	}
+	return Key(method, path), nil
+}
+
+// Keys returns the keys of the registered routes
+func Keys(routes gin.RoutesInfo) map[string]bool {
+	keys := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		keys[Key(route.Method, route.Path)] = true
+	}
+	return keys
+}
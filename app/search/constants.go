@@ -0,0 +1,50 @@
+package search
+
+const (
+	// TextQuery is the query parameter with the text the products are searched by
+	TextQuery = "q"
+
+	// CategoryQuery is the query parameter with the ID of the product category the products belong to
+	CategoryQuery = "category_id"
+
+	// MinPriceQuery is the query parameter with the minimum price of the products, included
+	MinPriceQuery = "min_price"
+
+	// MaxPriceQuery is the query parameter with the maximum price of the products, included
+	MaxPriceQuery = "max_price"
+
+	// InStockQuery is the query parameter that only keeps the products in stock
+	InStockQuery = "in_stock"
+
+	// MaxTextLength is the maximum length of the searched text
+	MaxTextLength = 200
+
+	// MaxIDLength is the maximum length of the IDs used as filters
+	MaxIDLength = 64
+)
+
+const (
+	// priceField is the name of the field with the price of the search results
+	priceField = "price"
+
+	// stockField is the name of the field with the stock of the search results
+	stockField = "stock"
+)
+
+var (
+	// ProductFilters are the filters supported by the product and business product searches
+	ProductFilters = []string{TextQuery, CategoryQuery}
+
+	// BranchProductFilters are the filters supported by the branch product searches, whose results have a price and
+	// a stock
+	BranchProductFilters = []string{TextQuery, CategoryQuery, MinPriceQuery, MaxPriceQuery, InStockQuery}
+
+	// filters are all the filters, including the ones not supported by every search
+	filters = []string{
+		TextQuery,
+		CategoryQuery,
+		MinPriceQuery,
+		MaxPriceQuery,
+		InStockQuery,
+	}
+)
@@ -0,0 +1,14 @@
+package search
+
+import (
+	"errors"
+)
+
+var (
+	InvalidTextError       = errors.New("invalid search text, expected up to 200 characters")
+	InvalidIDError         = errors.New("invalid ID, expected up to 64 characters without spaces")
+	InvalidPriceError      = errors.New("invalid price, expected a non-negative number")
+	InvalidPriceRangeError = errors.New("invalid price range, the minimum price is greater than the maximum one")
+	InvalidBoolError       = errors.New("invalid boolean, expected true or false")
+	UnsupportedFilterError = errors.New("filter not supported by this search")
+)
@@ -0,0 +1,81 @@
+package search
+
+import (
+	apppagination "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/pagination"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Filter removes the results of the search response that do not match the filters the search RPC does not support,
+// like the price range and the stock
+func (q *Query) Filter(response proto.Message) {
+	if response == nil || (q.MinPrice == nil && q.MaxPrice == nil && !q.InStock) {
+		return
+	}
+	message := response.ProtoReflect()
+	if !message.IsValid() {
+		return
+	}
+	field := apppagination.ItemsField(message.Descriptor())
+	if field == nil {
+		return
+	}
+
+	results := message.Get(field).List()
+	var kept []protoreflect.Value
+	for i := 0; i < results.Len(); i++ {
+		if q.matches(results.Get(i).Message()) {
+			kept = append(kept, results.Get(i))
+		}
+	}
+
+	message.Clear(field)
+	if len(kept) > 0 {
+		filtered := message.Mutable(field).List()
+		for _, result := range kept {
+			filtered.Append(result)
+		}
+	}
+}
+
+// matches checks if the search result matches the price range and the stock filter
+func (q *Query) matches(result protoreflect.Message) bool {
+	fields := result.Descriptor().Fields()
+
+	if q.MinPrice != nil || q.MaxPrice != nil {
+		price := fields.ByName(priceField)
+		if price == nil {
+			return false
+		}
+		value, ok := toFloat(price.Kind(), result.Get(price))
+		if !ok || (q.MinPrice != nil && value < *q.MinPrice) || (q.MaxPrice != nil && value > *q.MaxPrice) {
+			return false
+		}
+	}
+
+	if q.InStock {
+		stock := fields.ByName(stockField)
+		if stock == nil {
+			return false
+		}
+		value, ok := toFloat(stock.Kind(), result.Get(stock))
+		if !ok || value <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// toFloat converts the value of a numeric field to a float, and returns false if the field is not numeric
+func toFloat(kind protoreflect.Kind, value protoreflect.Value) (float64, bool) {
+	switch kind {
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return value.Float(), true
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return float64(value.Uint()), true
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return float64(value.Int()), true
+	}
+	return 0, false
+}
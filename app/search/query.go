@@ -0,0 +1,89 @@
+package search
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Query is a product search requested through the query parameters, so it can be cached and bookmarked
+type Query struct {
+	Text       string
+	CategoryID string
+	MinPrice   *float64
+	MaxPrice   *float64
+	InStock    bool
+}
+
+// Parse parses and validates the search requested by the query parameters of the request, rejecting the filters that
+// are not among the supported ones
+func Parse(ctx *gin.Context, supported []string) (*Query, error) {
+	// Reject the filters the search does not support
+	for _, filter := range filters {
+		if _, ok := ctx.GetQuery(filter); ok && !slices.Contains(supported, filter) {
+			return nil, fmt.Errorf("%w: %s", UnsupportedFilterError, filter)
+		}
+	}
+
+	query := &Query{
+		Text:       strings.TrimSpace(ctx.Query(TextQuery)),
+		CategoryID: ctx.Query(CategoryQuery),
+	}
+	if utf8.RuneCountInString(query.Text) > MaxTextLength {
+		return nil, fmt.Errorf("%w: %s", InvalidTextError, TextQuery)
+	}
+	if query.CategoryID != "" && !isValidID(query.CategoryID) {
+		return nil, fmt.Errorf("%w: %s", InvalidIDError, CategoryQuery)
+	}
+
+	// Parse the price range
+	var err error
+	if query.MinPrice, err = parsePrice(ctx, MinPriceQuery); err != nil {
+		return nil, err
+	}
+	if query.MaxPrice, err = parsePrice(ctx, MaxPriceQuery); err != nil {
+		return nil, err
+	}
+	if query.MinPrice != nil && query.MaxPrice != nil && *query.MinPrice > *query.MaxPrice {
+		return nil, InvalidPriceRangeError
+	}
+
+	// Parse the stock filter
+	if inStock := ctx.Query(InStockQuery); inStock != "" {
+		query.InStock, err = strconv.ParseBool(inStock)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", InvalidBoolError, InStockQuery)
+		}
+	}
+	return query, nil
+}
+
+// ProductCategoryID returns the ID of the product category filter of the search RPCs, or nil if it is not set
+func (q *Query) ProductCategoryID() *string {
+	if q.CategoryID == "" {
+		return nil
+	}
+	return &q.CategoryID
+}
+
+// parsePrice parses the price of the given query parameter, or returns nil if it is not set
+func parsePrice(ctx *gin.Context, name string) (*float64, error) {
+	value := ctx.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+	price, err := strconv.ParseFloat(value, 64)
+	if err != nil || price < 0 || math.IsNaN(price) || math.IsInf(price, 0) {
+		return nil, fmt.Errorf("%w: %s", InvalidPriceError, name)
+	}
+	return &price, nil
+}
+
+// isValidID checks that the value can be used as the ID of a filter
+func isValidID(value string) bool {
+	return len(value) <= MaxIDLength && !strings.ContainsAny(value, " \t\r\n")
+}
@@ -0,0 +1,13 @@
+package timeout
+
+import (
+	"time"
+)
+
+const (
+	// DefaultKey is the key of the deadline of the routes without their own one
+	DefaultKey = "BACKEND_TIMEOUT"
+
+	// DefaultTimeout is the default deadline of the routes without their own one
+	DefaultTimeout = 10 * time.Second
+)
@@ -0,0 +1,9 @@
+package timeout
+
+import (
+	"errors"
+)
+
+var (
+	NonPositiveTimeoutError = errors.New("non-positive timeout")
+)
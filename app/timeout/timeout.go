@@ -0,0 +1,71 @@
+package timeout
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	approute "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/route"
+	"time"
+)
+
+// Middleware sets the deadline of every request from the timeout of its route, so the backend calls made while
+// handling it are bounded
+type Middleware struct {
+	defaultTimeout time.Duration
+	routeTimeouts  map[string]time.Duration
+}
+
+// NewMiddleware creates a new timeout middleware with the timeouts of the routes indexed by their route key, like
+// "GET /api/v1/shops/products/{product-id}", and the default timeout of the rest
+func NewMiddleware(defaultTimeout time.Duration, routeTimeouts map[string]time.Duration) (*Middleware, error) {
+	if defaultTimeout <= 0 {
+		return nil, NonPositiveTimeoutError
+	}
+
+	// Check the timeouts and normalize the route keys
+	normalizedTimeouts := make(map[string]time.Duration, len(routeTimeouts))
+	for key, timeout := range routeTimeouts {
+		if timeout <= 0 {
+			return nil, NonPositiveTimeoutError
+		}
+		normalizedKey, err := approute.ParseKey(key)
+		if err != nil {
+			return nil, err
+		}
+		normalizedTimeouts[normalizedKey] = timeout
+	}
+
+	return &Middleware{
+		defaultTimeout: defaultTimeout,
+		routeTimeouts:  normalizedTimeouts,
+	}, nil
+}
+
+// Handler sets the deadline of the request context, which is also cancelled when the client disconnects or once the
+// request has been handled
+func (m *Middleware) Handler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		timeout, ok := m.routeTimeouts[approute.KeyFromGinContext(ctx)]
+		if !ok {
+			timeout = m.defaultTimeout
+		}
+
+		requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), timeout)
+		defer cancel()
+		ctx.Request = ctx.Request.WithContext(requestCtx)
+
+		ctx.Next()
+	}
+}
+
+// Bind returns a copy of the context of a backend call with the deadline of the request context, which is cancelled
+// as soon as the request context is done, like when the client disconnects
+func Bind(ctx, requestCtx context.Context) context.Context {
+	var cancel context.CancelFunc
+	if deadline, ok := requestCtx.Deadline(); ok {
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	context.AfterFunc(requestCtx, cancel)
+	return ctx
+}
@@ -0,0 +1,54 @@
+package tracing
+
+import (
+	"time"
+)
+
+const (
+	// ExporterKey is the key of the exporter the finished spans are sent to
+	ExporterKey = "TRACING_EXPORTER"
+
+	// OTLPEndpointKey is the key of the base URL of the OTLP/HTTP collector
+	OTLPEndpointKey = "TRACING_OTLP_ENDPOINT"
+
+	// SampleRatioKey is the key of the ratio of the new traces that are sampled
+	SampleRatioKey = "TRACING_SAMPLE_RATIO"
+
+	// ServiceNameKey is the key of the service name reported in the traces
+	ServiceNameKey = "TRACING_SERVICE_NAME"
+
+	// DefaultServiceName is the default service name reported in the traces
+	DefaultServiceName = "api-gateway"
+
+	// DefaultSampleRatio is the default ratio of the new traces that are sampled
+	DefaultSampleRatio = 1.0
+)
+
+const (
+	// NoneExporter drops every span, while the trace context is still propagated to the backend services
+	NoneExporter = "none"
+
+	// StdoutExporter writes every span as JSON to the standard output
+	StdoutExporter = "stdout"
+
+	// OTLPExporter sends the spans to an OpenTelemetry collector through OTLP/HTTP
+	OTLPExporter = "otlp"
+)
+
+const (
+	// otlpTracesPath is the path of the OTLP/HTTP traces endpoint
+	otlpTracesPath = "/v1/traces"
+
+	// instrumentationName is the name of the instrumentation scope reported in the traces
+	instrumentationName = "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/tracing"
+
+	// authenticationSpanName is the name of the span of the authentication middleware of the authenticated routes,
+	// which validates the JWT of the request
+	authenticationSpanName = "jwt.validate"
+
+	// authenticationSpanKey is the key of the span of the authentication of the request in the Gin context
+	authenticationSpanKey = "tracing.authentication_span"
+
+	// shutdownTimeout is the timeout of the export of the spans that are still queued once the tracer is closed
+	shutdownTimeout = 10 * time.Second
+)
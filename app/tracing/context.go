@@ -0,0 +1,15 @@
+package tracing
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SpanFromGinContext returns the server span of the request, or nil if the request is not traced
+func SpanFromGinContext(ctx *gin.Context) trace.Span {
+	span := trace.SpanFromContext(ctx.Request.Context())
+	if !span.SpanContext().IsValid() {
+		return nil
+	}
+	return span
+}
@@ -0,0 +1,12 @@
+package tracing
+
+import (
+	"errors"
+)
+
+var (
+	NilLoggerError          = errors.New("nil logger")
+	InvalidExporterError    = errors.New("invalid tracing exporter")
+	InvalidSampleRatioError = errors.New("sample ratio must be between 0 and 1")
+	MissingEndpointError    = errors.New("missing OTLP endpoint")
+)
@@ -0,0 +1,38 @@
+package tracing
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"io"
+	"strings"
+)
+
+// NewExporter creates the exporter with the given name, which is nil for the none exporter
+func NewExporter(name, otlpEndpoint string, stdout io.Writer) (sdktrace.SpanExporter, error) {
+	switch name {
+	case NoneExporter:
+		return nil, nil
+	case StdoutExporter:
+		return stdouttrace.New(stdouttrace.WithWriter(stdout))
+	case OTLPExporter:
+		return NewOTLPExporter(otlpEndpoint)
+	default:
+		return nil, InvalidExporterError
+	}
+}
+
+// NewOTLPExporter creates a new exporter that sends the spans to the collector at the given base URL, like
+// http://localhost:4318
+func NewOTLPExporter(endpoint string) (sdktrace.SpanExporter, error) {
+	// Check if the endpoint is empty
+	if endpoint == "" {
+		return nil, MissingEndpointError
+	}
+
+	return otlptracehttp.New(
+		context.Background(),
+		otlptracehttp.WithEndpointURL(strings.TrimSuffix(endpoint, "/")+otlpTracesPath),
+	)
+}
@@ -0,0 +1,66 @@
+package tracing
+
+import (
+	"github.com/gin-gonic/gin"
+	commonhandler "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/route"
+	pbtypesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RouteHandler creates the endpoints like the wrapped route handler does, tracing the authentication middleware of the
+// authenticated ones as a child span of the request, which ends once the token is validated and the route handler
+// starts, or once the request is rejected
+type RouteHandler struct {
+	commonhandler.Handler
+}
+
+// NewRouteHandler creates a new route handler that traces the authentication of the endpoints created by the given one
+func NewRouteHandler(handler commonhandler.Handler) *RouteHandler {
+	return &RouteHandler{Handler: handler}
+}
+
+// CreateAuthenticatedEndpoint creates the authenticated endpoint, tracing its authentication middleware
+func (r *RouteHandler) CreateAuthenticatedEndpoint(mapper *pbtypesrest.Mapper, handler gin.HandlerFunc) (
+	string,
+	gin.HandlerFunc,
+	gin.HandlerFunc,
+) {
+	path, authHandler, handler := r.Handler.CreateAuthenticatedEndpoint(mapper, handler)
+	return path, traceAuthentication(authHandler), func(ctx *gin.Context) {
+		endAuthentication(ctx)
+		handler(ctx)
+	}
+}
+
+// traceAuthentication wraps the authentication middleware of a route, which calls the route handler itself once the
+// token is validated, with the span of the authentication
+func traceAuthentication(authHandler gin.HandlerFunc) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		span := SpanFromGinContext(ctx)
+		if span == nil {
+			authHandler(ctx)
+			return
+		}
+
+		_, child := span.TracerProvider().Tracer(instrumentationName).Start(
+			ctx.Request.Context(),
+			authenticationSpanName,
+		)
+		ctx.Set(authenticationSpanKey, child)
+		authHandler(ctx)
+
+		// End the span of the rejected requests, which never reach the route handler
+		endAuthentication(ctx)
+	}
+}
+
+// endAuthentication ends the span of the authentication of the request, if it is still recording
+func endAuthentication(ctx *gin.Context) {
+	value, ok := ctx.Get(authenticationSpanKey)
+	if !ok {
+		return
+	}
+	if span, ok := value.(trace.Span); ok && span.IsRecording() {
+		span.End()
+	}
+}
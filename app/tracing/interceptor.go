@@ -0,0 +1,60 @@
+package tracing
+
+import (
+	"context"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/stats"
+)
+
+// ClientHandler starts a client span for every attempt of the calls made to the given backend service and propagates
+// the trace context to the service through the W3C Trace Context metadata
+func (t *Tracer) ClientHandler(service string) stats.Handler {
+	return otelgrpc.NewClientHandler(
+		otelgrpc.WithTracerProvider(t.provider),
+		otelgrpc.WithPropagators(t.propagator),
+		otelgrpc.WithSpanAttributes(semconv.PeerService(service)),
+	)
+}
+
+// WrapUnaryClientInterceptor records the time spent by the given interceptor before it invokes the next one, like the
+// time spent fetching the Google ID token by the authentication interceptor, as a span with the given name
+func (t *Tracer) WrapUnaryClientInterceptor(
+	name string,
+	interceptor grpc.UnaryClientInterceptor,
+) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		_, span := t.tracer.Start(ctx, name, trace.WithAttributes(semconv.RPCMethod(method)))
+
+		err := interceptor(
+			ctx, method, req, reply, cc, func(
+				ctx context.Context,
+				method string,
+				req, reply interface{},
+				cc *grpc.ClientConn,
+				opts ...grpc.CallOption,
+			) error {
+				span.End()
+				return invoker(ctx, method, req, reply, cc, opts...)
+			}, opts...,
+		)
+
+		// The interceptor failed before invoking the next one
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+		return err
+	}
+}
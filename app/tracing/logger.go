@@ -0,0 +1,25 @@
+package tracing
+
+import (
+	"log/slog"
+)
+
+// Logger is the logger for the tracer
+type Logger struct {
+	logger *slog.Logger
+}
+
+// NewLogger creates a new tracer logger
+func NewLogger(logger *slog.Logger) (*Logger, error) {
+	// Check if the logger is nil
+	if logger == nil {
+		return nil, NilLoggerError
+	}
+
+	return &Logger{logger: logger}, nil
+}
+
+// Failed logs an error reported by the OpenTelemetry SDK, like a batch of spans that could not be exported
+func (l *Logger) Failed(err error) {
+	l.logger.Error("tracing failed", slog.String("error", err.Error()))
+}
@@ -0,0 +1,48 @@
+package tracing
+
+import (
+	"github.com/gin-gonic/gin"
+	appaccesslog "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/accesslog"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+)
+
+// HTTPMiddleware starts the server span of every request, continuing the trace of the W3C traceparent header if the
+// client sent one
+func (t *Tracer) HTTPMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		// Continue the trace of the client, if any
+		requestCtx := t.propagator.Extract(ctx.Request.Context(), propagation.HeaderCarrier(ctx.Request.Header))
+
+		// Start the server span
+		route := ctx.FullPath()
+		name := ctx.Request.Method
+		if route != "" {
+			name += " " + route
+		}
+		requestCtx, span := t.tracer.Start(
+			requestCtx,
+			name,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(ctx.Request.Method),
+				semconv.HTTPRoute(route),
+				semconv.URLPath(appaccesslog.RedactPath(ctx)),
+			),
+		)
+		ctx.Request = ctx.Request.WithContext(requestCtx)
+
+		ctx.Next()
+
+		// Record the response status
+		statusCode := ctx.Writer.Status()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(statusCode))
+		if statusCode >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(statusCode))
+		}
+		span.End()
+	}
+}
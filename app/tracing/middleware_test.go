@@ -0,0 +1,170 @@
+package tracing
+
+import (
+	"github.com/gin-gonic/gin"
+	appaccesslog "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/accesslog"
+	pbtypesrest "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/rest"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type (
+	// authenticatingHandler creates the endpoints like the default route handler, with an authentication middleware
+	// that rejects the requests without an Authorization header
+	authenticatingHandler struct{}
+)
+
+// CreateAuthenticatedEndpoint creates the authenticated endpoint
+func (authenticatingHandler) CreateAuthenticatedEndpoint(mapper *pbtypesrest.Mapper, handler gin.HandlerFunc) (
+	string,
+	gin.HandlerFunc,
+	gin.HandlerFunc,
+) {
+	return mapper.Path(), func(ctx *gin.Context) {
+		if ctx.GetHeader("Authorization") == "" {
+			ctx.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		ctx.Next()
+	}, handler
+}
+
+// CreateUnauthenticatedEndpoint creates the unauthenticated endpoint
+func (authenticatingHandler) CreateUnauthenticatedEndpoint(mapper *pbtypesrest.Mapper, handler gin.HandlerFunc) (
+	string,
+	gin.HandlerFunc,
+) {
+	return mapper.Path(), handler
+}
+
+// newTestTracer creates a tracer that samples every trace and records its spans
+func newTestTracer() (*Tracer, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		sdktrace.WithSpanProcessor(recorder),
+	)
+	return &Tracer{
+		provider:   provider,
+		tracer:     provider.Tracer(instrumentationName),
+		propagator: propagation.TraceContext{},
+	}, recorder
+}
+
+// endedSpan returns the ended span with the given name, or nil if there is none
+func endedSpan(recorder *tracetest.SpanRecorder, name string) sdktrace.ReadOnlySpan {
+	for _, span := range recorder.Ended() {
+		if span.Name() == name {
+			return span
+		}
+	}
+	return nil
+}
+
+// TestHTTPMiddleware checks the path of the requests is exported with the values of their sensitive path parameters
+// and their email addresses redacted
+func TestHTTPMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cases := []struct {
+		name  string
+		route string
+		path  string
+		want  string
+	}{
+		{
+			name:  "sensitive parameter",
+			route: "/users/reset-password/:token",
+			path:  "/users/reset-password/secret-token",
+			want:  "/users/reset-password/" + appaccesslog.RedactedValue,
+		},
+		{
+			name:  "email parameter",
+			route: "/users/emails/:email",
+			path:  "/users/emails/john.doe@example.com",
+			want:  "/users/emails/" + appaccesslog.RedactedValue + "@example.com",
+		},
+		{
+			name:  "plain parameter",
+			route: "/shops/businesses/:business-id",
+			path:  "/shops/businesses/business-id",
+			want:  "/shops/businesses/business-id",
+		},
+	}
+	for _, c := range cases {
+		t.Run(
+			c.name, func(t *testing.T) {
+				tracer, recorder := newTestTracer()
+				router := gin.New()
+				router.Use(tracer.HTTPMiddleware())
+				router.GET(c.route, func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+				router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, c.path, nil))
+
+				span := endedSpan(recorder, http.MethodGet+" "+c.route)
+				if span == nil {
+					t.Fatalf("no span of the request")
+				}
+				for _, attribute := range span.Attributes() {
+					if attribute.Key == semconv.URLPathKey && attribute.Value.AsString() != c.want {
+						t.Errorf("url.path = %s, want %s", attribute.Value.AsString(), c.want)
+					}
+				}
+			},
+		)
+	}
+}
+
+// TestRouteHandler checks the span of the authentication ends before the route handler starts, or once the request is
+// rejected
+func TestRouteHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cases := []struct {
+		name          string
+		authorization string
+		want          int
+	}{
+		{name: "authenticated", authorization: "Bearer token", want: http.StatusOK},
+		{name: "rejected", want: http.StatusUnauthorized},
+	}
+	for _, c := range cases {
+		t.Run(
+			c.name, func(t *testing.T) {
+				tracer, recorder := newTestTracer()
+				router := gin.New()
+				router.Use(tracer.HTTPMiddleware())
+
+				mapper := &pbtypesrest.Mapper{Endpoint: pbtypesrest.NewEndpoint("businesses")}
+				router.GET(
+					NewRouteHandler(authenticatingHandler{}).CreateAuthenticatedEndpoint(
+						mapper, func(ctx *gin.Context) {
+							if endedSpan(recorder, authenticationSpanName) == nil {
+								t.Errorf("span of the authentication not ended before the route handler")
+							}
+							ctx.Status(http.StatusOK)
+						},
+					),
+				)
+
+				request := httptest.NewRequest(http.MethodGet, "/businesses", nil)
+				if c.authorization != "" {
+					request.Header.Set("Authorization", c.authorization)
+				}
+				response := httptest.NewRecorder()
+				router.ServeHTTP(response, request)
+
+				if response.Code != c.want {
+					t.Errorf("status = %d, want %d", response.Code, c.want)
+				}
+				if endedSpan(recorder, authenticationSpanName) == nil {
+					t.Errorf("span of the authentication not ended")
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,63 @@
+package tracing
+
+import (
+	"context"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracer creates the spans of the requests and the backend calls, and exports the sampled ones in batches in the
+// background
+type Tracer struct {
+	provider   *sdktrace.TracerProvider
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// NewTracer creates a new tracer that samples the given ratio of the new traces, while the traces started by
+// another service keep the sampling decision of their parent. The spans are dropped if the exporter is nil
+func NewTracer(exporter sdktrace.SpanExporter, serviceName string, sampleRatio float64, logger *Logger) (
+	*Tracer,
+	error,
+) {
+	// Check if the logger is nil
+	if logger == nil {
+		return nil, NilLoggerError
+	}
+
+	// Check if the sample ratio is valid
+	if sampleRatio < 0 || sampleRatio > 1 {
+		return nil, InvalidSampleRatioError
+	}
+
+	// Log the export failures, which the SDK reports to the global error handler
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(logger.Failed))
+
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+		sdktrace.WithResource(
+			resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName)),
+		),
+	}
+	if exporter != nil {
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+	provider := sdktrace.NewTracerProvider(options...)
+
+	return &Tracer{
+		provider:   provider,
+		tracer:     provider.Tracer(instrumentationName),
+		propagator: propagation.TraceContext{},
+	}, nil
+}
+
+// Close stops the tracer and exports the spans that are still queued
+func (t *Tracer) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return t.provider.Shutdown(ctx)
+}
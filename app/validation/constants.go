@@ -0,0 +1,6 @@
+package validation
+
+const (
+	// ginCtxKey is the key of the validator in the Gin context
+	ginCtxKey = "validator"
+)
@@ -0,0 +1,24 @@
+package validation
+
+type (
+	// Error is the error of a request message with invalid fields
+	Error struct {
+		Violations []Violation
+	}
+
+	// Violation is the violation of a rule by a field of a request message
+	Violation struct {
+		Field       string
+		Description string
+	}
+)
+
+// Error returns the message of the error
+func (e *Error) Error() string {
+	return InvalidFieldsError.Error()
+}
+
+// Unwrap returns the generic error of the invalid fields
+func (e *Error) Unwrap() error {
+	return InvalidFieldsError
+}
@@ -0,0 +1,13 @@
+package validation
+
+import (
+	"errors"
+)
+
+var (
+	NilMessageError       = errors.New("nil request message")
+	DuplicateMessageError = errors.New("duplicate rules of a request message")
+	UnknownFieldError     = errors.New("unknown field")
+	UnsupportedRuleError  = errors.New("unsupported rule for the kind of the field")
+	InvalidFieldsError    = errors.New("the request has invalid fields")
+)
@@ -0,0 +1,49 @@
+package validation
+
+import (
+	"net/mail"
+	"regexp"
+)
+
+var (
+	// usernamePattern is the pattern of the usernames
+	usernamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]{3,32}$`)
+
+	// uuidPattern is the pattern of the UUIDs in their canonical form
+	uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+	// objectIDPattern is the pattern of the MongoDB ObjectIDs in hexadecimal
+	objectIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{24}$`)
+
+	// phoneNumberPattern is the pattern of the phone numbers in the E.164 format, with an optional plus sign
+	phoneNumberPattern = regexp.MustCompile(`^\+?[1-9][0-9]{6,14}$`)
+)
+
+// IsEmail checks if the value is a bare email address, without a display name
+func IsEmail(value string) bool {
+	if len(value) > 254 {
+		return false
+	}
+	address, err := mail.ParseAddress(value)
+	return err == nil && address.Address == value
+}
+
+// IsUsername checks if the value is a username
+func IsUsername(value string) bool {
+	return usernamePattern.MatchString(value)
+}
+
+// IsUUID checks if the value is a UUID in its canonical form
+func IsUUID(value string) bool {
+	return uuidPattern.MatchString(value)
+}
+
+// IsObjectID checks if the value is a MongoDB ObjectID in hexadecimal
+func IsObjectID(value string) bool {
+	return objectIDPattern.MatchString(value)
+}
+
+// IsPhoneNumber checks if the value is a phone number in the E.164 format
+func IsPhoneNumber(value string) bool {
+	return phoneNumberPattern.MatchString(value)
+}
@@ -0,0 +1,41 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestFormats checks the formats of the string fields
+func TestFormats(t *testing.T) {
+	cases := []struct {
+		name    string
+		matches func(string) bool
+		value   string
+		want    bool
+	}{
+		{name: "email", matches: IsEmail, value: "john.doe@example.com", want: true},
+		{name: "email with display name", matches: IsEmail, value: "John <john.doe@example.com>", want: false},
+		{name: "email without domain", matches: IsEmail, value: "john.doe@", want: false},
+		{name: "email too long", matches: IsEmail, value: strings.Repeat("a", 250) + "@b.co", want: false},
+		{name: "username", matches: IsUsername, value: "john_doe.92", want: true},
+		{name: "username too short", matches: IsUsername, value: "jd", want: false},
+		{name: "username with spaces", matches: IsUsername, value: "john doe", want: false},
+		{name: "UUID", matches: IsUUID, value: "3f2c9a4e-8b7d-4c1a-9e6f-0b2d5a8c7e1f", want: true},
+		{name: "UUID without dashes", matches: IsUUID, value: "3f2c9a4e8b7d4c1a9e6f0b2d5a8c7e1f", want: false},
+		{name: "ObjectID", matches: IsObjectID, value: "507f1f77bcf86cd799439011", want: true},
+		{name: "ObjectID too short", matches: IsObjectID, value: "507f1f77bcf86cd79943901", want: false},
+		{name: "phone number", matches: IsPhoneNumber, value: "+584141234567", want: true},
+		{name: "phone number without plus sign", matches: IsPhoneNumber, value: "584141234567", want: true},
+		{name: "phone number with leading zero", matches: IsPhoneNumber, value: "04141234567", want: false},
+	}
+
+	for _, c := range cases {
+		t.Run(
+			c.name, func(t *testing.T) {
+				if got := c.matches(c.value); got != c.want {
+					t.Errorf("matches(%q) = %v, want %v", c.value, got, c.want)
+				}
+			},
+		)
+	}
+}
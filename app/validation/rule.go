@@ -0,0 +1,204 @@
+package validation
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type (
+	// Rule is a declarative rule of a field of a request message. The rules but Required skip the empty strings and
+	// the unset messages and lists, while the numbers are always checked, since their zero value cannot be told apart
+	// from an unset one
+	Rule struct {
+		name    string
+		compile func(field protoreflect.FieldDescriptor) (check, bool)
+	}
+
+	// check checks a field of a message, returning its violations named after the given path of the field
+	check func(message protoreflect.Message, field protoreflect.FieldDescriptor, path string) []Violation
+)
+
+// Required checks that the field is set, and that the strings are not blank
+func Required() Rule {
+	return Rule{
+		name: "required",
+		compile: func(field protoreflect.FieldDescriptor) (check, bool) {
+			return func(message protoreflect.Message, field protoreflect.FieldDescriptor, path string) []Violation {
+				blank := field.Kind() == protoreflect.StringKind && !field.IsList() &&
+					strings.TrimSpace(message.Get(field).String()) == ""
+				if !message.Has(field) || blank {
+					return []Violation{{Field: path, Description: "is required"}}
+				}
+				return nil
+			}, true
+		},
+	}
+}
+
+// Length checks that the strings have between the given numbers of characters, or that the list has between the
+// given numbers of items
+func Length(min, max int) Rule {
+	return Rule{
+		name: "length",
+		compile: func(field protoreflect.FieldDescriptor) (check, bool) {
+			if field.IsList() {
+				return func(message protoreflect.Message, field protoreflect.FieldDescriptor, path string) []Violation {
+					if !message.Has(field) {
+						return nil
+					}
+					if length := message.Get(field).List().Len(); length < min || length > max {
+						return []Violation{
+							{Field: path, Description: fmt.Sprintf("must have between %d and %d items", min, max)},
+						}
+					}
+					return nil
+				}, true
+			}
+			return stringCheck(
+				field, func(value string) string {
+					if length := utf8.RuneCountInString(value); length < min || length > max {
+						return fmt.Sprintf("must have between %d and %d characters", min, max)
+					}
+					return ""
+				},
+			)
+		},
+	}
+}
+
+// Range checks that the number is between the given bounds, both included
+func Range(min, max float64) Rule {
+	description := fmt.Sprintf("must be between %s and %s", formatNumber(min), formatNumber(max))
+	return numberRule("range", min, max, description)
+}
+
+// Min checks that the number is greater than or equal to the given bound
+func Min(min float64) Rule {
+	return numberRule("min", min, math.Inf(1), "must be at least "+formatNumber(min))
+}
+
+// Email checks that the strings are bare email addresses
+func Email() Rule {
+	return formatRule("email", IsEmail, "must be a valid email address")
+}
+
+// Username checks that the strings are usernames
+func Username() Rule {
+	return formatRule(
+		"username",
+		IsUsername,
+		"must have between 3 and 32 letters, digits, dots, dashes or underscores",
+	)
+}
+
+// UUID checks that the strings are UUIDs in their canonical form
+func UUID() Rule {
+	return formatRule("uuid", IsUUID, "must be a valid UUID")
+}
+
+// ObjectID checks that the strings are MongoDB ObjectIDs in hexadecimal
+func ObjectID() Rule {
+	return formatRule("object_id", IsObjectID, "must be a valid ObjectID")
+}
+
+// PhoneNumber checks that the strings are phone numbers in the E.164 format
+func PhoneNumber() Rule {
+	return formatRule("phone_number", IsPhoneNumber, "must be a valid phone number in the E.164 format")
+}
+
+// formatRule creates a rule that checks that the strings match the given format
+func formatRule(name string, matches func(string) bool, description string) Rule {
+	return Rule{
+		name: name,
+		compile: func(field protoreflect.FieldDescriptor) (check, bool) {
+			return stringCheck(
+				field, func(value string) string {
+					if !matches(value) {
+						return description
+					}
+					return ""
+				},
+			)
+		},
+	}
+}
+
+// numberRule creates a rule that checks that the number is between the given bounds, both included
+func numberRule(name string, min, max float64, description string) Rule {
+	return Rule{
+		name: name,
+		compile: func(field protoreflect.FieldDescriptor) (check, bool) {
+			if field.IsList() || field.IsMap() || !isNumber(field.Kind()) {
+				return nil, false
+			}
+			return func(message protoreflect.Message, field protoreflect.FieldDescriptor, path string) []Violation {
+				if value := toFloat(message.Get(field), field.Kind()); value < min || value > max {
+					return []Violation{{Field: path, Description: description}}
+				}
+				return nil
+			}, true
+		},
+	}
+}
+
+// stringCheck creates the check of a string field, or a list of strings, that describes the violation of each
+// non-empty string, or returns an empty string if it is valid
+func stringCheck(field protoreflect.FieldDescriptor, describe func(value string) string) (check, bool) {
+	if field.Kind() != protoreflect.StringKind || field.IsMap() {
+		return nil, false
+	}
+	return func(message protoreflect.Message, field protoreflect.FieldDescriptor, path string) []Violation {
+		var violations []Violation
+		validate := func(value, path string) {
+			if value == "" {
+				return
+			}
+			if description := describe(value); description != "" {
+				violations = append(violations, Violation{Field: path, Description: description})
+			}
+		}
+
+		if !field.IsList() {
+			validate(message.Get(field).String(), path)
+			return violations
+		}
+		list := message.Get(field).List()
+		for index := 0; index < list.Len(); index++ {
+			validate(list.Get(index).String(), path+"["+strconv.Itoa(index)+"]")
+		}
+		return violations
+	}, true
+}
+
+// isNumber checks if the kind is a numeric one
+func isNumber(kind protoreflect.Kind) bool {
+	switch kind {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind,
+		protoreflect.FloatKind, protoreflect.DoubleKind:
+		return true
+	}
+	return false
+}
+
+// toFloat converts the value of a numeric field to a float
+func toFloat(value protoreflect.Value, kind protoreflect.Kind) float64 {
+	switch kind {
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return float64(value.Uint())
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return value.Float()
+	}
+	return float64(value.Int())
+}
+
+// formatNumber formats a bound of a rule
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,34 @@
+package rules
+
+import (
+	appvalidation "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/validation"
+	pbauth "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/auth"
+)
+
+// authMessages are the rules of the request messages of the auth service
+var authMessages = []appvalidation.Message{
+	appvalidation.NewMessage(
+		&pbauth.LogInRequest{},
+		appvalidation.NewField("username", appvalidation.Required()),
+		appvalidation.NewField("password", appvalidation.Required()),
+	),
+	appvalidation.NewMessage(
+		&pbauth.AddRoleRequest{},
+		appvalidation.NewField("role", appvalidation.Required(), appvalidation.Length(1, maxNameLength)),
+	),
+	appvalidation.NewMessage(
+		&pbauth.AddPermissionRequest{},
+		appvalidation.NewField("permission", appvalidation.Required()),
+		appvalidation.NewField("permission.resource", appvalidation.Required()),
+		appvalidation.NewField("permission.action", appvalidation.Required()),
+		appvalidation.NewField("permission.description", appvalidation.Length(0, maxDescriptionLength)),
+	),
+	appvalidation.NewMessage(
+		&pbauth.AddRolePermissionRequest{},
+		appvalidation.NewField("permission_id", appvalidation.Required()),
+	),
+	appvalidation.NewMessage(
+		&pbauth.AddUserRoleRequest{},
+		appvalidation.NewField("role_id", appvalidation.Required()),
+	),
+}
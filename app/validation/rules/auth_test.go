@@ -0,0 +1,78 @@
+package rules
+
+import (
+	pbauth "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/auth"
+	"testing"
+)
+
+// TestAuthMessages checks the rules of the request messages of the auth service
+func TestAuthMessages(t *testing.T) {
+	testValidation(
+		t, []validationCase{
+			{
+				name:    "LogInRequest valid",
+				message: &pbauth.LogInRequest{Username: "john.doe", Password: "secret-password"},
+			},
+			{
+				name:       "LogInRequest missing",
+				message:    &pbauth.LogInRequest{Password: " "},
+				violations: []string{"username", "password"},
+			},
+			{
+				name:    "AddRoleRequest valid",
+				message: &pbauth.AddRoleRequest{Role: "admin"},
+			},
+			{
+				name:       "AddRoleRequest missing",
+				message:    &pbauth.AddRoleRequest{},
+				violations: []string{"role"},
+			},
+			{
+				name:       "AddRoleRequest malformed",
+				message:    &pbauth.AddRoleRequest{Role: longName},
+				violations: []string{"role"},
+			},
+			{
+				name: "AddPermissionRequest valid",
+				message: &pbauth.AddPermissionRequest{
+					Permission: &pbauth.Permission{Resource: "users", Action: "read"},
+				},
+			},
+			{
+				name:       "AddPermissionRequest missing",
+				message:    &pbauth.AddPermissionRequest{},
+				violations: []string{"permission"},
+			},
+			{
+				name:       "AddPermissionRequest missing nested",
+				message:    &pbauth.AddPermissionRequest{Permission: &pbauth.Permission{}},
+				violations: []string{"permission.resource", "permission.action"},
+			},
+			{
+				name: "AddPermissionRequest malformed",
+				message: &pbauth.AddPermissionRequest{
+					Permission: &pbauth.Permission{Resource: "users", Action: "read", Description: longDescription},
+				},
+				violations: []string{"permission.description"},
+			},
+			{
+				name:    "AddRolePermissionRequest valid",
+				message: &pbauth.AddRolePermissionRequest{PermissionId: "permission-id"},
+			},
+			{
+				name:       "AddRolePermissionRequest missing",
+				message:    &pbauth.AddRolePermissionRequest{},
+				violations: []string{"permission_id"},
+			},
+			{
+				name:    "AddUserRoleRequest valid",
+				message: &pbauth.AddUserRoleRequest{RoleId: "role-id"},
+			},
+			{
+				name:       "AddUserRoleRequest missing",
+				message:    &pbauth.AddUserRoleRequest{},
+				violations: []string{"role_id"},
+			},
+		},
+	)
+}
@@ -0,0 +1,15 @@
+package rules
+
+import (
+	appvalidation "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/validation"
+	pborder "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/order"
+)
+
+// orderMessages are the rules of the request messages of the order service
+var orderMessages = []appvalidation.Message{
+	appvalidation.NewMessage(
+		&pborder.AddProductToCartRequest{},
+		appvalidation.NewField("branch_product_id", appvalidation.Required()),
+		appvalidation.NewField("quantity", appvalidation.Min(1)),
+	),
+}
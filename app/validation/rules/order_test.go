@@ -0,0 +1,28 @@
+package rules
+
+import (
+	pborder "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/order"
+	"testing"
+)
+
+// TestOrderMessages checks the rules of the request messages of the order service
+func TestOrderMessages(t *testing.T) {
+	testValidation(
+		t, []validationCase{
+			{
+				name:    "AddProductToCartRequest valid",
+				message: &pborder.AddProductToCartRequest{BranchProductId: "branch-product-id", Quantity: 2},
+			},
+			{
+				name:       "AddProductToCartRequest missing",
+				message:    &pborder.AddProductToCartRequest{},
+				violations: []string{"branch_product_id", "quantity"},
+			},
+			{
+				name:       "AddProductToCartRequest malformed",
+				message:    &pborder.AddProductToCartRequest{BranchProductId: "branch-product-id", Quantity: -1},
+				violations: []string{"quantity"},
+			},
+		},
+	)
+}
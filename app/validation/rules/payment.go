@@ -0,0 +1,47 @@
+package rules
+
+import (
+	appvalidation "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/validation"
+	pbpayment "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/payment"
+)
+
+// paymentMessages are the rules of the request messages of the payment service
+var paymentMessages = []appvalidation.Message{
+	appvalidation.NewMessage(
+		&pbpayment.AddPaymentAccountRequest{},
+		appvalidation.NewField("business_id", appvalidation.Required()),
+		appvalidation.NewField("payment_account", appvalidation.Required()),
+		appvalidation.NewField("payment_account.account_platform", appvalidation.Required()),
+		appvalidation.NewField("payment_account.account_identifier", appvalidation.Required()),
+		appvalidation.NewField("payment_account.account_email", appvalidation.Email()),
+		appvalidation.NewField("payment_account.account_phone_number", appvalidation.PhoneNumber()),
+	),
+	appvalidation.NewMessage(
+		&pbpayment.AddOrderPaymentRequest{},
+		appvalidation.NewField("payment", appvalidation.Required()),
+		appvalidation.NewField("payment.payment_account_id", appvalidation.Required()),
+		appvalidation.NewField("payment.amount", appvalidation.Required()),
+	),
+	appvalidation.NewMessage(
+		&pbpayment.PayForOrderRequest{},
+		appvalidation.NewField("payment", appvalidation.Required()),
+		appvalidation.NewField("payment.payment_account_id", appvalidation.Required()),
+		appvalidation.NewField("payment.amount", appvalidation.Required()),
+	),
+	appvalidation.NewMessage(
+		&pbpayment.AddBranchRentPaymentRequest{},
+		appvalidation.NewField("payment", appvalidation.Required()),
+		appvalidation.NewField("payment.payment_account_id", appvalidation.Required()),
+		appvalidation.NewField("payment.amount", appvalidation.Required()),
+	),
+	appvalidation.NewMessage(
+		&pbpayment.PayForBranchRentRequest{},
+		appvalidation.NewField("payment", appvalidation.Required()),
+		appvalidation.NewField("payment.payment_account_id", appvalidation.Required()),
+		appvalidation.NewField("payment.amount", appvalidation.Required()),
+	),
+	appvalidation.NewMessage(
+		&pbpayment.VerifyPaymentRequest{},
+		appvalidation.NewField("payment_id", appvalidation.Required()),
+	),
+}
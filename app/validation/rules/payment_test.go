@@ -0,0 +1,113 @@
+package rules
+
+import (
+	pbpayment "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/payment"
+	"google.golang.org/protobuf/proto"
+	"testing"
+)
+
+// TestPaymentMessages checks the rules of the request messages of the payment service
+func TestPaymentMessages(t *testing.T) {
+	payment := &pbpayment.AddPayment{PaymentAccountId: "payment-account-id", Amount: "10.50"}
+
+	testValidation(
+		t, []validationCase{
+			{
+				name: "AddPaymentAccountRequest valid",
+				message: &pbpayment.AddPaymentAccountRequest{
+					BusinessId: proto.String("business-id"),
+					PaymentAccount: &pbpayment.PaymentAccount{
+						AccountPlatform:    "zelle",
+						AccountIdentifier:  "john.doe",
+						AccountEmail:       proto.String("john.doe@example.com"),
+						AccountPhoneNumber: proto.String("+584141234567"),
+					},
+				},
+			},
+			{
+				name:       "AddPaymentAccountRequest missing",
+				message:    &pbpayment.AddPaymentAccountRequest{},
+				violations: []string{"business_id", "payment_account"},
+			},
+			{
+				name: "AddPaymentAccountRequest missing nested",
+				message: &pbpayment.AddPaymentAccountRequest{
+					BusinessId:     proto.String("business-id"),
+					PaymentAccount: &pbpayment.PaymentAccount{},
+				},
+				violations: []string{"payment_account.account_platform", "payment_account.account_identifier"},
+			},
+			{
+				name: "AddPaymentAccountRequest malformed",
+				message: &pbpayment.AddPaymentAccountRequest{
+					BusinessId: proto.String("business-id"),
+					PaymentAccount: &pbpayment.PaymentAccount{
+						AccountPlatform:    "zelle",
+						AccountIdentifier:  "john.doe",
+						AccountEmail:       proto.String("john.doe"),
+						AccountPhoneNumber: proto.String("phone"),
+					},
+				},
+				violations: []string{"payment_account.account_email", "payment_account.account_phone_number"},
+			},
+			{
+				name:    "AddOrderPaymentRequest valid",
+				message: &pbpayment.AddOrderPaymentRequest{OrderId: "order-id", Payment: payment},
+			},
+			{
+				name:       "AddOrderPaymentRequest missing",
+				message:    &pbpayment.AddOrderPaymentRequest{},
+				violations: []string{"payment"},
+			},
+			{
+				name:       "AddOrderPaymentRequest missing nested",
+				message:    &pbpayment.AddOrderPaymentRequest{OrderId: "order-id", Payment: &pbpayment.AddPayment{}},
+				violations: []string{"payment.payment_account_id", "payment.amount"},
+			},
+			{
+				name:    "PayForOrderRequest valid",
+				message: &pbpayment.PayForOrderRequest{OrderId: "order-id", Payment: payment},
+			},
+			{
+				name:       "PayForOrderRequest missing",
+				message:    &pbpayment.PayForOrderRequest{},
+				violations: []string{"payment"},
+			},
+			{
+				name: "PayForOrderRequest missing nested",
+				message: &pbpayment.PayForOrderRequest{
+					OrderId: "order-id",
+					Payment: &pbpayment.AddPayment{PaymentAccountId: "payment-account-id", Amount: " "},
+				},
+				violations: []string{"payment.amount"},
+			},
+			{
+				name:    "AddBranchRentPaymentRequest valid",
+				message: &pbpayment.AddBranchRentPaymentRequest{BranchRentId: "branch-rent-id", Payment: payment},
+			},
+			{
+				name:       "AddBranchRentPaymentRequest missing",
+				message:    &pbpayment.AddBranchRentPaymentRequest{},
+				violations: []string{"payment"},
+			},
+			{
+				name:    "PayForBranchRentRequest valid",
+				message: &pbpayment.PayForBranchRentRequest{BranchRentId: "branch-rent-id", Payment: payment},
+			},
+			{
+				name:       "PayForBranchRentRequest missing",
+				message:    &pbpayment.PayForBranchRentRequest{},
+				violations: []string{"payment"},
+			},
+			{
+				name:    "VerifyPaymentRequest valid",
+				message: &pbpayment.VerifyPaymentRequest{PaymentId: "payment-id"},
+			},
+			{
+				name:       "VerifyPaymentRequest missing",
+				message:    &pbpayment.VerifyPaymentRequest{},
+				violations: []string{"payment_id"},
+			},
+		},
+	)
+}
@@ -0,0 +1,35 @@
+package rules
+
+import (
+	appvalidation "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/validation"
+)
+
+const (
+	// maxNameLength is the maximum number of characters of the names
+	maxNameLength = 100
+
+	// maxDescriptionLength is the maximum number of characters of the descriptions
+	maxDescriptionLength = 1000
+
+	// minPasswordLength is the minimum number of characters of the passwords
+	minPasswordLength = 8
+
+	// maxPasswordLength is the maximum number of characters of the passwords
+	maxPasswordLength = 128
+)
+
+// All returns the rules of the request messages of every backend service. Only the fields of the request bodies are
+// declared, since the fields taken from the path are set after the body is validated
+func All() []appvalidation.Message {
+	var messages []appvalidation.Message
+	for _, service := range [][]appvalidation.Message{
+		authMessages,
+		userMessages,
+		shopMessages,
+		orderMessages,
+		paymentMessages,
+	} {
+		messages = append(messages, service...)
+	}
+	return messages
+}
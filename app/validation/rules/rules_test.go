@@ -0,0 +1,66 @@
+package rules
+
+import (
+	"errors"
+	appvalidation "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/validation"
+	"google.golang.org/protobuf/proto"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type (
+	// validationCase is a request message and the fields whose rules it violates, none if it is valid
+	validationCase struct {
+		name       string
+		message    proto.Message
+		violations []string
+	}
+)
+
+var (
+	// longName is a name with more characters than allowed
+	longName = strings.Repeat("a", maxNameLength+1)
+
+	// longDescription is a description with more characters than allowed
+	longDescription = strings.Repeat("a", maxDescriptionLength+1)
+)
+
+// TestAll checks that the rules of every request message compile
+func TestAll(t *testing.T) {
+	if _, err := appvalidation.NewValidator(All()...); err != nil {
+		t.Fatalf("NewValidator() error = %v", err)
+	}
+}
+
+// testValidation validates the request message of each case with every rule, checking the fields it violates
+func testValidation(t *testing.T, cases []validationCase) {
+	t.Helper()
+
+	validator, err := appvalidation.NewValidator(All()...)
+	if err != nil {
+		t.Fatalf("NewValidator() error = %v", err)
+	}
+
+	for _, c := range cases {
+		t.Run(
+			c.name, func(t *testing.T) {
+				err := validator.Validate(c.message)
+
+				var fields []string
+				var validationErr *appvalidation.Error
+				if errors.As(err, &validationErr) {
+					for _, violation := range validationErr.Violations {
+						fields = append(fields, violation.Field)
+					}
+				} else if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+
+				if !slices.Equal(fields, c.violations) {
+					t.Errorf("Validate() violated fields = %v, want %v", fields, c.violations)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,198 @@
+package rules
+
+import (
+	appvalidation "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/validation"
+	pbshop "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/shop"
+)
+
+// shopMessages are the rules of the request messages of the shop service
+var shopMessages = []appvalidation.Message{
+	// Businesses
+	appvalidation.NewMessage(
+		&pbshop.AddBusinessRequest{},
+		appvalidation.NewField("name", appvalidation.Required(), appvalidation.Length(1, maxNameLength)),
+		appvalidation.NewField("description", appvalidation.Length(0, maxDescriptionLength)),
+	),
+	appvalidation.NewMessage(
+		&pbshop.UpdateBusinessRequest{},
+		appvalidation.NewField("name", appvalidation.Length(1, maxNameLength)),
+		appvalidation.NewField("description", appvalidation.Length(0, maxDescriptionLength)),
+	),
+	appvalidation.NewMessage(
+		&pbshop.AddBusinessOwnerRequest{},
+		appvalidation.NewField("user_id", appvalidation.Required()),
+	),
+	appvalidation.NewMessage(
+		&pbshop.RemoveBusinessOwnerRequest{},
+		appvalidation.NewField("user_id", appvalidation.Required()),
+	),
+	appvalidation.NewMessage(
+		&pbshop.AddBusinessClientRequest{},
+		appvalidation.NewField("user_id", appvalidation.Required()),
+	),
+	appvalidation.NewMessage(
+		&pbshop.AddBusinessMarketCategoryRequest{},
+		appvalidation.NewField("business_id", appvalidation.Required()),
+		appvalidation.NewField("market_category_id", appvalidation.Required()),
+	),
+	appvalidation.NewMessage(
+		&pbshop.SetBusinessProfilePictureRequest{},
+		appvalidation.NewField("image_id", appvalidation.Required()),
+	),
+
+	// Branches and stores
+	appvalidation.NewMessage(
+		&pbshop.AddBranchRequest{},
+		appvalidation.NewField("store_id", appvalidation.Required()),
+		appvalidation.NewField("name", appvalidation.Required(), appvalidation.Length(1, maxNameLength)),
+		appvalidation.NewField("description", appvalidation.Length(0, maxDescriptionLength)),
+	),
+	appvalidation.NewMessage(
+		&pbshop.UpdateBranchRequest{},
+		appvalidation.NewField("name", appvalidation.Length(1, maxNameLength)),
+		appvalidation.NewField("description", appvalidation.Length(0, maxDescriptionLength)),
+	),
+	appvalidation.NewMessage(
+		&pbshop.AddStoreRequest{},
+		appvalidation.NewField("store", appvalidation.Required()),
+		appvalidation.NewField("store.store_number", appvalidation.Required()),
+		appvalidation.NewField("store.square_meters", appvalidation.Min(1)),
+	),
+	appvalidation.NewMessage(
+		&pbshop.AddBranchRentRequest{},
+		appvalidation.NewField("branch_id", appvalidation.Required()),
+		appvalidation.NewField("branch_rent", appvalidation.Required()),
+		appvalidation.NewField("branch_rent.store_id", appvalidation.Required()),
+		appvalidation.NewField("branch_rent.price", appvalidation.Min(0)),
+		appvalidation.NewField("branch_rent.from", appvalidation.Required()),
+		appvalidation.NewField("branch_rent.to", appvalidation.Required()),
+	),
+	appvalidation.NewMessage(
+		&pbshop.UpdateBranchRentRequest{},
+		appvalidation.NewField("branch_rent_id", appvalidation.Required()),
+	),
+
+	// Products
+	appvalidation.NewMessage(
+		&pbshop.AddProductRequest{},
+		appvalidation.NewField("product", appvalidation.Required()),
+		appvalidation.NewField("product.name", appvalidation.Required(), appvalidation.Length(1, maxNameLength)),
+		appvalidation.NewField("product.description", appvalidation.Length(0, maxDescriptionLength)),
+		appvalidation.NewField("product.price", appvalidation.Min(0)),
+		appvalidation.NewField("product.height", appvalidation.Min(0)),
+		appvalidation.NewField("product.length", appvalidation.Min(0)),
+		appvalidation.NewField("product.weight", appvalidation.Min(0)),
+		appvalidation.NewField("product.width", appvalidation.Min(0)),
+	),
+	appvalidation.NewMessage(
+		&pbshop.UpdateProductRequest{},
+		appvalidation.NewField("product", appvalidation.Required()),
+		appvalidation.NewField("product.name", appvalidation.Length(1, maxNameLength)),
+		appvalidation.NewField("product.description", appvalidation.Length(0, maxDescriptionLength)),
+		appvalidation.NewField("product.price", appvalidation.Min(0)),
+		appvalidation.NewField("product.height", appvalidation.Min(0)),
+		appvalidation.NewField("product.length", appvalidation.Min(0)),
+		appvalidation.NewField("product.weight", appvalidation.Min(0)),
+		appvalidation.NewField("product.width", appvalidation.Min(0)),
+	),
+	appvalidation.NewMessage(
+		&pbshop.AddBusinessProductRequest{},
+		appvalidation.NewField("business_id", appvalidation.Required()),
+		appvalidation.NewField("product_id", appvalidation.Required()),
+	),
+	appvalidation.NewMessage(
+		&pbshop.UpdateBusinessProductRequest{},
+		appvalidation.NewField("business_id", appvalidation.Required()),
+	),
+	appvalidation.NewMessage(
+		&pbshop.AddBranchProductRequest{},
+		appvalidation.NewField("product_id", appvalidation.Required()),
+		appvalidation.NewField("price", appvalidation.Min(0)),
+		appvalidation.NewField("discount_percentage", appvalidation.Range(0, 100)),
+		appvalidation.NewField("stock", appvalidation.Min(0)),
+	),
+	appvalidation.NewMessage(
+		&pbshop.UpdateBranchProductRequest{},
+		appvalidation.NewField("product_id", appvalidation.Required()),
+		appvalidation.NewField("price", appvalidation.Min(0)),
+		appvalidation.NewField("discount_percentage", appvalidation.Range(0, 100)),
+		appvalidation.NewField("stock", appvalidation.Min(0)),
+	),
+
+	// Categories
+	appvalidation.NewMessage(
+		&pbshop.AddProductCategoryRequest{},
+		appvalidation.NewField("name", appvalidation.Required(), appvalidation.Length(1, maxNameLength)),
+		appvalidation.NewField("description", appvalidation.Length(0, maxDescriptionLength)),
+	),
+	appvalidation.NewMessage(
+		&pbshop.UpdateProductCategoryRequest{},
+		appvalidation.NewField("product_category_id", appvalidation.Required()),
+		appvalidation.NewField("name", appvalidation.Length(1, maxNameLength)),
+		appvalidation.NewField("description", appvalidation.Length(0, maxDescriptionLength)),
+	),
+	appvalidation.NewMessage(
+		&pbshop.AddMarketCategoryRequest{},
+		appvalidation.NewField("market_category", appvalidation.Required()),
+		appvalidation.NewField(
+			"market_category.name",
+			appvalidation.Required(),
+			appvalidation.Length(1, maxNameLength),
+		),
+		appvalidation.NewField("market_category.description", appvalidation.Length(0, maxDescriptionLength)),
+	),
+	appvalidation.NewMessage(
+		&pbshop.UpdateMarketCategoryRequest{},
+		appvalidation.NewField("name", appvalidation.Length(1, maxNameLength)),
+		appvalidation.NewField("description", appvalidation.Length(0, maxDescriptionLength)),
+	),
+
+	// Admin revisions
+	appvalidation.NewMessage(
+		&pbshop.OpenAdminRevisionToBusinessRequest{},
+		appvalidation.NewField("business_id", appvalidation.Required()),
+		appvalidation.NewField("title", appvalidation.Required(), appvalidation.Length(1, maxNameLength)),
+		appvalidation.NewField("description", appvalidation.Length(0, maxDescriptionLength)),
+	),
+	appvalidation.NewMessage(
+		&pbshop.OpenAdminRevisionToBranchRequest{},
+		appvalidation.NewField("branch_id", appvalidation.Required()),
+		appvalidation.NewField("admin_revision", appvalidation.Required()),
+		appvalidation.NewField(
+			"admin_revision.title",
+			appvalidation.Required(),
+			appvalidation.Length(1, maxNameLength),
+		),
+	),
+	appvalidation.NewMessage(
+		&pbshop.OpenAdminRevisionToProductRequest{},
+		appvalidation.NewField("product_id", appvalidation.Required()),
+		appvalidation.NewField("admin_revision", appvalidation.Required()),
+		appvalidation.NewField(
+			"admin_revision.title",
+			appvalidation.Required(),
+			appvalidation.Length(1, maxNameLength),
+		),
+	),
+	appvalidation.NewMessage(
+		&pbshop.OpenAdminRevisionToBusinessProductRequest{},
+		appvalidation.NewField("business_id", appvalidation.Required()),
+		appvalidation.NewField("product_id", appvalidation.Required()),
+		appvalidation.NewField("admin_revision", appvalidation.Required()),
+		appvalidation.NewField(
+			"admin_revision.title",
+			appvalidation.Required(),
+			appvalidation.Length(1, maxNameLength),
+		),
+	),
+	appvalidation.NewMessage(
+		&pbshop.UpdateAdminRevisionRequest{},
+		appvalidation.NewField("admin_revision_id", appvalidation.Required()),
+		appvalidation.NewField("title", appvalidation.Length(1, maxNameLength)),
+		appvalidation.NewField("description", appvalidation.Length(0, maxDescriptionLength)),
+	),
+	appvalidation.NewMessage(
+		&pbshop.CloseAdminRevisionRequest{},
+		appvalidation.NewField("admin_revision_id", appvalidation.Required()),
+	),
+}
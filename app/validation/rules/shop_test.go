@@ -0,0 +1,501 @@
+package rules
+
+import (
+	pbshop "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/shop"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"testing"
+)
+
+// TestShopBusinessMessages checks the rules of the request messages of the businesses of the shop service
+func TestShopBusinessMessages(t *testing.T) {
+	testValidation(
+		t, []validationCase{
+			{
+				name:    "AddBusinessRequest valid",
+				message: &pbshop.AddBusinessRequest{Name: "Pixel Plaza", Description: "A shopping mall"},
+			},
+			{
+				name:       "AddBusinessRequest missing",
+				message:    &pbshop.AddBusinessRequest{},
+				violations: []string{"name"},
+			},
+			{
+				name:       "AddBusinessRequest malformed",
+				message:    &pbshop.AddBusinessRequest{Name: longName, Description: longDescription},
+				violations: []string{"name", "description"},
+			},
+			{
+				name:    "UpdateBusinessRequest valid",
+				message: &pbshop.UpdateBusinessRequest{Name: proto.String("Pixel Plaza")},
+			},
+			{
+				name:    "UpdateBusinessRequest missing",
+				message: &pbshop.UpdateBusinessRequest{},
+			},
+			{
+				name:       "UpdateBusinessRequest malformed",
+				message:    &pbshop.UpdateBusinessRequest{Description: proto.String(longDescription)},
+				violations: []string{"description"},
+			},
+			{
+				name:    "AddBusinessOwnerRequest valid",
+				message: &pbshop.AddBusinessOwnerRequest{BusinessId: "business-id", UserId: "user-id"},
+			},
+			{
+				name:       "AddBusinessOwnerRequest missing",
+				message:    &pbshop.AddBusinessOwnerRequest{},
+				violations: []string{"user_id"},
+			},
+			{
+				name:    "RemoveBusinessOwnerRequest valid",
+				message: &pbshop.RemoveBusinessOwnerRequest{BusinessId: "business-id", UserId: "user-id"},
+			},
+			{
+				name:       "RemoveBusinessOwnerRequest missing",
+				message:    &pbshop.RemoveBusinessOwnerRequest{},
+				violations: []string{"user_id"},
+			},
+			{
+				name:    "AddBusinessClientRequest valid",
+				message: &pbshop.AddBusinessClientRequest{BusinessId: "business-id", UserId: "user-id"},
+			},
+			{
+				name:       "AddBusinessClientRequest missing",
+				message:    &pbshop.AddBusinessClientRequest{BusinessId: "business-id"},
+				violations: []string{"user_id"},
+			},
+			{
+				name: "AddBusinessMarketCategoryRequest valid",
+				message: &pbshop.AddBusinessMarketCategoryRequest{
+					BusinessId:       "business-id",
+					MarketCategoryId: "market-category-id",
+				},
+			},
+			{
+				name:       "AddBusinessMarketCategoryRequest missing",
+				message:    &pbshop.AddBusinessMarketCategoryRequest{},
+				violations: []string{"business_id", "market_category_id"},
+			},
+			{
+				name:    "SetBusinessProfilePictureRequest valid",
+				message: &pbshop.SetBusinessProfilePictureRequest{BusinessId: "business-id", ImageId: "image-id"},
+			},
+			{
+				name:       "SetBusinessProfilePictureRequest missing",
+				message:    &pbshop.SetBusinessProfilePictureRequest{},
+				violations: []string{"image_id"},
+			},
+		},
+	)
+}
+
+// TestShopBranchMessages checks the rules of the request messages of the branches and stores of the shop service
+func TestShopBranchMessages(t *testing.T) {
+	now := timestamppb.Now()
+
+	testValidation(
+		t, []validationCase{
+			{
+				name:    "AddBranchRequest valid",
+				message: &pbshop.AddBranchRequest{StoreId: "store-id", Name: "Main branch"},
+			},
+			{
+				name:       "AddBranchRequest missing",
+				message:    &pbshop.AddBranchRequest{},
+				violations: []string{"store_id", "name"},
+			},
+			{
+				name: "AddBranchRequest malformed",
+				message: &pbshop.AddBranchRequest{
+					StoreId:     "store-id",
+					Name:        "Main branch",
+					Description: longDescription,
+				},
+				violations: []string{"description"},
+			},
+			{
+				name:    "UpdateBranchRequest valid",
+				message: &pbshop.UpdateBranchRequest{BranchId: "branch-id", Name: proto.String("Main branch")},
+			},
+			{
+				name:       "UpdateBranchRequest malformed",
+				message:    &pbshop.UpdateBranchRequest{BranchId: "branch-id", Name: proto.String(longName)},
+				violations: []string{"name"},
+			},
+			{
+				name:    "AddStoreRequest valid",
+				message: &pbshop.AddStoreRequest{Store: &pbshop.Store{StoreNumber: "A-12", SquareMeters: 120}},
+			},
+			{
+				name:       "AddStoreRequest missing",
+				message:    &pbshop.AddStoreRequest{},
+				violations: []string{"store"},
+			},
+			{
+				name:       "AddStoreRequest missing nested",
+				message:    &pbshop.AddStoreRequest{Store: &pbshop.Store{SquareMeters: 120}},
+				violations: []string{"store.store_number"},
+			},
+			{
+				name:       "AddStoreRequest malformed",
+				message:    &pbshop.AddStoreRequest{Store: &pbshop.Store{StoreNumber: "A-12"}},
+				violations: []string{"store.square_meters"},
+			},
+			{
+				name: "AddBranchRentRequest valid",
+				message: &pbshop.AddBranchRentRequest{
+					BranchId:   "branch-id",
+					BranchRent: &pbshop.AddBranchRent{StoreId: "store-id", Price: 500, From: now, To: now},
+				},
+			},
+			{
+				name:       "AddBranchRentRequest missing",
+				message:    &pbshop.AddBranchRentRequest{},
+				violations: []string{"branch_id", "branch_rent"},
+			},
+			{
+				name: "AddBranchRentRequest missing nested",
+				message: &pbshop.AddBranchRentRequest{
+					BranchId:   "branch-id",
+					BranchRent: &pbshop.AddBranchRent{},
+				},
+				violations: []string{"branch_rent.store_id", "branch_rent.from", "branch_rent.to"},
+			},
+			{
+				name: "AddBranchRentRequest malformed",
+				message: &pbshop.AddBranchRentRequest{
+					BranchId:   "branch-id",
+					BranchRent: &pbshop.AddBranchRent{StoreId: "store-id", Price: -1, From: now, To: now},
+				},
+				violations: []string{"branch_rent.price"},
+			},
+			{
+				name:    "UpdateBranchRentRequest valid",
+				message: &pbshop.UpdateBranchRentRequest{BranchRentId: "branch-rent-id"},
+			},
+			{
+				name:       "UpdateBranchRentRequest missing",
+				message:    &pbshop.UpdateBranchRentRequest{},
+				violations: []string{"branch_rent_id"},
+			},
+		},
+	)
+}
+
+// TestShopProductMessages checks the rules of the request messages of the products of the shop service
+func TestShopProductMessages(t *testing.T) {
+	testValidation(
+		t, []validationCase{
+			{
+				name: "AddProductRequest valid",
+				message: &pbshop.AddProductRequest{
+					Product: &pbshop.Product{Name: "Keyboard", Price: 49.99, Weight: proto.Float32(0.8)},
+				},
+			},
+			{
+				name:       "AddProductRequest missing",
+				message:    &pbshop.AddProductRequest{},
+				violations: []string{"product"},
+			},
+			{
+				name:       "AddProductRequest missing nested",
+				message:    &pbshop.AddProductRequest{Product: &pbshop.Product{Price: 49.99}},
+				violations: []string{"product.name"},
+			},
+			{
+				name: "AddProductRequest malformed",
+				message: &pbshop.AddProductRequest{
+					Product: &pbshop.Product{
+						Name:        "Keyboard",
+						Description: longDescription,
+						Price:       -1,
+						Height:      proto.Float32(-1),
+						Length:      proto.Float32(-1),
+						Weight:      proto.Float32(-1),
+						Width:       proto.Float32(-1),
+					},
+				},
+				violations: []string{
+					"product.description",
+					"product.price",
+					"product.height",
+					"product.length",
+					"product.weight",
+					"product.width",
+				},
+			},
+			{
+				name: "UpdateProductRequest valid",
+				message: &pbshop.UpdateProductRequest{
+					ProductId: "product-id",
+					Product:   &pbshop.UpdateProduct{Price: proto.Float32(39.99)},
+				},
+			},
+			{
+				name:       "UpdateProductRequest missing",
+				message:    &pbshop.UpdateProductRequest{},
+				violations: []string{"product"},
+			},
+			{
+				name: "UpdateProductRequest malformed",
+				message: &pbshop.UpdateProductRequest{
+					ProductId: "product-id",
+					Product:   &pbshop.UpdateProduct{Name: proto.String(longName), Price: proto.Float32(-1)},
+				},
+				violations: []string{"product.name", "product.price"},
+			},
+			{
+				name:    "AddBusinessProductRequest valid",
+				message: &pbshop.AddBusinessProductRequest{BusinessId: "business-id", ProductId: "product-id"},
+			},
+			{
+				name:       "AddBusinessProductRequest missing",
+				message:    &pbshop.AddBusinessProductRequest{},
+				violations: []string{"business_id", "product_id"},
+			},
+			{
+				name:    "UpdateBusinessProductRequest valid",
+				message: &pbshop.UpdateBusinessProductRequest{BusinessId: "business-id", ProductId: "product-id"},
+			},
+			{
+				name:       "UpdateBusinessProductRequest missing",
+				message:    &pbshop.UpdateBusinessProductRequest{ProductId: "product-id"},
+				violations: []string{"business_id"},
+			},
+			{
+				name: "AddBranchProductRequest valid",
+				message: &pbshop.AddBranchProductRequest{
+					BranchId:           "branch-id",
+					ProductId:          "product-id",
+					Price:              49.99,
+					DiscountPercentage: 15,
+					Stock:              10,
+				},
+			},
+			{
+				name:       "AddBranchProductRequest missing",
+				message:    &pbshop.AddBranchProductRequest{},
+				violations: []string{"product_id"},
+			},
+			{
+				name: "AddBranchProductRequest malformed",
+				message: &pbshop.AddBranchProductRequest{
+					BranchId:           "branch-id",
+					ProductId:          "product-id",
+					Price:              -1,
+					DiscountPercentage: 101,
+					Stock:              -1,
+				},
+				violations: []string{"price", "discount_percentage", "stock"},
+			},
+			{
+				name: "UpdateBranchProductRequest valid",
+				message: &pbshop.UpdateBranchProductRequest{
+					BranchId:  "branch-id",
+					ProductId: "product-id",
+					Stock:     proto.Int32(5),
+				},
+			},
+			{
+				name:       "UpdateBranchProductRequest missing",
+				message:    &pbshop.UpdateBranchProductRequest{},
+				violations: []string{"product_id"},
+			},
+			{
+				name: "UpdateBranchProductRequest malformed",
+				message: &pbshop.UpdateBranchProductRequest{
+					BranchId:           "branch-id",
+					ProductId:          "product-id",
+					DiscountPercentage: proto.Float32(-5),
+				},
+				violations: []string{"discount_percentage"},
+			},
+		},
+	)
+}
+
+// TestShopCategoryMessages checks the rules of the request messages of the categories of the shop service
+func TestShopCategoryMessages(t *testing.T) {
+	testValidation(
+		t, []validationCase{
+			{
+				name:    "AddProductCategoryRequest valid",
+				message: &pbshop.AddProductCategoryRequest{Name: "Electronics"},
+			},
+			{
+				name:       "AddProductCategoryRequest missing",
+				message:    &pbshop.AddProductCategoryRequest{Name: "  "},
+				violations: []string{"name"},
+			},
+			{
+				name:       "AddProductCategoryRequest malformed",
+				message:    &pbshop.AddProductCategoryRequest{Name: longName},
+				violations: []string{"name"},
+			},
+			{
+				name: "UpdateProductCategoryRequest valid",
+				message: &pbshop.UpdateProductCategoryRequest{
+					ProductCategoryId: "product-category-id",
+					Description:       proto.String("Devices and gadgets"),
+				},
+			},
+			{
+				name:       "UpdateProductCategoryRequest missing",
+				message:    &pbshop.UpdateProductCategoryRequest{},
+				violations: []string{"product_category_id"},
+			},
+			{
+				name: "UpdateProductCategoryRequest malformed",
+				message: &pbshop.UpdateProductCategoryRequest{
+					ProductCategoryId: "product-category-id",
+					Name:              proto.String(longName),
+				},
+				violations: []string{"name"},
+			},
+			{
+				name: "AddMarketCategoryRequest valid",
+				message: &pbshop.AddMarketCategoryRequest{
+					MarketCategory: &pbshop.MarketCategory{Name: "Food court"},
+				},
+			},
+			{
+				name:       "AddMarketCategoryRequest missing",
+				message:    &pbshop.AddMarketCategoryRequest{},
+				violations: []string{"market_category"},
+			},
+			{
+				name:       "AddMarketCategoryRequest missing nested",
+				message:    &pbshop.AddMarketCategoryRequest{MarketCategory: &pbshop.MarketCategory{}},
+				violations: []string{"market_category.name"},
+			},
+			{
+				name: "AddMarketCategoryRequest malformed",
+				message: &pbshop.AddMarketCategoryRequest{
+					MarketCategory: &pbshop.MarketCategory{Name: "Food court", Description: longDescription},
+				},
+				violations: []string{"market_category.description"},
+			},
+			{
+				name:    "UpdateMarketCategoryRequest valid",
+				message: &pbshop.UpdateMarketCategoryRequest{MarketCategoryId: "market-category-id"},
+			},
+			{
+				name: "UpdateMarketCategoryRequest malformed",
+				message: &pbshop.UpdateMarketCategoryRequest{
+					MarketCategoryId: "market-category-id",
+					Description:      proto.String(longDescription),
+				},
+				violations: []string{"description"},
+			},
+		},
+	)
+}
+
+// TestShopAdminRevisionMessages checks the rules of the request messages of the admin revisions of the shop service
+func TestShopAdminRevisionMessages(t *testing.T) {
+	revision := &pbshop.OpenAdminRevision{Title: "Missing permits"}
+
+	testValidation(
+		t, []validationCase{
+			{
+				name: "OpenAdminRevisionToBusinessRequest valid",
+				message: &pbshop.OpenAdminRevisionToBusinessRequest{
+					BusinessId: "business-id",
+					Title:      proto.String("Missing permits"),
+				},
+			},
+			{
+				name:       "OpenAdminRevisionToBusinessRequest missing",
+				message:    &pbshop.OpenAdminRevisionToBusinessRequest{},
+				violations: []string{"business_id", "title"},
+			},
+			{
+				name: "OpenAdminRevisionToBusinessRequest malformed",
+				message: &pbshop.OpenAdminRevisionToBusinessRequest{
+					BusinessId:  "business-id",
+					Title:       proto.String(longName),
+					Description: proto.String(longDescription),
+				},
+				violations: []string{"title", "description"},
+			},
+			{
+				name: "OpenAdminRevisionToBranchRequest valid",
+				message: &pbshop.OpenAdminRevisionToBranchRequest{
+					BranchId:      "branch-id",
+					AdminRevision: revision,
+				},
+			},
+			{
+				name:       "OpenAdminRevisionToBranchRequest missing",
+				message:    &pbshop.OpenAdminRevisionToBranchRequest{},
+				violations: []string{"branch_id", "admin_revision"},
+			},
+			{
+				name: "OpenAdminRevisionToBranchRequest malformed",
+				message: &pbshop.OpenAdminRevisionToBranchRequest{
+					BranchId:      "branch-id",
+					AdminRevision: &pbshop.OpenAdminRevision{Title: longName},
+				},
+				violations: []string{"admin_revision.title"},
+			},
+			{
+				name: "OpenAdminRevisionToProductRequest valid",
+				message: &pbshop.OpenAdminRevisionToProductRequest{
+					ProductId:     "product-id",
+					AdminRevision: revision,
+				},
+			},
+			{
+				name: "OpenAdminRevisionToProductRequest missing",
+				message: &pbshop.OpenAdminRevisionToProductRequest{
+					ProductId:     "product-id",
+					AdminRevision: &pbshop.OpenAdminRevision{},
+				},
+				violations: []string{"admin_revision.title"},
+			},
+			{
+				name: "OpenAdminRevisionToBusinessProductRequest valid",
+				message: &pbshop.OpenAdminRevisionToBusinessProductRequest{
+					BusinessId:    "business-id",
+					ProductId:     "product-id",
+					AdminRevision: revision,
+				},
+			},
+			{
+				name:       "OpenAdminRevisionToBusinessProductRequest missing",
+				message:    &pbshop.OpenAdminRevisionToBusinessProductRequest{},
+				violations: []string{"business_id", "product_id", "admin_revision"},
+			},
+			{
+				name: "UpdateAdminRevisionRequest valid",
+				message: &pbshop.UpdateAdminRevisionRequest{
+					AdminRevisionId: "admin-revision-id",
+					IsSuspended:     proto.Bool(true),
+				},
+			},
+			{
+				name:       "UpdateAdminRevisionRequest missing",
+				message:    &pbshop.UpdateAdminRevisionRequest{},
+				violations: []string{"admin_revision_id"},
+			},
+			{
+				name: "UpdateAdminRevisionRequest malformed",
+				message: &pbshop.UpdateAdminRevisionRequest{
+					AdminRevisionId: "admin-revision-id",
+					Title:           proto.String(longName),
+				},
+				violations: []string{"title"},
+			},
+			{
+				name:    "CloseAdminRevisionRequest valid",
+				message: &pbshop.CloseAdminRevisionRequest{AdminRevisionId: "admin-revision-id"},
+			},
+			{
+				name:       "CloseAdminRevisionRequest missing",
+				message:    &pbshop.CloseAdminRevisionRequest{},
+				violations: []string{"admin_revision_id"},
+			},
+		},
+	)
+}
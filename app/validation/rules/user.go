@@ -0,0 +1,77 @@
+package rules
+
+import (
+	appvalidation "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/validation"
+	pbuser "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/user"
+)
+
+// userMessages are the rules of the request messages of the user service
+var userMessages = []appvalidation.Message{
+	appvalidation.NewMessage(
+		&pbuser.SignUpRequest{},
+		appvalidation.NewField("username", appvalidation.Required(), appvalidation.Username()),
+		appvalidation.NewField("email", appvalidation.Required(), appvalidation.Email()),
+		appvalidation.NewField(
+			"password",
+			appvalidation.Required(),
+			appvalidation.Length(minPasswordLength, maxPasswordLength),
+		),
+		appvalidation.NewField("first_name", appvalidation.Required(), appvalidation.Length(1, maxNameLength)),
+		appvalidation.NewField("last_name", appvalidation.Required(), appvalidation.Length(1, maxNameLength)),
+		appvalidation.NewField("phone_number", appvalidation.PhoneNumber()),
+	),
+	appvalidation.NewMessage(
+		&pbuser.UpdateUserRequest{},
+		appvalidation.NewField("first_name", appvalidation.Length(1, maxNameLength)),
+		appvalidation.NewField("last_name", appvalidation.Length(1, maxNameLength)),
+	),
+	appvalidation.NewMessage(
+		&pbuser.ChangeUsernameRequest{},
+		appvalidation.NewField("username", appvalidation.Required(), appvalidation.Username()),
+	),
+	appvalidation.NewMessage(
+		&pbuser.ChangePasswordRequest{},
+		appvalidation.NewField("old_password", appvalidation.Required()),
+		appvalidation.NewField(
+			"new_password",
+			appvalidation.Required(),
+			appvalidation.Length(minPasswordLength, maxPasswordLength),
+		),
+	),
+	appvalidation.NewMessage(
+		&pbuser.ForgotPasswordRequest{},
+		appvalidation.NewField("username", appvalidation.Required()),
+	),
+	appvalidation.NewMessage(
+		&pbuser.ResetPasswordRequest{},
+		appvalidation.NewField(
+			"new_password",
+			appvalidation.Required(),
+			appvalidation.Length(minPasswordLength, maxPasswordLength),
+		),
+	),
+	appvalidation.NewMessage(
+		&pbuser.DeleteUserRequest{},
+		appvalidation.NewField("password", appvalidation.Required()),
+	),
+	appvalidation.NewMessage(
+		&pbuser.AddEmailRequest{},
+		appvalidation.NewField("email", appvalidation.Required(), appvalidation.Email()),
+	),
+	appvalidation.NewMessage(
+		&pbuser.ChangePrimaryEmailRequest{},
+		appvalidation.NewField("email", appvalidation.Required(), appvalidation.Email()),
+	),
+	appvalidation.NewMessage(
+		&pbuser.SendVerificationEmailRequest{},
+		appvalidation.NewField("email", appvalidation.Required(), appvalidation.Email()),
+	),
+	appvalidation.NewMessage(
+		&pbuser.ChangePhoneNumberRequest{},
+		appvalidation.NewField("phone_number", appvalidation.Required(), appvalidation.PhoneNumber()),
+	),
+	appvalidation.NewMessage(
+		&pbuser.SendVerificationSMSRequest{},
+		appvalidation.NewField("phone_number", appvalidation.Required(), appvalidation.PhoneNumber()),
+	),
+}
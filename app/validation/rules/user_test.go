@@ -0,0 +1,186 @@
+package rules
+
+import (
+	pbuser "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/user"
+	"google.golang.org/protobuf/proto"
+	"testing"
+)
+
+// TestUserMessages checks the rules of the request messages of the user service
+func TestUserMessages(t *testing.T) {
+	testValidation(
+		t, []validationCase{
+			{
+				name: "SignUpRequest valid",
+				message: &pbuser.SignUpRequest{
+					Username:    "john.doe",
+					Email:       "john.doe@example.com",
+					Password:    "secret-password",
+					FirstName:   "John",
+					LastName:    "Doe",
+					PhoneNumber: "+584141234567",
+				},
+			},
+			{
+				name:       "SignUpRequest missing",
+				message:    &pbuser.SignUpRequest{},
+				violations: []string{"username", "email", "password", "first_name", "last_name"},
+			},
+			{
+				name: "SignUpRequest malformed",
+				message: &pbuser.SignUpRequest{
+					Username:    "jd",
+					Email:       "John Doe <john.doe@example.com>",
+					Password:    "short",
+					FirstName:   longName,
+					LastName:    "Doe",
+					PhoneNumber: "0414-1234567",
+				},
+				violations: []string{"username", "email", "password", "first_name", "phone_number"},
+			},
+			{
+				name:    "UpdateUserRequest valid",
+				message: &pbuser.UpdateUserRequest{FirstName: proto.String("John")},
+			},
+			{
+				name:    "UpdateUserRequest missing",
+				message: &pbuser.UpdateUserRequest{},
+			},
+			{
+				name:       "UpdateUserRequest malformed",
+				message:    &pbuser.UpdateUserRequest{LastName: proto.String(longName)},
+				violations: []string{"last_name"},
+			},
+			{
+				name:    "ChangeUsernameRequest valid",
+				message: &pbuser.ChangeUsernameRequest{Username: "john_doe"},
+			},
+			{
+				name:       "ChangeUsernameRequest missing",
+				message:    &pbuser.ChangeUsernameRequest{},
+				violations: []string{"username"},
+			},
+			{
+				name:       "ChangeUsernameRequest malformed",
+				message:    &pbuser.ChangeUsernameRequest{Username: "john doe"},
+				violations: []string{"username"},
+			},
+			{
+				name:    "ChangePasswordRequest valid",
+				message: &pbuser.ChangePasswordRequest{OldPassword: "old-password", NewPassword: "new-password"},
+			},
+			{
+				name:       "ChangePasswordRequest missing",
+				message:    &pbuser.ChangePasswordRequest{},
+				violations: []string{"old_password", "new_password"},
+			},
+			{
+				name:       "ChangePasswordRequest malformed",
+				message:    &pbuser.ChangePasswordRequest{OldPassword: "old-password", NewPassword: "new"},
+				violations: []string{"new_password"},
+			},
+			{
+				name:    "ForgotPasswordRequest valid",
+				message: &pbuser.ForgotPasswordRequest{Username: "john.doe"},
+			},
+			{
+				name:       "ForgotPasswordRequest missing",
+				message:    &pbuser.ForgotPasswordRequest{},
+				violations: []string{"username"},
+			},
+			{
+				name:    "ResetPasswordRequest valid",
+				message: &pbuser.ResetPasswordRequest{NewPassword: "new-password"},
+			},
+			{
+				name:       "ResetPasswordRequest missing",
+				message:    &pbuser.ResetPasswordRequest{},
+				violations: []string{"new_password"},
+			},
+			{
+				name:       "ResetPasswordRequest malformed",
+				message:    &pbuser.ResetPasswordRequest{NewPassword: "new"},
+				violations: []string{"new_password"},
+			},
+			{
+				name:    "DeleteUserRequest valid",
+				message: &pbuser.DeleteUserRequest{Password: "secret-password"},
+			},
+			{
+				name:       "DeleteUserRequest missing",
+				message:    &pbuser.DeleteUserRequest{},
+				violations: []string{"password"},
+			},
+			{
+				name:    "AddEmailRequest valid",
+				message: &pbuser.AddEmailRequest{Email: "john.doe@example.com"},
+			},
+			{
+				name:       "AddEmailRequest missing",
+				message:    &pbuser.AddEmailRequest{},
+				violations: []string{"email"},
+			},
+			{
+				name:       "AddEmailRequest malformed",
+				message:    &pbuser.AddEmailRequest{Email: "john.doe"},
+				violations: []string{"email"},
+			},
+			{
+				name:    "ChangePrimaryEmailRequest valid",
+				message: &pbuser.ChangePrimaryEmailRequest{Email: "john.doe@example.com"},
+			},
+			{
+				name:       "ChangePrimaryEmailRequest missing",
+				message:    &pbuser.ChangePrimaryEmailRequest{},
+				violations: []string{"email"},
+			},
+			{
+				name:       "ChangePrimaryEmailRequest malformed",
+				message:    &pbuser.ChangePrimaryEmailRequest{Email: "@example.com"},
+				violations: []string{"email"},
+			},
+			{
+				name:    "SendVerificationEmailRequest valid",
+				message: &pbuser.SendVerificationEmailRequest{Email: "john.doe@example.com"},
+			},
+			{
+				name:       "SendVerificationEmailRequest missing",
+				message:    &pbuser.SendVerificationEmailRequest{},
+				violations: []string{"email"},
+			},
+			{
+				name:       "SendVerificationEmailRequest malformed",
+				message:    &pbuser.SendVerificationEmailRequest{Email: "john.doe@"},
+				violations: []string{"email"},
+			},
+			{
+				name:    "ChangePhoneNumberRequest valid",
+				message: &pbuser.ChangePhoneNumberRequest{PhoneNumber: "584141234567"},
+			},
+			{
+				name:       "ChangePhoneNumberRequest missing",
+				message:    &pbuser.ChangePhoneNumberRequest{},
+				violations: []string{"phone_number"},
+			},
+			{
+				name:       "ChangePhoneNumberRequest malformed",
+				message:    &pbuser.ChangePhoneNumberRequest{PhoneNumber: "+0123"},
+				violations: []string{"phone_number"},
+			},
+			{
+				name:    "SendVerificationSMSRequest valid",
+				message: &pbuser.SendVerificationSMSRequest{PhoneNumber: "+584141234567"},
+			},
+			{
+				name:       "SendVerificationSMSRequest missing",
+				message:    &pbuser.SendVerificationSMSRequest{},
+				violations: []string{"phone_number"},
+			},
+			{
+				name:       "SendVerificationSMSRequest malformed",
+				message:    &pbuser.SendVerificationSMSRequest{PhoneNumber: "phone"},
+				violations: []string{"phone_number"},
+			},
+		},
+	)
+}
@@ -0,0 +1,170 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"strings"
+)
+
+type (
+	// Message declares the rules of the fields of a request message
+	Message struct {
+		message proto.Message
+		fields  []Field
+	}
+
+	// Field declares the rules of a field, named by the path of its proto names separated by dots, like
+	// product.price. The rules of the fields of a nested message are skipped if the message is not set
+	Field struct {
+		path  string
+		rules []Rule
+	}
+
+	// Validator validates the request messages with the declared rules before they are sent to the backend services
+	Validator struct {
+		messages map[protoreflect.FullName][]field
+	}
+
+	// field is a field of a request message with its rules compiled
+	field struct {
+		path        string
+		descriptors []protoreflect.FieldDescriptor
+		checks      []check
+	}
+)
+
+// NewMessage declares the rules of the fields of the given request message
+func NewMessage(message proto.Message, fields ...Field) Message {
+	return Message{message: message, fields: fields}
+}
+
+// NewField declares the rules of the field with the given path
+func NewField(path string, rules ...Rule) Field {
+	return Field{path: path, rules: rules}
+}
+
+// NewValidator creates a new validator with the rules of the given messages, checking every field exists and
+// supports its rules. Every invalid rule is reported at once
+func NewValidator(messages ...Message) (*Validator, error) {
+	var errs []error
+	compiled := make(map[protoreflect.FullName][]field, len(messages))
+	for _, message := range messages {
+		if message.message == nil {
+			errs = append(errs, NilMessageError)
+			continue
+		}
+		descriptor := message.message.ProtoReflect().Descriptor()
+		name := descriptor.FullName()
+		if _, ok := compiled[name]; ok {
+			errs = append(errs, fmt.Errorf("%w: %s", DuplicateMessageError, name))
+			continue
+		}
+
+		fields := make([]field, 0, len(message.fields))
+		for _, declared := range message.fields {
+			f, err := compile(descriptor, declared)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+				continue
+			}
+			fields = append(fields, f)
+		}
+		compiled[name] = fields
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return &Validator{messages: compiled}, nil
+}
+
+// compile resolves the path of a field in the given message and compiles its rules
+func compile(descriptor protoreflect.MessageDescriptor, declared Field) (field, error) {
+	names := strings.Split(declared.path, ".")
+	descriptors := make([]protoreflect.FieldDescriptor, 0, len(names))
+	for index, name := range names {
+		if descriptor == nil {
+			return field{}, fmt.Errorf("%w %q", UnknownFieldError, declared.path)
+		}
+		fd := descriptor.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return field{}, fmt.Errorf("%w %q", UnknownFieldError, declared.path)
+		}
+		descriptors = append(descriptors, fd)
+
+		// Only the singular messages can have nested fields
+		descriptor = nil
+		if index < len(names)-1 && fd.Kind() == protoreflect.MessageKind && !fd.IsList() && !fd.IsMap() {
+			descriptor = fd.Message()
+		}
+	}
+
+	last := descriptors[len(descriptors)-1]
+	checks := make([]check, 0, len(declared.rules))
+	for _, rule := range declared.rules {
+		c, ok := rule.compile(last)
+		if !ok {
+			return field{}, fmt.Errorf("%w: %s of %q", UnsupportedRuleError, rule.name, declared.path)
+		}
+		checks = append(checks, c)
+	}
+	return field{path: declared.path, descriptors: descriptors, checks: checks}, nil
+}
+
+// Validate checks the given request message with its rules, returning an *Error with every violation if any. The
+// messages without rules are valid
+func (v *Validator) Validate(message proto.Message) error {
+	reflected := message.ProtoReflect()
+	fields, ok := v.messages[reflected.Descriptor().FullName()]
+	if !ok {
+		return nil
+	}
+
+	var violations []Violation
+	for _, f := range fields {
+		// Find the message the field belongs to, skipping the field if a parent message is not set
+		parent := reflected
+		for _, descriptor := range f.descriptors[:len(f.descriptors)-1] {
+			if !parent.Has(descriptor) {
+				parent = nil
+				break
+			}
+			parent = parent.Get(descriptor).Message()
+		}
+		if parent == nil {
+			continue
+		}
+
+		last := f.descriptors[len(f.descriptors)-1]
+		for _, c := range f.checks {
+			violations = append(violations, c(parent, last, f.path)...)
+		}
+	}
+
+	if len(violations) > 0 {
+		return &Error{Violations: violations}
+	}
+	return nil
+}
+
+// Middleware stores the validator in the Gin context, so the request messages are validated while the context of
+// their backend call is prepared
+func (v *Validator) Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Set(ginCtxKey, v)
+		ctx.Next()
+	}
+}
+
+// FromGinContext returns the validator stored in the Gin context, or nil if there is none
+func FromGinContext(ctx *gin.Context) *Validator {
+	if value, ok := ctx.Get(ginCtxKey); ok {
+		if validator, ok := value.(*Validator); ok {
+			return validator
+		}
+	}
+	return nil
+}
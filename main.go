@@ -3,28 +3,47 @@ package main
 import (
 	"context"
 	"flag"
-	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	goredis "github.com/go-redis/redis/v8"
 	"github.com/joho/godotenv"
-	"github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app"
+	appaccesslog "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/accesslog"
+	appbody "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/body"
+	appbreaker "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/breaker"
+	appbruteforce "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/bruteforce"
+	appcache "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/cache"
+	appcodec "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/codec"
+	appcompression "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/compression"
+	appconfig "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/config"
+	appcors "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/cors"
+	appcredentials "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/credentials"
 	appgrpc "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc"
+	appgrpcclient "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/grpc/client"
+	apphealth "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/health"
+	appidempotency "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/idempotency"
 	appjwt "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/jwt"
 	applistener "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/listener"
 	applogger "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/logger"
+	appmetrics "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/metrics"
 	appapi "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/module/api"
+	appparams "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/params"
+	appproblem "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/problem"
+	appratelimit "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/ratelimit"
+	appredis "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/redis"
+	apprequestid "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/requestid"
+	appresponse "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/response"
+	appretry "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/retry"
+	apptimeout "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/timeout"
+	apptracing "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/tracing"
+	appvalidation "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/validation"
+	appvalidationrules "github.com/pixel-plaza-dev/uru-databases-2-api-gateway/app/validation/rules"
 	"github.com/pixel-plaza-dev/uru-databases-2-api-gateway/docs"
 	commonginmiddlewareauth "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/middleware/auth"
 	commonheader "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/gin/middleware/security/header"
-	commonclientresponse "github.com/pixel-plaza-dev/uru-databases-2-go-api-common/http/grpc/client/response"
-	commongcloud "github.com/pixel-plaza-dev/uru-databases-2-go-service-common/cloud/gcloud"
 	commonenv "github.com/pixel-plaza-dev/uru-databases-2-go-service-common/config/env"
 	commonflag "github.com/pixel-plaza-dev/uru-databases-2-go-service-common/config/flag"
 	commonjwtvalidator "github.com/pixel-plaza-dev/uru-databases-2-go-service-common/crypto/jwt/validator"
 	commonjwtvalidatorgrpc "github.com/pixel-plaza-dev/uru-databases-2-go-service-common/crypto/jwt/validator/grpc"
 	clientauthinterceptor "github.com/pixel-plaza-dev/uru-databases-2-go-service-common/http/grpc/client/interceptor/auth"
-	commongrpcoutgoingctx "github.com/pixel-plaza-dev/uru-databases-2-go-service-common/http/grpc/client/interceptor/outgoing-ctx"
-	commonlistener "github.com/pixel-plaza-dev/uru-databases-2-go-service-common/http/listener"
-	commontls "github.com/pixel-plaza-dev/uru-databases-2-go-service-common/http/tls"
 	pbauth "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/auth"
 	pborder "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/order"
 	pbpayment "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/compiled/pixel_plaza/payment"
@@ -36,16 +55,18 @@ import (
 	pbconfigshop "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/grpc/shop"
 	pbconfiguser "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/config/grpc/user"
 	pbtypesgrpc "github.com/pixel-plaza-dev/uru-databases-2-protobuf-common/types/grpc"
+	"github.com/prometheus/client_golang/prometheus"
 	swaggerFiles "github.com/swaggo/files"
 	"github.com/swaggo/gin-swagger"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/oauth"
+	"os"
 )
 
 func init() {
 	// Declare flags and parse them
 	commonflag.SetModeFlag()
+	appconfig.SetFlags()
 	flag.Parse()
 	applogger.FlagLogger.ModeFlagSet(commonflag.Mode)
 
@@ -63,6 +84,9 @@ func init() {
 // @Title           Pixel Plaza REST API
 // @Version         1.0
 // @Description     The REST API Gateway to the Auth, User, Business, Payment and Order microservices
+// @Description     The errors are answered as application/problem+json (RFC 7807) with a stable error code
+// @Description     The request bodies must be application/json or application/x-protobuf and are bounded in size, answering 415 and 413 otherwise
+// @Description     The responses are application/json, or application/x-protobuf for the clients that prefer it in their Accept header
 
 // @License.name  GPL-3.0
 // @License.url   http://www.gnu.org/licenses/gpl-3.0.html
@@ -74,22 +98,26 @@ func init() {
 // @name Authorization
 // @description Type "Bearer" followed by a space and JWT token.
 func main() {
-	// Get the listener port
-	servicePort, err := commonlistener.LoadServicePort(
-		"0.0.0.0", applistener.PortKey,
-	)
+	// Load the configuration
+	config, err := appconfig.Load(commonflag.Mode.IsProd())
 	if err != nil {
+		applogger.ConfigLogger.InvalidConfig(err)
+		os.Exit(1)
+	}
+	if err = applogger.SetLevel(config.Logging.Level); err != nil {
 		panic(err)
 	}
-	applogger.EnvironmentLogger.EnvironmentVariableLoaded(applistener.PortKey)
-
-	// Dynamically set the Swagger host
-	if commonflag.Mode != nil && commonflag.Mode.IsDev() {
-		docs.SwaggerInfo.Host = "localhost:" + servicePort.Port
-	} else {
-		docs.SwaggerInfo.Host = "uru-databases-2-api-gateway-246064477369.us-central1.run.app"
+	applogger.ConfigLogger.ConfigLoaded(config)
+	for _, warning := range config.Warnings(commonflag.Mode.IsProd()) {
+		applogger.ConfigLogger.WeakConfig(warning)
+	}
+	for _, variable := range config.LoadedVariables {
+		applogger.EnvironmentLogger.EnvironmentVariableLoaded(variable)
 	}
 
+	// Set the Swagger host
+	docs.SwaggerInfo.Host = config.Swagger.Host
+
 	// Get the gRPC services URI
 	var uriKeys = []string{
 		appgrpc.AuthServiceUriKey,
@@ -98,35 +126,19 @@ func main() {
 		appgrpc.PaymentServiceUriKey,
 		appgrpc.OrderServiceUriKey,
 	}
-	var uris = make(map[string]string)
-	for _, uriKey := range uriKeys {
-		uri, err := commonenv.LoadVariable(uriKey)
-		if err != nil {
-			panic(err)
-		}
-		applogger.EnvironmentLogger.EnvironmentVariableLoaded(uriKey)
-		uris[uriKey] = uri
-	}
-
-	// Get the JWT public key
-	jwtPublicKey, err := commonenv.LoadVariable(appjwt.PublicKey)
-	if err != nil {
-		panic(err)
-	}
-	applogger.EnvironmentLogger.EnvironmentVariableLoaded(appjwt.PublicKey)
+	uris := config.Services.URIs()
+	credentialsConfig := &config.Credentials
 
-	// Load Google Cloud service account credentials
-	googleCredentials, err := commongcloud.LoadGoogleCredentials(context.Background())
+	// Create the per-RPC credentials provider
+	credentialsProvider, err := appcredentials.NewProvider(context.Background(), credentialsConfig)
 	if err != nil {
 		panic(err)
 	}
 
-	// Get the service account token source for each gRPC server URI
+	// Get the token source for each gRPC server URI, which is nil if the calls are not authenticated
 	var tokenSources = make(map[string]*oauth.TokenSource)
 	for _, uriKey := range uriKeys {
-		tokenSource, err := commongcloud.LoadServiceAccountCredentials(
-			context.Background(), "https://"+uris[uriKey], googleCredentials,
-		)
+		tokenSource, err := credentialsProvider.TokenSource(context.Background(), uris[uriKey])
 		if err != nil {
 			panic(err)
 		}
@@ -135,22 +147,9 @@ func main() {
 	}
 
 	// Load transport credentials
-	var transportCredentials credentials.TransportCredentials
-
-	if commonflag.Mode.IsDev() {
-		// Load server TLS credentials
-		transportCredentials, err = credentials.NewServerTLSFromFile(
-			app.ServerCertPath, app.ServerKeyPath,
-		)
-		if err != nil {
-			panic(err)
-		}
-	} else {
-		// Load system certificates pool
-		transportCredentials, err = commontls.LoadSystemCredentials()
-		if err != nil {
-			panic(err)
-		}
+	transportCredentials, err := appcredentials.LoadTransportCredentials(credentialsConfig)
+	if err != nil {
+		panic(err)
 	}
 
 	// Create gRPC interceptions map
@@ -162,9 +161,13 @@ func main() {
 		appgrpc.PaymentServiceUriKey: &pbconfigpayment.Interceptions,
 	}
 
-	// Create client authentication interceptors
+	// Create client authentication interceptors for the services whose calls are authenticated
 	var clientAuthInterceptors = make(map[string]*clientauthinterceptor.Interceptor)
 	for _, uriKey := range uriKeys {
+		if tokenSources[uriKey] == nil {
+			continue
+		}
+
 		clientAuthInterceptor, err := clientauthinterceptor.NewInterceptor(
 			tokenSources[uriKey],
 			grpcInterceptions[uriKey],
@@ -178,41 +181,129 @@ func main() {
 	// Create common gRPC client interceptors after authentication
 	var commonInterceptorsAfterAuth []grpc.UnaryClientInterceptor
 	if commonflag.Mode.IsDev() {
-		outgoingCtx, err := commongrpcoutgoingctx.NewInterceptor(applogger.OutgoingCtxLogger)
+		// Add the outgoing metadata interceptor, which redacts the bearer tokens
+		commonInterceptorsAfterAuth = append(
+			commonInterceptorsAfterAuth,
+			applogger.AccessLogger.OutgoingMetadataInterceptor(),
+		)
+	}
+
+	// Create the metrics of the HTTP routes and the backend gRPC calls
+	metrics, err := appmetrics.NewMetrics(prometheus.DefaultRegisterer)
+	if err != nil {
+		panic(err)
+	}
+
+	// Create the tracer of the HTTP requests and the backend gRPC calls
+	tracingExporter, err := apptracing.NewExporter(config.Tracing.Exporter, config.Tracing.OTLPEndpoint, os.Stdout)
+	if err != nil {
+		panic(err)
+	}
+	tracer, err := apptracing.NewTracer(
+		tracingExporter,
+		config.Tracing.ServiceName,
+		config.Tracing.SampleRatio,
+		applogger.TracingLogger,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	// Create the circuit breakers of the backend services
+	var breakers *appbreaker.Breakers
+	if config.Breakers.Enabled {
+		var serviceNames []string
+		for _, uriKey := range uriKeys {
+			serviceNames = append(serviceNames, appgrpc.ServiceNames[uriKey])
+		}
+		breakers, err = appbreaker.NewBreakers(
+			serviceNames,
+			config.Breakers.Default.Policy(),
+			config.Breakers.ServicePolicies(),
+			applogger.BreakerLogger,
+		)
 		if err != nil {
 			panic(err)
 		}
+	}
 
-		// Add the outgoing context interceptor
-		commonInterceptorsAfterAuth = append(commonInterceptorsAfterAuth, outgoingCtx.PrintOutgoingCtx())
+	// Create the retrier of the backend calls that failed with a transient error
+	var retrier *appretry.Retrier
+	if config.Retry.Enabled {
+		retrier, err = appretry.NewRetrier(
+			config.Retry.Default.Policy(),
+			config.Retry.MethodPolicies(),
+			config.Retry.IdempotentMethods,
+			applogger.RetryLogger,
+		)
+		if err != nil {
+			panic(err)
+		}
 	}
 
 	// Create gRPC connections
 	var conns = make(map[string]*grpc.ClientConn)
 	for _, uriKey := range uriKeys {
+		// Record the metrics of the whole call, including the authentication
+		interceptors := []grpc.UnaryClientInterceptor{
+			metrics.UnaryClientInterceptor(appgrpc.ServiceNames[uriKey]),
+			appaccesslog.UnaryClientInterceptor(),
+		}
+
+		// Reject the calls right away while the backend service is failing, counting the retried calls once
+		if breakers != nil {
+			interceptors = append(interceptors, breakers.UnaryClientInterceptor(appgrpc.ServiceNames[uriKey]))
+		}
+
+		// Retry the transient failures, authenticating every attempt
+		if retrier != nil {
+			interceptors = append(interceptors, retrier.UnaryClientInterceptor(appgrpc.ServiceNames[uriKey]))
+		}
+
+		// Authenticate the calls, if needed, tracing the time spent fetching the ID token, and add the metadata of the
+		// gateway once they are authenticated
+		var authInterceptor grpc.UnaryClientInterceptor
+		if clientAuthInterceptor, ok := clientAuthInterceptors[uriKey]; ok {
+			authInterceptor = tracer.WrapUnaryClientInterceptor("gcloud.id_token", clientAuthInterceptor.Authenticate())
+		}
+		interceptors = appgrpcclient.UnaryClientInterceptors(interceptors, authInterceptor, commonInterceptorsAfterAuth)
+
 		conn, err := grpc.NewClient(
 			uris[uriKey], grpc.WithTransportCredentials(transportCredentials),
-			grpc.WithChainUnaryInterceptor(
-				append(
-					[]grpc.UnaryClientInterceptor{
-						clientAuthInterceptors[uriKey].Authenticate(),
-					}, commonInterceptorsAfterAuth...,
-				)...,
-			),
+			grpc.WithChainUnaryInterceptor(interceptors...),
+			grpc.WithStatsHandler(tracer.ClientHandler(appgrpc.ServiceNames[uriKey])),
 		)
 		if err != nil {
 			panic(err)
 		}
 		conns[uriKey] = conn
 	}
-	defer func(conns map[string]*grpc.ClientConn) {
-		for _, conn := range conns {
-			err = conn.Close()
-			if err != nil {
-				panic(err)
-			}
-		}
-	}(conns)
+
+	// Create the health checker for the backend services, which probes the connections of the API calls, whose
+	// breakers and metrics skip its health checks
+	var healthServices []apphealth.Service
+	for _, uriKey := range uriKeys {
+		healthServices = append(
+			healthServices, apphealth.Service{
+				Name: appgrpc.ServiceNames[uriKey],
+				Conn: conns[uriKey],
+			},
+		)
+	}
+	healthChecker, err := apphealth.NewChecker(
+		healthServices,
+		config.Health.RefreshInterval.Duration(),
+		config.Health.CheckTimeout.Duration(),
+		applogger.HealthLogger,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	// Start refreshing the health of the backend services in the background
+	if err = healthChecker.Start(); err != nil {
+		panic(err)
+	}
 
 	// Create gRPC server clients
 	userClient := pbuser.NewUserClient(conns[appgrpc.UserServiceUriKey])
@@ -221,9 +312,13 @@ func main() {
 	paymentClient := pbpayment.NewPaymentClient(conns[appgrpc.PaymentServiceUriKey])
 	orderClient := pborder.NewOrderClient(conns[appgrpc.OrderServiceUriKey])
 
-	// Create token validator
+	// Create token validator, which always requires a token source even if the calls are not authenticated
+	authTokenSource := tokenSources[appgrpc.AuthServiceUriKey]
+	if authTokenSource == nil {
+		authTokenSource = appcredentials.NewAnonymousTokenSource()
+	}
 	tokenValidator, err := commonjwtvalidatorgrpc.NewDefaultTokenValidator(
-		tokenSources[appgrpc.AuthServiceUriKey], authClient, nil,
+		authTokenSource, authClient, nil,
 	)
 	if err != nil {
 		panic(err)
@@ -231,7 +326,7 @@ func main() {
 
 	// Create JWT validator with ED25519 public key
 	jwtValidator, err := commonjwtvalidator.NewEd25519Validator(
-		[]byte(jwtPublicKey),
+		[]byte(config.JWT.PublicKey),
 		tokenValidator,
 		commonflag.Mode,
 	)
@@ -244,34 +339,321 @@ func main() {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
-	// Create the response handler
-	responseHandler, err := commonclientresponse.NewDefaultHandler(commonflag.Mode)
+	// Wrap the JWT validator, so the rejected tokens are answered with problem details
+	problemJWTValidator, err := appjwt.NewValidator(jwtValidator)
 	if err != nil {
 		panic(err)
 	}
 
-	// Create the authentication middleware
-	authMiddleware, err := commonginmiddlewareauth.NewMiddleware(
-		jwtValidator,
+	// Create the authentication middleware, whose errors are answered with problem details
+	commonAuthMiddleware, err := commonginmiddlewareauth.NewMiddleware(
+		problemJWTValidator,
 		applogger.AuthMiddlewareLogger,
-		responseHandler,
+		appproblem.NewResponseHandler(),
+	)
+	if err != nil {
+		panic(err)
+	}
+	authMiddleware, err := appjwt.NewAuthentication(commonAuthMiddleware)
+	if err != nil {
+		panic(err)
+	}
+
+	// Create the JWT identifier, which keys the requests by the user ID of their token, parsed like the authentication
+	// middleware does
+	jwtIdentifier, err := appjwt.NewIdentifier(jwtValidator)
+	if err != nil {
+		panic(err)
+	}
+
+	// Create the access log middleware
+	accessLogMiddleware, err := appaccesslog.NewMiddleware(
+		applogger.AccessLogger,
+		jwtIdentifier,
+		config.Logging.RequestBodies,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	// Create the connections to close once the server has been drained, stopping the health checker before the
+	// gRPC connections it probes and closing these in the same order they were created
+	connections := []applistener.Connection{
+		{
+			Name:   "health checker",
+			Closer: healthChecker,
+		},
+	}
+
+	// Create the Redis client, if any subsystem uses it
+	var redisClient goredis.UniversalClient
+	if config.UsesRedis() {
+		redisClient = appredis.NewClient(&config.Redis)
+		connections = append(
+			connections, applistener.Connection{
+				Name:   "redis client",
+				Closer: redisClient,
+			},
+		)
+	}
+
+	// Create the rate limiter
+	var rateLimiter *appratelimit.Limiter
+	if config.RateLimit.Enabled {
+		var rateLimitStore appratelimit.Store
+		if config.RateLimit.Backend == appratelimit.RedisBackend {
+			rateLimitStore, err = appratelimit.NewRedisStore(redisClient)
+			if err != nil {
+				panic(err)
+			}
+		} else {
+			memoryStore := appratelimit.NewMemoryStore()
+			rateLimitStore = memoryStore
+			connections = append(
+				connections, applistener.Connection{
+					Name:   "rate limit store",
+					Closer: memoryStore,
+				},
+			)
+		}
+
+		rateLimiter, err = appratelimit.NewLimiter(
+			rateLimitStore,
+			jwtIdentifier,
+			config.RateLimit.DefaultLimit(),
+			config.RateLimit.RouteLimits(),
+			[]string{apphealth.LivenessRouteKey, apphealth.ReadinessRouteKey},
+			applogger.RateLimitLogger,
+		)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	// Create the log-in guard
+	var logInGuard *appbruteforce.Guard
+	if config.LogIn.Enabled {
+		logInGuard, err = appbruteforce.NewGuard(
+			config.LogIn.Username.Policy(),
+			config.LogIn.IP.Policy(),
+			config.Listener.TrustsClientIP(),
+			applogger.LogInGuardLogger,
+		)
+		if err != nil {
+			panic(err)
+		}
+		connections = append(
+			connections, applistener.Connection{
+				Name:   "log-in guard",
+				Closer: logInGuard,
+			},
+		)
+	}
+
+	// Create the idempotency middleware
+	var idempotencyMiddleware *appidempotency.Middleware
+	if config.Idempotency.Enabled {
+		var idempotencyStore appidempotency.Store
+		if config.Idempotency.Backend == appidempotency.RedisBackend {
+			idempotencyStore, err = appidempotency.NewRedisStore(redisClient)
+			if err != nil {
+				panic(err)
+			}
+		} else {
+			memoryStore := appidempotency.NewMemoryStore()
+			idempotencyStore = memoryStore
+			connections = append(
+				connections, applistener.Connection{
+					Name:   "idempotency store",
+					Closer: memoryStore,
+				},
+			)
+		}
+
+		idempotencyMiddleware, err = appidempotency.NewMiddleware(
+			idempotencyStore,
+			jwtIdentifier,
+			config.Idempotency.Routes,
+			config.Idempotency.TTL.Duration(),
+			config.Idempotency.LockTTL.Duration(),
+			config.Idempotency.Required,
+			applogger.IdempotencyLogger,
+		)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	// Create the response cache, storing the responses in memory if enabled
+	var cacheStore *appcache.MemoryStore
+	if config.Cache.Enabled {
+		cacheStore, err = appcache.NewMemoryStore(config.Cache.MaxEntries, config.Cache.MaxBytes)
+		if err != nil {
+			panic(err)
+		}
+	}
+	responseCache, err := appcache.NewCache(
+		cacheStore,
+		jwtIdentifier,
+		config.Cache.RoutePolicies(),
+		config.Cache.Invalidations,
+		applogger.CacheLogger,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	// Create the codec of the responses, which are encoded as JSON or binary protobuf as negotiated with the client,
+	// and of the JSON request bodies
+	responseCodec := appcodec.NewCodec(
+		appcodec.Options{
+			ProtoJSON:      config.JSON.ProtoJSON,
+			UseProtoNames:  config.JSON.UseProtoNames,
+			EmitDefaults:   config.JSON.EmitDefaults,
+			EnumsAsNumbers: config.JSON.EnumsAsNumbers,
+		},
+	)
+
+	// Create the response handler of the API, which answers the failures of the gateway itself
+	responseHandler, err := appresponse.NewHandler(responseCodec, responseCache, idempotencyMiddleware)
+	if err != nil {
+		panic(err)
+	}
+
+	// Create the body middleware, which bounds the size of the request bodies of every route
+	bodyMiddleware, err := appbody.NewMiddleware(config.Body.MaxBytes, config.Body.Routes, config.Body.Strict)
+	if err != nil {
+		panic(err)
+	}
+
+	// Create the validator of the request bodies, checking the declared rules match the request messages
+	validator, err := appvalidation.NewValidator(appvalidationrules.All()...)
+	if err != nil {
+		panic(err)
+	}
+
+	// Create the timeout middleware, which sets the deadline of the backend calls of every route
+	timeoutMiddleware, err := apptimeout.NewMiddleware(
+		config.Timeouts.Default.Duration(),
+		config.Timeouts.RouteTimeouts(),
 	)
 	if err != nil {
 		panic(err)
 	}
 
-	// Gin router
-	router := gin.Default()
+	// Create the compression middleware
+	var compressionMiddleware *appcompression.Middleware
+	if config.Compression.Enabled {
+		compressionMiddleware, err = appcompression.NewMiddleware(
+			config.Compression.MinBytes,
+			config.Compression.ContentTypes,
+			config.Compression.GzipLevel,
+			config.Compression.BrotliLevel,
+		)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	// Create the CORS middleware
+	corsMiddleware, err := appcors.NewMiddleware(config.CORS.Default.Policy(), config.CORS.GroupPolicies())
+	if err != nil {
+		panic(err)
+	}
+
+	// Gin router, whose default logger is replaced by the structured access logs
+	router := gin.New()
+	router.Use(gin.CustomRecovery(appproblem.Recovery))
+
+	// Answer the unknown routes and the methods a route does not allow with problem details
+	router.HandleMethodNotAllowed = true
+	router.NoRoute(appproblem.NoRoute)
+	router.NoMethod(appproblem.NoMethod)
+
+	// Only trust the X-Forwarded-For headers of the configured proxies, since the IP of the client identifies the
+	// anonymous callers of the rate limits and the log-in lockouts
+	if err = router.SetTrustedProxies(config.Listener.TrustedProxies); err != nil {
+		panic(err)
+	}
+	router.TrustedPlatform = config.Listener.TrustedPlatform
+
+	// Compress the responses, which must wrap the response writer before any other middleware so they see the
+	// uncompressed body
+	if compressionMiddleware != nil {
+		router.Use(compressionMiddleware.Handler())
+	}
+
+	// Accept or generate the request ID, which must be the first global middleware after the recovery and the
+	// compression
+	router.Use(apprequestid.Middleware())
+
+	// Log every request
+	router.Use(accessLogMiddleware.Log())
+
+	// Trace every request
+	router.Use(tracer.HTTPMiddleware())
+
+	// Record the metrics of every request
+	router.Use(metrics.HTTPMiddleware())
 
 	// Set up CORS middleware
-	router.Use(cors.Default())
+	router.Use(corsMiddleware.Handler())
 
 	// Added secure headers middleware
 	router.Use(commonheader.SecurityHeaders())
 
+	// Limit the rate of the requests of every client
+	if rateLimiter != nil {
+		router.Use(rateLimiter.Middleware())
+	}
+
+	// Bound the size and check the content type of the request body, before any middleware reads it
+	router.Use(bodyMiddleware.Handler())
+
+	// Set the deadline of the request
+	router.Use(timeoutMiddleware.Handler())
+
+	// Evict the cached responses invalidated by the successful writes
+	if config.Cache.Enabled {
+		router.Use(responseCache.Invalidator())
+	}
+
+	// Decode the JSON request bodies like their responses are encoded
+	router.Use(responseCodec.Middleware())
+
+	// Validate the request bodies before calling the backend services
+	router.Use(validator.Middleware())
+
 	// Use ginSwagger middleware to serve the API docs
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// Create the health controller
+	healthController, err := apphealth.NewController(router, healthChecker)
+	if err != nil {
+		panic(err)
+	}
+
+	// Initialize the liveness and readiness probes
+	healthController.Initialize()
+
+	// Expose the resolved configuration in development mode
+	if commonflag.Mode.IsDev() {
+		configController, err := appconfig.NewController(router, config)
+		if err != nil {
+			panic(err)
+		}
+		configController.Initialize()
+	}
+
+	// Expose the state of the circuit breakers in development mode
+	if commonflag.Mode.IsDev() && breakers != nil {
+		breakersController, err := appbreaker.NewController(router, breakers)
+		if err != nil {
+			panic(err)
+		}
+		breakersController.Initialize()
+	}
+
 	// Create the API controller
 	mainController := appapi.NewController(
 		router, authMiddleware, responseHandler,
@@ -281,30 +663,74 @@ func main() {
 	v1Controller := mainController.InitializeV1()
 
 	// Initialize the API version 1 children controllers
-	v1Controller.InitializeAuth(authClient)
+	v1Controller.InitializeAuth(authClient, logInGuard, config.LogIn.AdminRole)
 	v1Controller.InitializeUsers(userClient)
 	v1Controller.InitializePayments(paymentClient)
 	v1Controller.InitializeShops(shopClient)
 	v1Controller.InitializeOrders(orderClient)
 
-	// Run the server
-	if err = router.Run(servicePort.FormattedPort); err != nil {
+	// Check the declared path parameters of the routes match the endpoints they are registered with
+	if err = appparams.Check(appparams.Routes, appparams.Registered()); err != nil {
 		panic(err)
 	}
-	applogger.ListenerLogger.ServerStarted(servicePort.Port)
 
-	/*
-		// Run the server
-		if commonflag.Mode.IsProd() {
-			if err = router.Run(servicePort.FormattedPort); err != nil {
-				panic(err)
-			}
-			applogger.ListenerLogger.ServerStarted(servicePort.Port)
-		} else {
-			// Start the server with HTTPS
-			if err = router.RunTLS(servicePort.FormattedPort, app.ServerCertPath, app.ServerKeyPath); err != nil {
-				panic(err)
-			}
+	// Check the route keys of the configuration match the registered routes
+	if err = config.CheckRoutes(router.Routes()); err != nil {
+		panic(err)
+	}
+
+	// Start the metrics listener, which is stopped after the in-flight requests have been drained
+	if config.Metrics.Enabled {
+		metricsServer, err := appmetrics.NewServer(
+			config.Metrics.FormattedAddress(config.Listener.Host),
+			applogger.MetricsLogger,
+		)
+		if err != nil {
+			panic(err)
 		}
-	*/
+		if err = metricsServer.Start(); err != nil {
+			panic(err)
+		}
+
+		connections = append(
+			connections, applistener.Connection{
+				Name:   "metrics server",
+				Closer: metricsServer,
+			},
+		)
+	}
+	for _, uriKey := range uriKeys {
+		connections = append(
+			connections, applistener.Connection{
+				Name:   uriKey,
+				Closer: conns[uriKey],
+			},
+		)
+	}
+
+	// Flush the spans once the gRPC connections have been closed
+	connections = append(
+		connections, applistener.Connection{
+			Name:   "tracer",
+			Closer: tracer,
+		},
+	)
+
+	// Create the server
+	server, err := applistener.NewServer(
+		router,
+		config.Listener.Port,
+		config.Listener.FormattedAddress(),
+		config.Listener.ShutdownTimeout.Duration(),
+		connections,
+		applogger.ListenerLogger,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	// Run the server until a shutdown signal is received
+	if err = server.Run(); err != nil {
+		os.Exit(1)
+	}
 }